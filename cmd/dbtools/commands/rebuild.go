@@ -34,6 +34,27 @@ func runRebuild() error {
 	models := []interface{}{
 		&dbmodels.User{},
 		&dbmodels.Source{},
+		&dbmodels.RotatedToken{},
+		&dbmodels.Center{},
+		&dbmodels.CenterMembership{},
+		&dbmodels.OpeningHours{},
+		&dbmodels.CenterService{},
+		&dbmodels.CenterResource{},
+		&dbmodels.AvailabilityRule{},
+		&dbmodels.TimeBlock{},
+		&dbmodels.AppointmentSeries{},
+		&dbmodels.Appointment{},
+		&dbmodels.CalendarFeed{},
+		&dbmodels.ExternalCalendar{},
+		&dbmodels.Lead{},
+		&dbmodels.LeadConsent{},
+		&dbmodels.SlotHold{},
+		&dbmodels.WaitlistEntry{},
+		&dbmodels.WaitlistOffer{},
+		&dbmodels.AppointmentReview{},
+		&dbmodels.AccountToken{},
+		&dbmodels.RecoveryCode{},
+		&dbmodels.LoginThrottle{},
 	}
 
 	// Drop all tables
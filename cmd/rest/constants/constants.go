@@ -3,7 +3,15 @@ package constants
 // Auth
 
 var (
-	UserIDClaimKey   = "user_id"
-	EmailClaimKey    = "email"
-	SourceIDClaimKey = "source_id"
+	UserIDClaimKey        = "user_id"
+	EmailClaimKey         = "email"
+	SourceIDClaimKey      = "source_id"
+	EmailVerifiedClaimKey = "email_verified"
+)
+
+// Centers
+
+var (
+	CenterIDKey   = "center_id"
+	CenterRoleKey = "center_role"
 )
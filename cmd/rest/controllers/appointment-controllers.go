@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondAppointmentError maps appointment errors to their HTTP status,
+// anything else is a 500
+func respondAppointmentError(ctx *gin.Context, err error, fallbackMessage string) {
+	var transitionErr *domain.AppointmentTransitionError
+	var domainErr *domain.DomainError
+	switch {
+	case errors.As(err, &domainErr):
+		ctx.JSON(domainErr.HTTPCode, helpers.BuildDomainErrorResponse(domainErr))
+	case errors.As(err, &transitionErr):
+		response := helpers.BuildErrorResponse(transitionErr.Err.Error())
+		response["from"] = transitionErr.From
+		response["to"] = transitionErr.To
+		ctx.JSON(http.StatusConflict, response)
+	case errors.Is(err, exceptions.ErrAppointmentNotFound), errors.Is(err, exceptions.ErrCenterServiceNotFound), errors.Is(err, exceptions.ErrCenterResourceNotFound), errors.Is(err, exceptions.ErrMembershipNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentStatusChanged), errors.Is(err, exceptions.ErrAppointmentNotReschedulable):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentInvalidStatus):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrCenterPermissionDenied):
+		ctx.JSON(http.StatusForbidden, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+// parseAppointmentFilter reads the optional staff, status, from and to query
+// parameters
+func parseAppointmentFilter(ctx *gin.Context) (*domain.AppointmentFilter, error) {
+	filter := &domain.AppointmentFilter{}
+	if staff := ctx.Query("staff"); staff != "" {
+		staffID, err := uuid.Parse(staff)
+		if err != nil {
+			return nil, err
+		}
+		filter.StaffID = &staffID
+	}
+	if status := ctx.Query("status"); status != "" {
+		appointmentStatus := domain.AppointmentStatus(status)
+		filter.Status = &appointmentStatus
+	}
+
+	var err error
+	filter.From, err = parseTimeQuery(ctx, "from")
+	if err != nil {
+		return nil, err
+	}
+	filter.To, err = parseTimeQuery(ctx, "to")
+	if err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func ListAppointmentsController(ctx *gin.Context, appointmentService ports.AppointmentService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	filter, err := parseAppointmentFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	appointments, err := appointmentService.List(ctx.Request.Context(), centerCtx.CenterID, filter)
+	if err != nil {
+		respondAppointmentError(ctx, err, "Failed to list appointments")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointments))
+}
+
+func GetAppointmentController(ctx *gin.Context, appointmentService ports.AppointmentService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	appointmentID, err := uuid.Parse(ctx.Param("appointmentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	appointment, err := appointmentService.Get(ctx.Request.Context(), centerCtx.CenterID, appointmentID)
+	if err != nil {
+		respondAppointmentError(ctx, err, "Failed to get appointment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointment))
+}
+
+func CreateAppointmentController(ctx *gin.Context, appointmentService ports.AppointmentService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.CreateAppointmentInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	appointment, err := appointmentService.Create(ctx.Request.Context(), actor, &request)
+	if err != nil {
+		respondAppointmentError(ctx, err, "Failed to create appointment")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(appointment))
+}
+
+func UpdateAppointmentController(ctx *gin.Context, appointmentService ports.AppointmentService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	appointmentID, err := uuid.Parse(ctx.Param("appointmentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.UpdateAppointmentInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	appointment, err := appointmentService.Update(ctx.Request.Context(), actor, appointmentID, &request)
+	if err != nil {
+		respondAppointmentError(ctx, err, "Failed to update appointment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointment))
+}
+
+func ChangeAppointmentStatusController(ctx *gin.Context, appointmentService ports.AppointmentService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	appointmentID, err := uuid.Parse(ctx.Param("appointmentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.AppointmentStatusInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	appointment, err := appointmentService.ChangeStatus(ctx.Request.Context(), actor, appointmentID, &request)
+	if err != nil {
+		respondAppointmentError(ctx, err, "Failed to change appointment status")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointment))
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// linkTokenHeader carries the token of a self-service appointment link
+const linkTokenHeader = "X-Link-Token"
+
+// respondAppointmentLinkError maps link errors to their HTTP status, an
+// expired or revoked link is gone for good
+func respondAppointmentLinkError(ctx *gin.Context, err error, fallbackMessage string) {
+	var transitionErr *domain.AppointmentTransitionError
+	var domainErr *domain.DomainError
+	switch {
+	case errors.As(err, &domainErr):
+		ctx.JSON(domainErr.HTTPCode, helpers.BuildDomainErrorResponse(domainErr))
+	case errors.As(err, &transitionErr):
+		response := helpers.BuildErrorResponse(transitionErr.Err.Error())
+		response["from"] = transitionErr.From
+		response["to"] = transitionErr.To
+		ctx.JSON(http.StatusConflict, response)
+	case errors.Is(err, exceptions.ErrAppointmentLinkInvalid), errors.Is(err, exceptions.ErrAppointmentNotFound),
+		errors.Is(err, exceptions.ErrCenterNotFound), errors.Is(err, exceptions.ErrCenterServiceNotFound),
+		errors.Is(err, exceptions.ErrMembershipNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentLinkExpired), errors.Is(err, exceptions.ErrAppointmentLinkRevoked):
+		ctx.JSON(http.StatusGone, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentLinkWrongPurpose), errors.Is(err, exceptions.ErrCenterPermissionDenied):
+		ctx.JSON(http.StatusForbidden, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentLinkUnavailable), errors.Is(err, exceptions.ErrCancellationWindowClosed),
+		errors.Is(err, exceptions.ErrAppointmentStatusChanged), errors.Is(err, exceptions.ErrSlotUnavailable):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrSlotInvalidRange), errors.Is(err, exceptions.ErrSlotRangeTooLarge):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+func IssueAppointmentLinksController(ctx *gin.Context, linkService ports.AppointmentLinkService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	appointmentID, err := uuid.Parse(ctx.Param("appointmentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	links, err := linkService.Issue(ctx.Request.Context(), actor, appointmentID)
+	if err != nil {
+		respondAppointmentLinkError(ctx, err, "Failed to issue appointment links")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(links))
+}
+
+func RevokeAppointmentLinksController(ctx *gin.Context, linkService ports.AppointmentLinkService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	appointmentID, err := uuid.Parse(ctx.Param("appointmentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = linkService.Revoke(ctx.Request.Context(), actor, appointmentID)
+	if err != nil {
+		respondAppointmentLinkError(ctx, err, "Failed to revoke appointment links")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Appointment links revoked"})
+}
+
+func GetLinkedAppointmentController(ctx *gin.Context, linkService ports.AppointmentLinkService) {
+	appointment, err := linkService.Get(ctx.Request.Context(), ctx.GetHeader(linkTokenHeader))
+	if err != nil {
+		respondAppointmentLinkError(ctx, err, "Failed to get appointment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointment))
+}
+
+func ConfirmLinkedAppointmentController(ctx *gin.Context, linkService ports.AppointmentLinkService) {
+	appointment, err := linkService.Confirm(ctx.Request.Context(), ctx.GetHeader(linkTokenHeader))
+	if err != nil {
+		respondAppointmentLinkError(ctx, err, "Failed to confirm appointment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointment))
+}
+
+func CancelLinkedAppointmentController(ctx *gin.Context, linkService ports.AppointmentLinkService) {
+	var request domain.CancelAppointmentLinkInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	appointment, err := linkService.Cancel(ctx.Request.Context(), ctx.GetHeader(linkTokenHeader), &request)
+	if err != nil {
+		respondAppointmentLinkError(ctx, err, "Failed to cancel appointment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointment))
+}
+
+func ListLinkedAppointmentSlotsController(ctx *gin.Context, linkService ports.AppointmentLinkService) {
+	from, err := parseTimeQuery(ctx, "from")
+	if err != nil || from == nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+	to, err := parseTimeQuery(ctx, "to")
+	if err != nil || to == nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	slots, err := linkService.FindSlots(ctx.Request.Context(), ctx.GetHeader(linkTokenHeader), *from, *to)
+	if err != nil {
+		respondAppointmentLinkError(ctx, err, "Failed to list slots")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(slots))
+}
+
+func RescheduleLinkedAppointmentController(ctx *gin.Context, linkService ports.AppointmentLinkService) {
+	var request domain.RescheduleAppointmentLinkInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	appointment, err := linkService.Reschedule(ctx.Request.Context(), ctx.GetHeader(linkTokenHeader), &request)
+	if err != nil {
+		respondAppointmentLinkError(ctx, err, "Failed to reschedule appointment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointment))
+}
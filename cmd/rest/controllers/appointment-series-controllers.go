@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondAppointmentSeriesError maps series errors to their HTTP status, the
+// rest are appointment errors
+func respondAppointmentSeriesError(ctx *gin.Context, err error, fallbackMessage string) {
+	var conflictErr *domain.SeriesConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		response := helpers.BuildErrorResponse(conflictErr.Err.Error())
+		response["conflicts"] = conflictErr.Conflicts
+		ctx.JSON(http.StatusConflict, response)
+	case errors.Is(err, exceptions.ErrAppointmentSeriesNotFound), errors.Is(err, exceptions.ErrAppointmentOccurrenceNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentSeriesInvalidRule), errors.Is(err, exceptions.ErrAppointmentSeriesEmpty), errors.Is(err, exceptions.ErrAppointmentSeriesInvalidScope), errors.Is(err, exceptions.ErrAppointmentSeriesRuleScope), errors.Is(err, exceptions.ErrAppointmentOccurrenceRange):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentSeriesCancelled), errors.Is(err, exceptions.ErrAppointmentOccurrenceExists):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	default:
+		respondAppointmentError(ctx, err, fallbackMessage)
+	}
+}
+
+// parseOccurrenceParams reads the series id and the occurrence date of the path
+func parseOccurrenceParams(ctx *gin.Context) (uuid.UUID, domain.Date, error) {
+	seriesID, err := uuid.Parse(ctx.Param("seriesId"))
+	if err != nil {
+		return uuid.Nil, domain.Date{}, err
+	}
+	date, err := domain.ParseDate(ctx.Param("date"))
+	if err != nil {
+		return uuid.Nil, domain.Date{}, err
+	}
+	return seriesID, date, nil
+}
+
+func CreateAppointmentSeriesController(ctx *gin.Context, seriesService ports.AppointmentSeriesService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.CreateAppointmentSeriesInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	series, err := seriesService.Create(ctx.Request.Context(), actor, &request)
+	if err != nil {
+		respondAppointmentSeriesError(ctx, err, "Failed to create appointment series")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(series))
+}
+
+func GetAppointmentSeriesController(ctx *gin.Context, seriesService ports.AppointmentSeriesService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	seriesID, err := uuid.Parse(ctx.Param("seriesId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	series, err := seriesService.Get(ctx.Request.Context(), centerCtx.CenterID, seriesID)
+	if err != nil {
+		respondAppointmentSeriesError(ctx, err, "Failed to get appointment series")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(series))
+}
+
+// ListOccurrencesController lists the occurrences of every series, or of the
+// series of the path, between the required from and to query parameters
+func ListOccurrencesController(ctx *gin.Context, seriesService ports.AppointmentSeriesService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	filter := &domain.OccurrenceFilter{}
+	if param := ctx.Param("seriesId"); param != "" {
+		seriesID, err := uuid.Parse(param)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+			return
+		}
+		filter.SeriesID = &seriesID
+	}
+	if staff := ctx.Query("staff"); staff != "" {
+		staffID, err := uuid.Parse(staff)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+			return
+		}
+		filter.StaffID = &staffID
+	}
+	from, err := parseTimeQuery(ctx, "from")
+	if err != nil || from == nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(exceptions.ErrAppointmentOccurrenceRange.Error()))
+		return
+	}
+	to, err := parseTimeQuery(ctx, "to")
+	if err != nil || to == nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(exceptions.ErrAppointmentOccurrenceRange.Error()))
+		return
+	}
+	filter.From, filter.To = *from, *to
+
+	occurrences, err := seriesService.ListOccurrences(ctx.Request.Context(), centerCtx.CenterID, filter)
+	if err != nil {
+		respondAppointmentSeriesError(ctx, err, "Failed to list occurrences")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(occurrences))
+}
+
+func UpdateOccurrenceController(ctx *gin.Context, seriesService ports.AppointmentSeriesService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	seriesID, date, err := parseOccurrenceParams(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.UpdateOccurrenceInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	occurrence, err := seriesService.UpdateOccurrence(ctx.Request.Context(), actor, seriesID, date, &request)
+	if err != nil {
+		respondAppointmentSeriesError(ctx, err, "Failed to update occurrence")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(occurrence))
+}
+
+func CancelOccurrenceController(ctx *gin.Context, seriesService ports.AppointmentSeriesService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	seriesID, date, err := parseOccurrenceParams(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.CancelOccurrenceInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	err = seriesService.CancelOccurrence(ctx.Request.Context(), actor, seriesID, date, &request)
+	if err != nil {
+		respondAppointmentSeriesError(ctx, err, "Failed to cancel occurrence")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Occurrence cancelled"})
+}
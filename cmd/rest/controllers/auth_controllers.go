@@ -1,7 +1,10 @@
 package controllers
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"bifur.app/core/cmd/rest/constants"
@@ -42,9 +45,11 @@ func RefreshTokenController(ctx *gin.Context, authService ports.AuthService) {
 		return
 	}
 
-	response, err := authService.RefreshToken(ctx.Request.Context(), request.RefreshToken)
+	meta := helpers.GetRequestMetadata(ctx)
+
+	response, err := authService.RefreshToken(ctx.Request.Context(), request.RefreshToken, meta.UserAgent, meta.IPAddress)
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "expired") {
+		if errors.Is(err, exceptions.ErrAuthRefreshTokenReused) || strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "expired") {
 			ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse(err.Error()))
 			return
 		}
@@ -89,6 +94,9 @@ func LoginController(ctx *gin.Context, authService ports.AuthService) {
 
 	response, err := authService.Login(ctx.Request.Context(), &login, meta.UserAgent, meta.IPAddress)
 	if err != nil {
+		if respondLoginThrottled(ctx, err) {
+			return
+		}
 		if err.Error() == exceptions.ErrAuthInvalidCredentials.Error() {
 			ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse(err.Error()))
 			return
@@ -100,6 +108,25 @@ func LoginController(ctx *gin.Context, authService ports.AuthService) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// respondLoginThrottled answers a throttled login attempt with 423 when the
+// account is locked and 429 otherwise, telling the client when to retry
+func respondLoginThrottled(ctx *gin.Context, err error) bool {
+	var throttledErr *domain.LoginThrottledError
+	if !errors.As(err, &throttledErr) {
+		return false
+	}
+
+	retryAfter := int(math.Ceil(throttledErr.RetryAfter.Seconds()))
+	ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+
+	status := http.StatusTooManyRequests
+	if errors.Is(err, exceptions.ErrAuthAccountLocked) {
+		status = http.StatusLocked
+	}
+	ctx.JSON(status, helpers.BuildErrorResponse(err.Error()))
+	return true
+}
+
 func LogoutController(ctx *gin.Context, authService ports.AuthService) {
 	var request domain.RefreshTokenInput
 	if err := ctx.ShouldBindJSON(&request); err != nil {
@@ -141,3 +168,86 @@ func LogoutAllController(ctx *gin.Context, authService ports.AuthService) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Successfully logged out from all devices"})
 }
+
+// ForgotPasswordController always answers with success, whether the email
+// belongs to an account or not
+func ForgotPasswordController(ctx *gin.Context, authService ports.AuthService) {
+	var request domain.ForgotPasswordInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	err := authService.ForgotPassword(ctx.Request.Context(), request.Email)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse("Failed to request password reset"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "If the email belongs to an account, a reset link has been sent"})
+}
+
+func ResetPasswordController(ctx *gin.Context, authService ports.AuthService) {
+	var request domain.ResetPasswordInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	err := authService.ResetPassword(ctx.Request.Context(), request.Token, request.Password)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrAuthInvalidResetToken) {
+			ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse("Failed to reset password"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Password has been reset"})
+}
+
+func VerifyEmailController(ctx *gin.Context, authService ports.AuthService) {
+	var request domain.VerifyEmailInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	err := authService.VerifyEmail(ctx.Request.Context(), request.Token)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrAuthInvalidVerifyToken) {
+			ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse("Failed to verify email"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Email address verified"})
+}
+
+// ResendVerificationController always answers with success, whether the email
+// belongs to an unverified account or not
+func ResendVerificationController(ctx *gin.Context, authService ports.AuthService) {
+	var request domain.ResendVerificationInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	err := authService.ResendVerification(ctx.Request.Context(), request.Email)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse("Failed to resend verification email"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "If the email belongs to an unverified account, a verification link has been sent"})
+}
+
+// JWKSController publishes the public keys that verify access tokens, so
+// other services can authenticate requests without being able to mint tokens
+func JWKSController(ctx *gin.Context, authService ports.AuthService) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, authService.JWKS(ctx.Request.Context()))
+}
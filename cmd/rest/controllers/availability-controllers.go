@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondAvailabilityError maps availability errors to their HTTP status,
+// anything else is a 500
+func respondAvailabilityError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrAvailabilityRuleNotFound), errors.Is(err, exceptions.ErrMembershipNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAvailabilityOverlap):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAvailabilityInvalidInterval):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+func ListAvailabilityController(ctx *gin.Context, availabilityService ports.AvailabilityService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	staffID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	rules, err := availabilityService.List(ctx.Request.Context(), centerCtx.CenterID, staffID)
+	if err != nil {
+		respondAvailabilityError(ctx, err, "Failed to list availability")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(rules))
+}
+
+func CreateAvailabilityController(ctx *gin.Context, availabilityService ports.AvailabilityService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	staffID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.AvailabilityRuleInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	rule, err := availabilityService.Create(ctx.Request.Context(), centerCtx.CenterID, staffID, &request)
+	if err != nil {
+		respondAvailabilityError(ctx, err, "Failed to create availability")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(rule))
+}
+
+func UpdateAvailabilityController(ctx *gin.Context, availabilityService ports.AvailabilityService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	staffID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("ruleId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.AvailabilityRuleInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	rule, err := availabilityService.Update(ctx.Request.Context(), centerCtx.CenterID, staffID, ruleID, &request)
+	if err != nil {
+		respondAvailabilityError(ctx, err, "Failed to update availability")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(rule))
+}
+
+func DeleteAvailabilityController(ctx *gin.Context, availabilityService ports.AvailabilityService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	staffID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("ruleId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = availabilityService.Delete(ctx.Request.Context(), centerCtx.CenterID, staffID, ruleID)
+	if err != nil {
+		respondAvailabilityError(ctx, err, "Failed to delete availability")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Availability deleted"})
+}
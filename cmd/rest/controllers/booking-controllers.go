@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// holdTokenHeader carries the token returned along with a slot hold
+const holdTokenHeader = "X-Hold-Token"
+
+// respondBookingError maps booking errors to their HTTP status, an expired
+// hold is gone for good
+func respondBookingError(ctx *gin.Context, err error, fallbackMessage string) {
+	var domainErr *domain.DomainError
+	switch {
+	case errors.As(err, &domainErr):
+		ctx.JSON(domainErr.HTTPCode, helpers.BuildDomainErrorResponse(domainErr))
+	case errors.Is(err, exceptions.ErrCenterNotFound), errors.Is(err, exceptions.ErrCenterServiceNotFound),
+		errors.Is(err, exceptions.ErrMembershipNotFound), errors.Is(err, exceptions.ErrSlotHoldNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrSlotUnavailable):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrSlotHoldExpired):
+		ctx.JSON(http.StatusGone, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrSlotHoldLimit):
+		ctx.JSON(http.StatusTooManyRequests, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrSlotInvalidRange), errors.Is(err, exceptions.ErrSlotRangeTooLarge),
+		errors.Is(err, exceptions.ErrLeadMissingContact), errors.Is(err, exceptions.ErrLeadInvalidEmail),
+		errors.Is(err, exceptions.ErrLeadInvalidPhone), errors.Is(err, exceptions.ErrConsentInvalidPurpose):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+func GetBookingCenterController(ctx *gin.Context, bookingService ports.BookingService) {
+	center, err := bookingService.GetCenter(ctx.Request.Context(), ctx.Param("slug"))
+	if err != nil {
+		respondBookingError(ctx, err, "Failed to get center")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(center))
+}
+
+func ListBookingSlotsController(ctx *gin.Context, bookingService ports.BookingService) {
+	query, err := parseSlotQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	slots, err := bookingService.FindSlots(ctx.Request.Context(), ctx.Param("slug"), query)
+	if err != nil {
+		respondBookingError(ctx, err, "Failed to list slots")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(slots))
+}
+
+func HoldSlotController(ctx *gin.Context, bookingService ports.BookingService) {
+	var request domain.HoldSlotInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	metadata := helpers.GetRequestMetadata(ctx)
+	hold, err := bookingService.Hold(ctx.Request.Context(), ctx.Param("slug"), &request, metadata.IPAddress)
+	if err != nil {
+		respondBookingError(ctx, err, "Failed to hold slot")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(hold))
+}
+
+func ReleaseSlotHoldController(ctx *gin.Context, bookingService ports.BookingService) {
+	holdID, err := uuid.Parse(ctx.Param("holdId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = bookingService.Release(ctx.Request.Context(), ctx.Param("slug"), holdID, ctx.GetHeader(holdTokenHeader))
+	if err != nil {
+		respondBookingError(ctx, err, "Failed to release slot")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Slot released"})
+}
+
+func ConfirmBookingController(ctx *gin.Context, bookingService ports.BookingService) {
+	holdID, err := uuid.Parse(ctx.Param("holdId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.ConfirmBookingInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	metadata := helpers.GetRequestMetadata(ctx)
+	booking, err := bookingService.Confirm(ctx.Request.Context(), ctx.Param("slug"), holdID, ctx.GetHeader(holdTokenHeader), &request, metadata.UserAgent, metadata.IPAddress)
+	if err != nil {
+		respondBookingError(ctx, err, "Failed to confirm booking")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(booking))
+}
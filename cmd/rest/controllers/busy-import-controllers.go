@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/cmd/rest/middleware"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondBusyImportError maps import errors to their HTTP status, a calendar
+// that can't be fetched is a failure of the calendar's server
+func respondBusyImportError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrExternalCalendarNotFound), errors.Is(err, exceptions.ErrMembershipNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrExternalCalendarInvalidURL), errors.Is(err, exceptions.ErrExternalCalendarInvalidData):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrExternalCalendarTooLarge):
+		ctx.JSON(http.StatusRequestEntityTooLarge, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrExternalCalendarUnreachable):
+		ctx.JSON(http.StatusBadGateway, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+// importRequest returns the center and the staff member of the route
+func importRequest(ctx *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	staffID, err := uuid.Parse(ctx.Param(middleware.StaffIDParam))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return centerCtx.CenterID, staffID, true
+}
+
+// ImportBusyTimesController reads the .ics file from the "file" field of a
+// multipart form
+func ImportBusyTimesController(ctx *gin.Context, importService ports.BusyImportService) {
+	centerID, staffID, ok := importRequest(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+	defer file.Close()
+
+	result, err := importService.ImportFile(ctx.Request.Context(), centerID, staffID, file)
+	if err != nil {
+		respondBusyImportError(ctx, err, "Failed to import busy times")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(result))
+}
+
+func GetExternalCalendarController(ctx *gin.Context, importService ports.BusyImportService) {
+	centerID, staffID, ok := importRequest(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	calendar, err := importService.GetCalendar(ctx.Request.Context(), centerID, staffID)
+	if err != nil {
+		respondBusyImportError(ctx, err, "Failed to get external calendar")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(calendar))
+}
+
+func SetExternalCalendarController(ctx *gin.Context, importService ports.BusyImportService) {
+	centerID, staffID, ok := importRequest(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.ExternalCalendarInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	calendar, err := importService.SetCalendar(ctx.Request.Context(), centerID, staffID, &request)
+	if err != nil {
+		respondBusyImportError(ctx, err, "Failed to save external calendar")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(calendar))
+}
+
+func DeleteExternalCalendarController(ctx *gin.Context, importService ports.BusyImportService) {
+	centerID, staffID, ok := importRequest(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err := importService.DeleteCalendar(ctx.Request.Context(), centerID, staffID)
+	if err != nil {
+		respondBusyImportError(ctx, err, "Failed to delete external calendar")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "External calendar deleted"})
+}
+
+func SyncExternalCalendarController(ctx *gin.Context, importService ports.BusyImportService) {
+	centerID, staffID, ok := importRequest(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	result, err := importService.SyncCalendar(ctx.Request.Context(), centerID, staffID)
+	if err != nil {
+		respondBusyImportError(ctx, err, "Failed to sync external calendar")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(result))
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/utils/ical"
+	"github.com/gin-gonic/gin"
+)
+
+func respondCalendarFeedError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrCalendarFeedNotFound), errors.Is(err, exceptions.ErrCenterNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(exceptions.ErrCalendarFeedNotFound.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+func GetCalendarFeedController(ctx *gin.Context, feedService ports.CalendarFeedService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	feed, err := feedService.Get(ctx.Request.Context(), actor)
+	if err != nil {
+		respondCalendarFeedError(ctx, err, "Failed to get calendar feed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(feed))
+}
+
+// RotateCalendarFeedController returns the new feed URL, the only time it is
+// shown
+func RotateCalendarFeedController(ctx *gin.Context, feedService ports.CalendarFeedService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	link, err := feedService.Rotate(ctx.Request.Context(), actor)
+	if err != nil {
+		respondCalendarFeedError(ctx, err, "Failed to create calendar feed")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(link))
+}
+
+func RevokeCalendarFeedController(ctx *gin.Context, feedService ports.CalendarFeedService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = feedService.Revoke(ctx.Request.Context(), actor)
+	if err != nil {
+		respondCalendarFeedError(ctx, err, "Failed to revoke calendar feed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked"})
+}
+
+// CalendarFeedController serves the feed to calendar apps. The token in the
+// path is the only credential, access tokens are not accepted.
+func CalendarFeedController(ctx *gin.Context, feedService ports.CalendarFeedService) {
+	feedToken := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	body, err := feedService.Render(ctx.Request.Context(), feedToken)
+	if err != nil {
+		respondCalendarFeedError(ctx, err, "Failed to render calendar feed")
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Header("Referrer-Policy", "no-referrer")
+	ctx.Header("Content-Disposition", `inline; filename="appointments.ics"`)
+	ctx.Data(http.StatusOK, ical.ContentTypeICal, body)
+}
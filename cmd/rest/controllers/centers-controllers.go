@@ -1,21 +1,41 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 
 	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/cmd/rest/middleware"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
 	"bifur.app/core/internal/ports"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-func GetCentersController(ctx *gin.Context, centersRepository ports.CentersRepository) {
+// respondCenterError maps center errors to their HTTP status, anything else is
+// a 500
+func respondCenterError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrCenterNotFound), errors.Is(err, exceptions.ErrCenterResourceNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrCenterNotDeleted), errors.Is(err, exceptions.ErrCenterSlugTaken):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrCenterInvalidOpeningHours), errors.Is(err, exceptions.ErrCenterInvalidSlug), errors.Is(err, exceptions.ErrCenterInvalidTimezone):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+func GetCentersController(ctx *gin.Context, centersService ports.CentersService) {
 	userCtx, err := helpers.GetUserIdFromRequest(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	centers, err := centersRepository.GetAll(ctx.Request.Context(), userCtx.AsUUID)
+	centers, err := centersService.List(ctx.Request.Context(), userCtx.AsUUID)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -24,3 +44,223 @@ func GetCentersController(ctx *gin.Context, centersRepository ports.CentersRepos
 
 	ctx.JSON(http.StatusOK, centers)
 }
+
+func CreateCenterController(ctx *gin.Context, centersService ports.CentersService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	var request domain.CreateCenterInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	center, err := centersService.Create(ctx.Request.Context(), userCtx.AsUUID, &request)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to create center")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(center))
+}
+
+func InitialSetupController(ctx *gin.Context, centersService ports.CentersService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	var request domain.CenterSetupInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	setup, err := centersService.Setup(ctx.Request.Context(), userCtx.AsUUID, &request)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to set up center")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(setup))
+}
+
+func GetCenterController(ctx *gin.Context, centersService ports.CentersService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	center, err := centersService.Get(ctx.Request.Context(), centerCtx.CenterID)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to get center")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(center))
+}
+
+func UpdateCenterController(ctx *gin.Context, centersService ports.CentersService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.UpdateCenterInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	center, err := centersService.Update(ctx.Request.Context(), centerCtx.CenterID, &request)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to update center")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(center))
+}
+
+func DeleteCenterController(ctx *gin.Context, centersService ports.CentersService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = centersService.Delete(ctx.Request.Context(), centerCtx.CenterID)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to delete center")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Center deleted"})
+}
+
+// RestoreCenterController is not behind the center guard, deleted centers are
+// not visible to it. The service only lets the owner restore the center.
+func RestoreCenterController(ctx *gin.Context, centersService ports.CentersService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	centerID, err := uuid.Parse(ctx.Param(middleware.CenterIDParam))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	center, err := centersService.Restore(ctx.Request.Context(), centerID, userCtx.AsUUID)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to restore center")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(center))
+}
+
+func ListOpeningHoursController(ctx *gin.Context, centersService ports.CentersService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	hours, err := centersService.ListOpeningHours(ctx.Request.Context(), centerCtx.CenterID)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to list opening hours")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(hours))
+}
+
+func ListCenterServicesController(ctx *gin.Context, centersService ports.CentersService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	services, err := centersService.ListServices(ctx.Request.Context(), centerCtx.CenterID)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to list services")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(services))
+}
+
+func ListCenterResourcesController(ctx *gin.Context, centersService ports.CentersService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	resources, err := centersService.ListResources(ctx.Request.Context(), centerCtx.CenterID)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to list resources")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(resources))
+}
+
+func CreateCenterResourceController(ctx *gin.Context, centersService ports.CentersService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.CenterResourceInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	resource, err := centersService.CreateResource(ctx.Request.Context(), centerCtx.CenterID, &request)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to create resource")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(resource))
+}
+
+func UpdateCenterResourceController(ctx *gin.Context, centersService ports.CentersService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	resourceID, err := uuid.Parse(ctx.Param("resourceId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.UpdateCenterResourceInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	resource, err := centersService.UpdateResource(ctx.Request.Context(), centerCtx.CenterID, resourceID, &request)
+	if err != nil {
+		respondCenterError(ctx, err, "Failed to update resource")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(resource))
+}
@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondLeadError maps lead errors to their HTTP status, a duplicate tells
+// which lead has the email or the phone
+func respondLeadError(ctx *gin.Context, err error, fallbackMessage string) {
+	var duplicateErr *domain.DuplicateLeadError
+	switch {
+	case errors.As(err, &duplicateErr):
+		response := helpers.BuildErrorResponse(duplicateErr.Err.Error())
+		response["lead_id"] = duplicateErr.LeadID
+		ctx.JSON(http.StatusConflict, response)
+	case errors.Is(err, exceptions.ErrLeadDuplicate):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrLeadNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrLeadMissingContact), errors.Is(err, exceptions.ErrLeadInvalidEmail),
+		errors.Is(err, exceptions.ErrLeadInvalidPhone), errors.Is(err, exceptions.ErrConsentInvalidPurpose):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+// parseLeadFilter reads the optional q, limit and offset query parameters
+func parseLeadFilter(ctx *gin.Context) (*domain.LeadFilter, error) {
+	filter := &domain.LeadFilter{Query: ctx.Query("q")}
+	var err error
+	if limit := ctx.Query("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		filter.Offset, err = strconv.Atoi(offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func ListLeadsController(ctx *gin.Context, leadService ports.LeadService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	filter, err := parseLeadFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	leads, err := leadService.List(ctx.Request.Context(), centerCtx.CenterID, filter)
+	if err != nil {
+		respondLeadError(ctx, err, "Failed to list leads")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(leads))
+}
+
+func GetLeadController(ctx *gin.Context, leadService ports.LeadService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	leadID, err := uuid.Parse(ctx.Param("leadId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	lead, err := leadService.Get(ctx.Request.Context(), centerCtx.CenterID, leadID)
+	if err != nil {
+		respondLeadError(ctx, err, "Failed to get lead")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(lead))
+}
+
+func CreateLeadController(ctx *gin.Context, leadService ports.LeadService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.CreateLeadInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	metadata := helpers.GetRequestMetadata(ctx)
+	lead, err := leadService.Create(ctx.Request.Context(), actor, &request, metadata.UserAgent, metadata.IPAddress)
+	if err != nil {
+		respondLeadError(ctx, err, "Failed to create lead")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(lead))
+}
+
+func UpdateLeadController(ctx *gin.Context, leadService ports.LeadService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	leadID, err := uuid.Parse(ctx.Param("leadId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.UpdateLeadInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	lead, err := leadService.Update(ctx.Request.Context(), centerCtx.CenterID, leadID, &request)
+	if err != nil {
+		respondLeadError(ctx, err, "Failed to update lead")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(lead))
+}
+
+func DeleteLeadController(ctx *gin.Context, leadService ports.LeadService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	leadID, err := uuid.Parse(ctx.Param("leadId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = leadService.Delete(ctx.Request.Context(), centerCtx.CenterID, leadID)
+	if err != nil {
+		respondLeadError(ctx, err, "Failed to delete lead")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Lead deleted"})
+}
+
+func ListLeadConsentsController(ctx *gin.Context, leadService ports.LeadService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	leadID, err := uuid.Parse(ctx.Param("leadId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	consents, err := leadService.ListConsents(ctx.Request.Context(), centerCtx.CenterID, leadID)
+	if err != nil {
+		respondLeadError(ctx, err, "Failed to list consents")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(consents))
+}
+
+func RecordLeadConsentController(ctx *gin.Context, leadService ports.LeadService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	leadID, err := uuid.Parse(ctx.Param("leadId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.ConsentInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	metadata := helpers.GetRequestMetadata(ctx)
+	consent, err := leadService.RecordConsent(ctx.Request.Context(), actor, leadID, &request, metadata.UserAgent, metadata.IPAddress)
+	if err != nil {
+		respondLeadError(ctx, err, "Failed to record consent")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(consent))
+}
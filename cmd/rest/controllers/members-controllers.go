@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondMembershipError maps membership errors to their HTTP status, anything
+// else is a 500
+func respondMembershipError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrMembershipNotFound), errors.Is(err, exceptions.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrMembershipAlreadyExists), errors.Is(err, exceptions.ErrMembershipOwnerLocked):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrMembershipInvalidRole):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+func ListMembersController(ctx *gin.Context, membershipService ports.MembershipService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	members, err := membershipService.ListMembers(ctx.Request.Context(), centerCtx.CenterID)
+	if err != nil {
+		respondMembershipError(ctx, err, "Failed to list members")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(members))
+}
+
+func AddMemberController(ctx *gin.Context, membershipService ports.MembershipService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.AddMemberInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	member, err := membershipService.AddMember(ctx.Request.Context(), centerCtx.CenterID, &request)
+	if err != nil {
+		respondMembershipError(ctx, err, "Failed to add member")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(member))
+}
+
+func UpdateMemberRoleController(ctx *gin.Context, membershipService ports.MembershipService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.UpdateMemberRoleInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	err = membershipService.UpdateMemberRole(ctx.Request.Context(), centerCtx.CenterID, userID, request.Role)
+	if err != nil {
+		respondMembershipError(ctx, err, "Failed to update member role")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Member role updated"})
+}
+
+func RemoveMemberController(ctx *gin.Context, membershipService ports.MembershipService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	userID, err := uuid.Parse(ctx.Param("userId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = membershipService.RemoveMember(ctx.Request.Context(), centerCtx.CenterID, userID)
+	if err != nil {
+		respondMembershipError(ctx, err, "Failed to remove member")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
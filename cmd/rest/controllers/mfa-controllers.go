@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+)
+
+// respondMFAError maps MFA errors to their HTTP status, anything else is a 500
+func respondMFAError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrMFAInvalidChallenge), errors.Is(err, exceptions.ErrMFAInvalidCode):
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrMFAAlreadyEnabled), errors.Is(err, exceptions.ErrMFANotEnabled), errors.Is(err, exceptions.ErrMFASetupNotStarted):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+// LoginMFAController exchanges the challenge returned by LoginController and a
+// TOTP or recovery code for the session tokens
+func LoginMFAController(ctx *gin.Context, authService ports.AuthService) {
+	var request domain.LoginMFAInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	meta := helpers.GetRequestMetadata(ctx)
+
+	response, err := authService.LoginMFA(ctx.Request.Context(), &request, meta.UserAgent, meta.IPAddress)
+	if err != nil {
+		if respondLoginThrottled(ctx, err) {
+			return
+		}
+		respondMFAError(ctx, err, "Failed to login")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func SetupTOTPController(ctx *gin.Context, authService ports.AuthService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	response, err := authService.SetupTOTP(ctx.Request.Context(), userCtx.AsUUID)
+	if err != nil {
+		respondMFAError(ctx, err, "Failed to set up two-factor authentication")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func ConfirmTOTPController(ctx *gin.Context, authService ports.AuthService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	var request domain.TOTPCodeInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	response, err := authService.ConfirmTOTP(ctx.Request.Context(), userCtx.AsUUID, request.Code)
+	if err != nil {
+		respondMFAError(ctx, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func DisableTOTPController(ctx *gin.Context, authService ports.AuthService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	var request domain.TOTPCodeInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	err = authService.DisableTOTP(ctx.Request.Context(), userCtx.AsUUID, request.Code)
+	if err != nil {
+		respondMFAError(ctx, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+func RegenerateRecoveryCodesController(ctx *gin.Context, authService ports.AuthService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	var request domain.TOTPCodeInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	response, err := authService.RegenerateRecoveryCodes(ctx.Request.Context(), userCtx.AsUUID, request.Code)
+	if err != nil {
+		respondMFAError(ctx, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
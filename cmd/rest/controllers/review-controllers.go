@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondReviewError maps review errors to their HTTP status, the errors of
+// the review links are the ones of the other appointment links
+func respondReviewError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrReviewNotFound), errors.Is(err, exceptions.ErrAppointmentNotFound),
+		errors.Is(err, exceptions.ErrAppointmentLinkInvalid), errors.Is(err, exceptions.ErrCenterNotFound),
+		errors.Is(err, exceptions.ErrCenterServiceNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentLinkExpired), errors.Is(err, exceptions.ErrAppointmentLinkRevoked):
+		ctx.JSON(http.StatusGone, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrAppointmentLinkWrongPurpose), errors.Is(err, exceptions.ErrCenterPermissionDenied):
+		ctx.JSON(http.StatusForbidden, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrReviewAlreadySubmitted), errors.Is(err, exceptions.ErrReviewNotReviewable):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrReviewInvalidRating), errors.Is(err, exceptions.ErrReviewInvalidStatus):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+// parseReviewFilter reads the optional status, staff, limit and offset query
+// parameters
+func parseReviewFilter(ctx *gin.Context) (*domain.ReviewFilter, error) {
+	filter := &domain.ReviewFilter{Status: domain.ReviewStatus(ctx.Query("status"))}
+	if staff := ctx.Query("staff"); staff != "" {
+		staffID, err := uuid.Parse(staff)
+		if err != nil {
+			return nil, err
+		}
+		filter.StaffID = &staffID
+	}
+	var err error
+	if limit := ctx.Query("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		filter.Offset, err = strconv.Atoi(offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func ListReviewsController(ctx *gin.Context, reviewService ports.ReviewService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	filter, err := parseReviewFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	reviews, err := reviewService.List(ctx.Request.Context(), centerCtx.CenterID, filter)
+	if err != nil {
+		respondReviewError(ctx, err, "Failed to list reviews")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(reviews))
+}
+
+func ModerateReviewController(ctx *gin.Context, reviewService ports.ReviewService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	reviewID, err := uuid.Parse(ctx.Param("reviewId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.ModerateReviewInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	review, err := reviewService.Moderate(ctx.Request.Context(), actor, reviewID, &request)
+	if err != nil {
+		respondReviewError(ctx, err, "Failed to moderate review")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(review))
+}
+
+func IssueReviewLinkController(ctx *gin.Context, reviewService ports.ReviewService) {
+	actor, err := helpers.GetCenterMemberFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	appointmentID, err := uuid.Parse(ctx.Param("appointmentId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	link, err := reviewService.IssueLink(ctx.Request.Context(), actor, appointmentID)
+	if err != nil {
+		respondReviewError(ctx, err, "Failed to issue review link")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(link))
+}
+
+func GetReviewableAppointmentController(ctx *gin.Context, reviewService ports.ReviewService) {
+	appointment, err := reviewService.GetReviewable(ctx.Request.Context(), ctx.GetHeader(linkTokenHeader))
+	if err != nil {
+		respondReviewError(ctx, err, "Failed to get appointment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(appointment))
+}
+
+func SubmitReviewController(ctx *gin.Context, reviewService ports.ReviewService) {
+	var request domain.SubmitReviewInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	review, err := reviewService.Submit(ctx.Request.Context(), ctx.GetHeader(linkTokenHeader), &request)
+	if err != nil {
+		respondReviewError(ctx, err, "Failed to submit review")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(review))
+}
+
+func ListPublishedReviewsController(ctx *gin.Context, reviewService ports.ReviewService) {
+	filter, err := parseReviewFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	reviews, err := reviewService.ListPublished(ctx.Request.Context(), ctx.Param("slug"), filter)
+	if err != nil {
+		respondReviewError(ctx, err, "Failed to list reviews")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(reviews))
+}
+
+func GetReviewStatsController(ctx *gin.Context, reviewService ports.ReviewService) {
+	stats, err := reviewService.GetStats(ctx.Request.Context(), ctx.Param("slug"))
+	if err != nil {
+		respondReviewError(ctx, err, "Failed to get review stats")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(stats))
+}
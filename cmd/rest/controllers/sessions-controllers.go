@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func ListSessionsController(ctx *gin.Context, sessionService ports.SessionService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	sessions, err := sessionService.List(ctx.Request.Context(), userCtx.AsUUID, userCtx.SourceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse("Failed to list sessions"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(sessions))
+}
+
+func RevokeSessionController(ctx *gin.Context, sessionService ports.SessionService) {
+	userCtx, err := helpers.GetUserIdFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, helpers.BuildErrorResponse("User not authenticated"))
+		return
+	}
+
+	sessionID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = sessionService.Revoke(ctx.Request.Context(), userCtx.AsUUID, sessionID)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrAuthSourceNotFound) {
+			ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse("Failed to revoke session"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondSlotError maps slot errors to their HTTP status, anything else is a
+// 500
+func respondSlotError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrCenterServiceNotFound), errors.Is(err, exceptions.ErrCenterNotFound), errors.Is(err, exceptions.ErrMembershipNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrSlotInvalidRange), errors.Is(err, exceptions.ErrSlotRangeTooLarge):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+// parseSlotQuery reads the service, staff, from and to query parameters, only
+// staff is optional
+func parseSlotQuery(ctx *gin.Context) (*domain.SlotQuery, error) {
+	serviceID, err := uuid.Parse(ctx.Query("service"))
+	if err != nil {
+		return nil, err
+	}
+
+	query := &domain.SlotQuery{ServiceID: serviceID}
+	if staff := ctx.Query("staff"); staff != "" {
+		staffID, err := uuid.Parse(staff)
+		if err != nil {
+			return nil, err
+		}
+		query.StaffID = &staffID
+	}
+
+	from, err := parseTimeQuery(ctx, "from")
+	if err != nil || from == nil {
+		return nil, domain.ErrBadRequest
+	}
+	to, err := parseTimeQuery(ctx, "to")
+	if err != nil || to == nil {
+		return nil, domain.ErrBadRequest
+	}
+	query.From, query.To = *from, *to
+	return query, nil
+}
+
+func ListSlotsController(ctx *gin.Context, slotService ports.SlotService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	query, err := parseSlotQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	slots, err := slotService.FindSlots(ctx.Request.Context(), centerCtx.CenterID, query)
+	if err != nil {
+		respondSlotError(ctx, err, "Failed to list slots")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(slots))
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondTimeBlockError maps time block errors to their HTTP status, anything
+// else is a 500
+func respondTimeBlockError(ctx *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, exceptions.ErrTimeBlockNotFound), errors.Is(err, exceptions.ErrMembershipNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrTimeBlockInvalidRange), errors.Is(err, exceptions.ErrTimeBlockInvalidReason):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrTimeBlockImported):
+		ctx.JSON(http.StatusConflict, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+// timeBlockStaffID returns the staff member of the route, closure routes have
+// none
+func timeBlockStaffID(ctx *gin.Context) (*uuid.UUID, error) {
+	param := ctx.Param("userId")
+	if param == "" {
+		return nil, nil
+	}
+	staffID, err := uuid.Parse(param)
+	if err != nil {
+		return nil, err
+	}
+	return &staffID, nil
+}
+
+// parseTimeQuery reads an optional RFC 3339 query parameter
+func parseTimeQuery(ctx *gin.Context, key string) (*time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func ListTimeBlocksController(ctx *gin.Context, timeBlockService ports.TimeBlockService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	staffID, err := timeBlockStaffID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	from, err := parseTimeQuery(ctx, "from")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+	to, err := parseTimeQuery(ctx, "to")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	blocks, err := timeBlockService.List(ctx.Request.Context(), centerCtx.CenterID, staffID, from, to)
+	if err != nil {
+		respondTimeBlockError(ctx, err, "Failed to list time blocks")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(blocks))
+}
+
+func CreateTimeBlockController(ctx *gin.Context, timeBlockService ports.TimeBlockService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	staffID, err := timeBlockStaffID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.TimeBlockInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	block, err := timeBlockService.Create(ctx.Request.Context(), centerCtx.CenterID, staffID, &request)
+	if err != nil {
+		respondTimeBlockError(ctx, err, "Failed to create time block")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(block))
+}
+
+func UpdateTimeBlockController(ctx *gin.Context, timeBlockService ports.TimeBlockService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	staffID, err := timeBlockStaffID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	blockID, err := uuid.Parse(ctx.Param("blockId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.TimeBlockInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	block, err := timeBlockService.Update(ctx.Request.Context(), centerCtx.CenterID, staffID, blockID, &request)
+	if err != nil {
+		respondTimeBlockError(ctx, err, "Failed to update time block")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(block))
+}
+
+func DeleteTimeBlockController(ctx *gin.Context, timeBlockService ports.TimeBlockService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	staffID, err := timeBlockStaffID(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	blockID, err := uuid.Parse(ctx.Param("blockId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = timeBlockService.Delete(ctx.Request.Context(), centerCtx.CenterID, staffID, blockID)
+	if err != nil {
+		respondTimeBlockError(ctx, err, "Failed to delete time block")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Time block deleted"})
+}
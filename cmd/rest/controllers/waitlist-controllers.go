@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// offerTokenHeader carries the token of the link sent with a waitlist offer
+const offerTokenHeader = "X-Offer-Token"
+
+// respondWaitlistError maps waitlist errors to their HTTP status, an offer
+// answered or expired is gone for good
+func respondWaitlistError(ctx *gin.Context, err error, fallbackMessage string) {
+	var domainErr *domain.DomainError
+	switch {
+	case errors.As(err, &domainErr):
+		ctx.JSON(domainErr.HTTPCode, helpers.BuildDomainErrorResponse(domainErr))
+	case errors.Is(err, exceptions.ErrCenterNotFound), errors.Is(err, exceptions.ErrCenterServiceNotFound),
+		errors.Is(err, exceptions.ErrMembershipNotFound), errors.Is(err, exceptions.ErrLeadNotFound),
+		errors.Is(err, exceptions.ErrWaitlistEntryNotFound), errors.Is(err, exceptions.ErrWaitlistOfferNotFound):
+		ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrWaitlistOfferClosed):
+		ctx.JSON(http.StatusGone, helpers.BuildErrorResponse(err.Error()))
+	case errors.Is(err, exceptions.ErrWaitlistInvalidRange), errors.Is(err, exceptions.ErrWaitlistInvalidStatus),
+		errors.Is(err, exceptions.ErrWaitlistEmailRequired), errors.Is(err, exceptions.ErrWaitlistConsentRequired),
+		errors.Is(err, exceptions.ErrLeadMissingContact), errors.Is(err, exceptions.ErrLeadInvalidEmail),
+		errors.Is(err, exceptions.ErrLeadInvalidPhone), errors.Is(err, exceptions.ErrConsentInvalidPurpose):
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(fallbackMessage))
+	}
+}
+
+// parseWaitlistFilter reads the optional status and service query parameters
+func parseWaitlistFilter(ctx *gin.Context) (*domain.WaitlistFilter, error) {
+	filter := &domain.WaitlistFilter{Status: domain.WaitlistStatus(ctx.Query("status"))}
+	if service := ctx.Query("service"); service != "" {
+		serviceID, err := uuid.Parse(service)
+		if err != nil {
+			return nil, err
+		}
+		filter.ServiceID = &serviceID
+	}
+	return filter, nil
+}
+
+func ListWaitlistController(ctx *gin.Context, waitlistService ports.WaitlistService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	filter, err := parseWaitlistFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	entries, err := waitlistService.List(ctx.Request.Context(), centerCtx.CenterID, filter)
+	if err != nil {
+		respondWaitlistError(ctx, err, "Failed to list waitlist")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(entries))
+}
+
+func CreateWaitlistEntryController(ctx *gin.Context, waitlistService ports.WaitlistService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	var request domain.CreateWaitlistEntryInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	entry, err := waitlistService.Create(ctx.Request.Context(), centerCtx.CenterID, &request)
+	if err != nil {
+		respondWaitlistError(ctx, err, "Failed to add to waitlist")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(entry))
+}
+
+func DeleteWaitlistEntryController(ctx *gin.Context, waitlistService ports.WaitlistService) {
+	centerCtx, err := helpers.GetCenterFromRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	entryID, err := uuid.Parse(ctx.Param("entryId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = waitlistService.Delete(ctx.Request.Context(), centerCtx.CenterID, entryID)
+	if err != nil {
+		respondWaitlistError(ctx, err, "Failed to delete waitlist entry")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Waitlist entry deleted"})
+}
+
+func JoinWaitlistController(ctx *gin.Context, waitlistService ports.WaitlistService) {
+	var request domain.JoinWaitlistInput
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequestPayload.Error()))
+		return
+	}
+
+	metadata := helpers.GetRequestMetadata(ctx)
+	entry, err := waitlistService.Join(ctx.Request.Context(), ctx.Param("slug"), &request, metadata.UserAgent, metadata.IPAddress)
+	if err != nil {
+		respondWaitlistError(ctx, err, "Failed to join waitlist")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(entry))
+}
+
+func GetWaitlistOfferController(ctx *gin.Context, waitlistService ports.WaitlistService) {
+	offerID, err := uuid.Parse(ctx.Param("offerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	offer, err := waitlistService.GetOffer(ctx.Request.Context(), ctx.Param("slug"), offerID, ctx.GetHeader(offerTokenHeader))
+	if err != nil {
+		respondWaitlistError(ctx, err, "Failed to get waitlist offer")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.BuildSuccessResponse(offer))
+}
+
+func ClaimWaitlistOfferController(ctx *gin.Context, waitlistService ports.WaitlistService) {
+	offerID, err := uuid.Parse(ctx.Param("offerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	booking, err := waitlistService.ClaimOffer(ctx.Request.Context(), ctx.Param("slug"), offerID, ctx.GetHeader(offerTokenHeader))
+	if err != nil {
+		respondWaitlistError(ctx, err, "Failed to claim waitlist offer")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, helpers.BuildSuccessResponse(booking))
+}
+
+func DeclineWaitlistOfferController(ctx *gin.Context, waitlistService ports.WaitlistService) {
+	offerID, err := uuid.Parse(ctx.Param("offerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+		return
+	}
+
+	err = waitlistService.DeclineOffer(ctx.Request.Context(), ctx.Param("slug"), offerID, ctx.GetHeader(offerTokenHeader))
+	if err != nil {
+		respondWaitlistError(ctx, err, "Failed to decline waitlist offer")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Waitlist offer declined"})
+}
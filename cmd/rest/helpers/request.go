@@ -33,8 +33,9 @@ func GetUserAgentFromRequest(ctx *gin.Context) string {
 }
 
 type UserCtx struct {
-	UserID string
-	AsUUID uuid.UUID
+	UserID   string
+	AsUUID   uuid.UUID
+	SourceID string
 }
 
 func GetUserIdFromRequest(ctx *gin.Context) (UserCtx, error) {
@@ -43,7 +44,37 @@ func GetUserIdFromRequest(ctx *gin.Context) (UserCtx, error) {
 	if err != nil {
 		return UserCtx{}, err
 	}
-	return UserCtx{UserID: userID, AsUUID: userIDUUID}, nil
+	return UserCtx{UserID: userID, AsUUID: userIDUUID, SourceID: ctx.GetString(constants.SourceIDClaimKey)}, nil
+}
+
+type CenterCtx struct {
+	CenterID uuid.UUID
+	Role     domain.CenterRole
+}
+
+// GetCenterFromRequest returns the center resolved by the center guard
+func GetCenterFromRequest(ctx *gin.Context) (CenterCtx, error) {
+	centerID, err := uuid.Parse(ctx.GetString(constants.CenterIDKey))
+	if err != nil {
+		return CenterCtx{}, err
+	}
+	role, _ := ctx.Get(constants.CenterRoleKey)
+	centerRole, _ := role.(domain.CenterRole)
+	return CenterCtx{CenterID: centerID, Role: centerRole}, nil
+}
+
+// GetCenterMemberFromRequest returns the membership of the user in the center
+// resolved by the center guard
+func GetCenterMemberFromRequest(ctx *gin.Context) (*domain.CenterMembership, error) {
+	userCtx, err := GetUserIdFromRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	centerCtx, err := GetCenterFromRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.CenterMembership{CenterID: centerCtx.CenterID, UserID: userCtx.AsUUID, Role: centerCtx.Role}, nil
 }
 
 type RequestMetadata struct {
@@ -67,4 +98,5 @@ func AttachClaimsToContext(ctx *gin.Context, claims *domain.JWTClaims) {
 	ctx.Set(constants.UserIDClaimKey, claims.UserID.String())
 	ctx.Set(constants.EmailClaimKey, claims.Email)
 	ctx.Set(constants.SourceIDClaimKey, claims.SourceID)
+	ctx.Set(constants.EmailVerifiedClaimKey, claims.EmailVerified)
 }
@@ -1,6 +1,9 @@
 package helpers
 
-import "github.com/gin-gonic/gin"
+import (
+	"bifur.app/core/internal/domain"
+	"github.com/gin-gonic/gin"
+)
 
 func BuildErrorResponse(err string) gin.H {
 	return gin.H{
@@ -8,6 +11,15 @@ func BuildErrorResponse(err string) gin.H {
 	}
 }
 
+// BuildDomainErrorResponse adds the machine-readable code of the error to the
+// usual error response
+func BuildDomainErrorResponse(err *domain.DomainError) gin.H {
+	return gin.H{
+		"error": err.HTTPErrorBody.Errors,
+		"code":  err.HTTPErrorBody.Code,
+	}
+}
+
 func BuildSuccessResponse(data interface{}) gin.H {
 	return gin.H{
 		"data": data,
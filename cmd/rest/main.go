@@ -2,6 +2,8 @@ package main
 
 import (
 	"log"
+	// Center timezones must resolve even where the host has no zoneinfo
+	_ "time/tzdata"
 
 	"bifur.app/core/cmd/rest/helpers"
 	"bifur.app/core/cmd/rest/server"
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"net/http"
 	"time"
 
 	"bifur.app/core/cmd/rest/helpers"
@@ -12,16 +13,32 @@ import (
 )
 
 type AuthMiddleware struct {
-	authService ports.AuthService
-	sourceRepo  ports.SourceRepository
-	jwtConfig   domain.JWTConfig
+	authService           ports.AuthService
+	sourceRepo            ports.SourceRepository
+	jwtConfig             domain.JWTConfig
+	unverifiedEmailPolicy domain.UnverifiedEmailPolicy
 }
 
-func NewAuthMiddleware(authUseCase ports.AuthService, sourceRepo ports.SourceRepository, jwtConfig domain.JWTConfig) *AuthMiddleware {
+func NewAuthMiddleware(authUseCase ports.AuthService, sourceRepo ports.SourceRepository, jwtConfig domain.JWTConfig, unverifiedEmailPolicy domain.UnverifiedEmailPolicy) *AuthMiddleware {
 	return &AuthMiddleware{
-		authService: authUseCase,
-		sourceRepo:  sourceRepo,
-		jwtConfig:   jwtConfig,
+		authService:           authUseCase,
+		sourceRepo:            sourceRepo,
+		jwtConfig:             jwtConfig,
+		unverifiedEmailPolicy: unverifiedEmailPolicy,
+	}
+}
+
+// allowsUnverified tells whether the configured policy lets a user with an
+// unverified email address perform the request
+func (m *AuthMiddleware) allowsUnverified(ctx *gin.Context) bool {
+	switch m.unverifiedEmailPolicy {
+	case domain.UnverifiedEmailPolicyBlock:
+		return false
+	case domain.UnverifiedEmailPolicyRestrict:
+		method := ctx.Request.Method
+		return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+	default:
+		return true
 	}
 }
 
@@ -33,14 +50,15 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 			helpers.AbortUnauthorizedRequest(ctx, err)
 			return
 		}
-		decoded, err := token.ExtractAndValidateToken(ctx, []byte(m.jwtConfig.AtkSecret))
 
+		// Validate the token
+		claims, err := m.authService.ValidateToken(ctx.Request.Context(), tk)
 		if err != nil {
 			helpers.AbortUnauthorizedRequest(ctx, err)
 			return
 		}
 
-		source, err := m.sourceRepo.GetByID(ctx.Request.Context(), decoded.SourceID)
+		source, err := m.sourceRepo.GetByID(ctx.Request.Context(), claims.SourceID)
 		if err != nil {
 			helpers.AbortUnauthorizedRequest(ctx, err)
 			return
@@ -50,10 +68,9 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		// Validate the token
-		claims, err := m.authService.ValidateToken(ctx.Request.Context(), tk)
-		if err != nil {
-			helpers.AbortUnauthorizedRequest(ctx, err)
+		if !claims.EmailVerified && !m.allowsUnverified(ctx) {
+			ctx.JSON(http.StatusForbidden, helpers.BuildErrorResponse(exceptions.ErrAuthEmailNotVerified.Error()))
+			ctx.Abort()
 			return
 		}
 
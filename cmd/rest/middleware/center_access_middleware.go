@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/cmd/rest/constants"
+	"bifur.app/core/cmd/rest/helpers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// CenterIDParam is the route parameter the center guard resolves the center from
+	CenterIDParam = "id"
+	// StaffIDParam is the route parameter of the staff member a route acts on
+	StaffIDParam = "userId"
+)
+
+type CenterAccessMiddleware struct {
+	membershipService ports.MembershipService
+}
+
+func NewCenterAccessMiddleware(membershipService ports.MembershipService) *CenterAccessMiddleware {
+	return &CenterAccessMiddleware{membershipService: membershipService}
+}
+
+// Require rejects requests of users whose role in the center of the route does
+// not grant the permission. It must run after Authenticate. Users that are not
+// members get a 404 so the existence of the center is not disclosed.
+func (m *CenterAccessMiddleware) Require(permission domain.Permission) gin.HandlerFunc {
+	return m.guard(func(ctx *gin.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CenterMembership, error) {
+		return m.membershipService.Authorize(ctx.Request.Context(), centerID, userID, permission)
+	})
+}
+
+// RequireOnStaff is Require for routes acting on the schedule of the staff
+// member of the route, which is always allowed to act on its own schedule
+func (m *CenterAccessMiddleware) RequireOnStaff(permission domain.Permission) gin.HandlerFunc {
+	return m.guard(func(ctx *gin.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CenterMembership, error) {
+		staffID, err := uuid.Parse(ctx.Param(StaffIDParam))
+		if err != nil {
+			return nil, exceptions.ErrMembershipNotFound
+		}
+		return m.membershipService.AuthorizeStaff(ctx.Request.Context(), centerID, userID, staffID, permission)
+	})
+}
+
+type authorizeFunc func(ctx *gin.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CenterMembership, error)
+
+func (m *CenterAccessMiddleware) guard(authorize authorizeFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userCtx, err := helpers.GetUserIdFromRequest(ctx)
+		if err != nil {
+			helpers.AbortUnauthorizedRequest(ctx, exceptions.ErrAuthHeaderMissing)
+			return
+		}
+
+		centerID, err := uuid.Parse(ctx.Param(CenterIDParam))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, helpers.BuildErrorResponse(domain.ErrBadRequest.Error()))
+			ctx.Abort()
+			return
+		}
+
+		membership, err := authorize(ctx, centerID, userCtx.AsUUID)
+		if err != nil {
+			switch {
+			case errors.Is(err, exceptions.ErrMembershipNotFound):
+				ctx.JSON(http.StatusNotFound, helpers.BuildErrorResponse(domain.ErrNotFound.Error()))
+			case errors.Is(err, exceptions.ErrCenterPermissionDenied):
+				ctx.JSON(http.StatusForbidden, helpers.BuildErrorResponse(err.Error()))
+			default:
+				ctx.JSON(http.StatusInternalServerError, helpers.BuildErrorResponse(domain.ErrInternalServerError.Error()))
+			}
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(constants.CenterIDKey, membership.CenterID.String())
+		ctx.Set(constants.CenterRoleKey, membership.Role)
+		ctx.Next()
+	}
+}
@@ -22,6 +22,9 @@ func CORSMiddleware(AppOrigins string) gin.HandlerFunc {
 		"Accept-Encoding",
 		"X-CSRF-Token",
 		"X-Requested-With",
+		"X-Hold-Token",
+		"X-Offer-Token",
+		"X-Link-Token",
 	}...)
 
 	return cors.New(corsConfig)
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"bifur.app/core/cmd/rest/controllers"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+)
+
+type AppointmentLinkRoutesDeps struct {
+	LinkService   ports.AppointmentLinkService
+	ReviewService ports.ReviewService
+}
+
+// SetupAppointmentLinkRoutes serves the self-service links of appointments to
+// their customers, outside of the authenticated routes. The token of the link
+// is sent in the X-Link-Token header.
+func SetupAppointmentLinkRoutes(router *gin.RouterGroup, deps *AppointmentLinkRoutesDeps) {
+	router.GET("", func(ctx *gin.Context) { controllers.GetLinkedAppointmentController(ctx, deps.LinkService) })
+	router.POST("/confirm", func(ctx *gin.Context) { controllers.ConfirmLinkedAppointmentController(ctx, deps.LinkService) })
+	router.POST("/cancel", func(ctx *gin.Context) { controllers.CancelLinkedAppointmentController(ctx, deps.LinkService) })
+	router.GET("/slots", func(ctx *gin.Context) { controllers.ListLinkedAppointmentSlotsController(ctx, deps.LinkService) })
+	router.POST("/reschedule", func(ctx *gin.Context) { controllers.RescheduleLinkedAppointmentController(ctx, deps.LinkService) })
+	router.GET("/review", func(ctx *gin.Context) { controllers.GetReviewableAppointmentController(ctx, deps.ReviewService) })
+	router.POST("/review", func(ctx *gin.Context) { controllers.SubmitReviewController(ctx, deps.ReviewService) })
+}
@@ -13,12 +13,23 @@ type AuthRoutesDeps struct {
 func SetupPublicAuthRoutes(router *gin.RouterGroup, deps *AuthRoutesDeps) {
 	router.POST("/register", func(ctx *gin.Context) { controllers.RegisterController(ctx, deps.AuthService) })
 	router.POST("/login", func(ctx *gin.Context) { controllers.LoginController(ctx, deps.AuthService) })
+	router.POST("/login/mfa", func(ctx *gin.Context) { controllers.LoginMFAController(ctx, deps.AuthService) })
 	router.POST("/refresh", func(ctx *gin.Context) { controllers.RefreshTokenController(ctx, deps.AuthService) })
 	router.POST("/logout", func(ctx *gin.Context) { controllers.LogoutController(ctx, deps.AuthService) })
+	router.POST("/forgot-password", func(ctx *gin.Context) { controllers.ForgotPasswordController(ctx, deps.AuthService) })
+	router.POST("/reset-password", func(ctx *gin.Context) { controllers.ResetPasswordController(ctx, deps.AuthService) })
+	router.POST("/verify-email", func(ctx *gin.Context) { controllers.VerifyEmailController(ctx, deps.AuthService) })
+	router.POST("/resend-verification", func(ctx *gin.Context) { controllers.ResendVerificationController(ctx, deps.AuthService) })
 }
 
 func SetupProtectedAuthRoutes(router *gin.RouterGroup, deps *AuthRoutesDeps) {
 
 	router.GET("/profile", func(ctx *gin.Context) { controllers.GetProfileController(ctx, deps.AuthService) })
 	router.POST("/logout-all", func(ctx *gin.Context) { controllers.LogoutAllController(ctx, deps.AuthService) })
+
+	mfaGroup := router.Group("/mfa")
+	mfaGroup.POST("/totp/setup", func(ctx *gin.Context) { controllers.SetupTOTPController(ctx, deps.AuthService) })
+	mfaGroup.POST("/totp/confirm", func(ctx *gin.Context) { controllers.ConfirmTOTPController(ctx, deps.AuthService) })
+	mfaGroup.POST("/totp/disable", func(ctx *gin.Context) { controllers.DisableTOTPController(ctx, deps.AuthService) })
+	mfaGroup.POST("/recovery-codes", func(ctx *gin.Context) { controllers.RegenerateRecoveryCodesController(ctx, deps.AuthService) })
 }
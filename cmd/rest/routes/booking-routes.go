@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"bifur.app/core/cmd/rest/controllers"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+)
+
+type BookingRoutesDeps struct {
+	BookingService  ports.BookingService
+	WaitlistService ports.WaitlistService
+	ReviewService   ports.ReviewService
+}
+
+// SetupBookingRoutes serves the online booking of the centers to their
+// customers, outside of the authenticated routes
+func SetupBookingRoutes(router *gin.RouterGroup, deps *BookingRoutesDeps) {
+	centerGroup := router.Group("/:slug")
+	centerGroup.GET("", func(ctx *gin.Context) { controllers.GetBookingCenterController(ctx, deps.BookingService) })
+	centerGroup.GET("/slots", func(ctx *gin.Context) { controllers.ListBookingSlotsController(ctx, deps.BookingService) })
+	centerGroup.POST("/holds", func(ctx *gin.Context) { controllers.HoldSlotController(ctx, deps.BookingService) })
+	centerGroup.DELETE("/holds/:holdId", func(ctx *gin.Context) { controllers.ReleaseSlotHoldController(ctx, deps.BookingService) })
+	centerGroup.POST("/holds/:holdId/confirm", func(ctx *gin.Context) { controllers.ConfirmBookingController(ctx, deps.BookingService) })
+	centerGroup.POST("/waitlist", func(ctx *gin.Context) { controllers.JoinWaitlistController(ctx, deps.WaitlistService) })
+	centerGroup.GET("/waitlist-offers/:offerId", func(ctx *gin.Context) { controllers.GetWaitlistOfferController(ctx, deps.WaitlistService) })
+	centerGroup.POST("/waitlist-offers/:offerId/claim", func(ctx *gin.Context) { controllers.ClaimWaitlistOfferController(ctx, deps.WaitlistService) })
+	centerGroup.POST("/waitlist-offers/:offerId/decline", func(ctx *gin.Context) { controllers.DeclineWaitlistOfferController(ctx, deps.WaitlistService) })
+	centerGroup.GET("/reviews", func(ctx *gin.Context) { controllers.ListPublishedReviewsController(ctx, deps.ReviewService) })
+	centerGroup.GET("/reviews/stats", func(ctx *gin.Context) { controllers.GetReviewStatsController(ctx, deps.ReviewService) })
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"bifur.app/core/cmd/rest/controllers"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarFeedRoutesDeps struct {
+	CalendarFeedService ports.CalendarFeedService
+}
+
+// SetupCalendarFeedRoutes serves the feeds to calendar apps, outside of the
+// authenticated routes
+func SetupCalendarFeedRoutes(router *gin.RouterGroup, deps *CalendarFeedRoutesDeps) {
+	router.GET("/:token", func(ctx *gin.Context) { controllers.CalendarFeedController(ctx, deps.CalendarFeedService) })
+}
@@ -2,18 +2,123 @@ package routes
 
 import (
 	"bifur.app/core/cmd/rest/controllers"
+	"bifur.app/core/cmd/rest/middleware"
+	"bifur.app/core/internal/domain"
 	"bifur.app/core/internal/ports"
 	"github.com/gin-gonic/gin"
 )
 
 type CentersRoutesDeps struct {
-	CentersRepository ports.CentersRepository
+	CentersService      ports.CentersService
+	MembershipService   ports.MembershipService
+	AvailabilityService ports.AvailabilityService
+	TimeBlockService    ports.TimeBlockService
+	SlotService         ports.SlotService
+	AppointmentService  ports.AppointmentService
+	SeriesService       ports.AppointmentSeriesService
+	CalendarFeedService ports.CalendarFeedService
+	BusyImportService   ports.BusyImportService
+	LeadService         ports.LeadService
+	WaitlistService     ports.WaitlistService
+	LinkService         ports.AppointmentLinkService
+	ReviewService       ports.ReviewService
+	CenterAccess        *middleware.CenterAccessMiddleware
 }
 
 func SetupCentersRoutes(router *gin.RouterGroup, deps *CentersRoutesDeps) {
 
-	router.POST("/initial-setup", func(ctx *gin.Context) {
+	router.GET("", func(ctx *gin.Context) { controllers.GetCentersController(ctx, deps.CentersService) })
+	router.POST("", func(ctx *gin.Context) { controllers.CreateCenterController(ctx, deps.CentersService) })
+	router.POST("/initial-setup", func(ctx *gin.Context) { controllers.InitialSetupController(ctx, deps.CentersService) })
 
-		controllers.GetCentersController(ctx, deps.CentersRepository)
-	})
+	// Routes of a single center, guarded by the role of the user in it
+	centerGroup := router.Group("/:" + middleware.CenterIDParam)
+	centerGroup.GET("", deps.CenterAccess.Require(domain.PermissionCenterRead), func(ctx *gin.Context) { controllers.GetCenterController(ctx, deps.CentersService) })
+	centerGroup.PATCH("", deps.CenterAccess.Require(domain.PermissionCenterUpdate), func(ctx *gin.Context) { controllers.UpdateCenterController(ctx, deps.CentersService) })
+	centerGroup.DELETE("", deps.CenterAccess.Require(domain.PermissionCenterDelete), func(ctx *gin.Context) { controllers.DeleteCenterController(ctx, deps.CentersService) })
+	centerGroup.POST("/restore", func(ctx *gin.Context) { controllers.RestoreCenterController(ctx, deps.CentersService) })
+	centerGroup.GET("/opening-hours", deps.CenterAccess.Require(domain.PermissionScheduleRead), func(ctx *gin.Context) { controllers.ListOpeningHoursController(ctx, deps.CentersService) })
+	centerGroup.GET("/services", deps.CenterAccess.Require(domain.PermissionScheduleRead), func(ctx *gin.Context) { controllers.ListCenterServicesController(ctx, deps.CentersService) })
+	centerGroup.GET("/resources", deps.CenterAccess.Require(domain.PermissionScheduleRead), func(ctx *gin.Context) { controllers.ListCenterResourcesController(ctx, deps.CentersService) })
+	centerGroup.POST("/resources", deps.CenterAccess.Require(domain.PermissionCenterUpdate), func(ctx *gin.Context) { controllers.CreateCenterResourceController(ctx, deps.CentersService) })
+	centerGroup.PATCH("/resources/:resourceId", deps.CenterAccess.Require(domain.PermissionCenterUpdate), func(ctx *gin.Context) { controllers.UpdateCenterResourceController(ctx, deps.CentersService) })
+	centerGroup.GET("/slots", deps.CenterAccess.Require(domain.PermissionScheduleRead), func(ctx *gin.Context) { controllers.ListSlotsController(ctx, deps.SlotService) })
+
+	// Members without appointments:manage can still manage their own
+	// appointments, the service checks it
+	appointmentsGroup := centerGroup.Group("/appointments")
+	appointmentsGroup.GET("", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.ListAppointmentsController(ctx, deps.AppointmentService) })
+	appointmentsGroup.POST("", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.CreateAppointmentController(ctx, deps.AppointmentService) })
+	appointmentsGroup.GET("/:appointmentId", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.GetAppointmentController(ctx, deps.AppointmentService) })
+	appointmentsGroup.PATCH("/:appointmentId", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.UpdateAppointmentController(ctx, deps.AppointmentService) })
+	appointmentsGroup.POST("/:appointmentId/status", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.ChangeAppointmentStatusController(ctx, deps.AppointmentService) })
+	appointmentsGroup.POST("/:appointmentId/links", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.IssueAppointmentLinksController(ctx, deps.LinkService) })
+	appointmentsGroup.DELETE("/:appointmentId/links", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.RevokeAppointmentLinksController(ctx, deps.LinkService) })
+	appointmentsGroup.POST("/:appointmentId/review-link", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.IssueReviewLinkController(ctx, deps.ReviewService) })
+
+	// The calendar feed of the member calling
+	centerGroup.GET("/calendar-feed", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.GetCalendarFeedController(ctx, deps.CalendarFeedService) })
+	centerGroup.POST("/calendar-feed", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.RotateCalendarFeedController(ctx, deps.CalendarFeedService) })
+	centerGroup.DELETE("/calendar-feed", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.RevokeCalendarFeedController(ctx, deps.CalendarFeedService) })
+
+	seriesGroup := centerGroup.Group("/appointment-series")
+	seriesGroup.POST("", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.CreateAppointmentSeriesController(ctx, deps.SeriesService) })
+	seriesGroup.GET("/occurrences", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.ListOccurrencesController(ctx, deps.SeriesService) })
+	seriesGroup.GET("/:seriesId", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.GetAppointmentSeriesController(ctx, deps.SeriesService) })
+	seriesGroup.GET("/:seriesId/occurrences", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.ListOccurrencesController(ctx, deps.SeriesService) })
+	seriesGroup.PATCH("/:seriesId/occurrences/:date", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.UpdateOccurrenceController(ctx, deps.SeriesService) })
+	seriesGroup.POST("/:seriesId/occurrences/:date/cancel", deps.CenterAccess.Require(domain.PermissionAppointmentsRead), func(ctx *gin.Context) { controllers.CancelOccurrenceController(ctx, deps.SeriesService) })
+
+	leadsGroup := centerGroup.Group("/leads")
+	leadsGroup.GET("", deps.CenterAccess.Require(domain.PermissionLeadsRead), func(ctx *gin.Context) { controllers.ListLeadsController(ctx, deps.LeadService) })
+	leadsGroup.POST("", deps.CenterAccess.Require(domain.PermissionLeadsManage), func(ctx *gin.Context) { controllers.CreateLeadController(ctx, deps.LeadService) })
+	leadsGroup.GET("/:leadId", deps.CenterAccess.Require(domain.PermissionLeadsRead), func(ctx *gin.Context) { controllers.GetLeadController(ctx, deps.LeadService) })
+	leadsGroup.PATCH("/:leadId", deps.CenterAccess.Require(domain.PermissionLeadsManage), func(ctx *gin.Context) { controllers.UpdateLeadController(ctx, deps.LeadService) })
+	leadsGroup.DELETE("/:leadId", deps.CenterAccess.Require(domain.PermissionLeadsManage), func(ctx *gin.Context) { controllers.DeleteLeadController(ctx, deps.LeadService) })
+	leadsGroup.GET("/:leadId/consents", deps.CenterAccess.Require(domain.PermissionLeadsRead), func(ctx *gin.Context) { controllers.ListLeadConsentsController(ctx, deps.LeadService) })
+	leadsGroup.POST("/:leadId/consents", deps.CenterAccess.Require(domain.PermissionLeadsManage), func(ctx *gin.Context) { controllers.RecordLeadConsentController(ctx, deps.LeadService) })
+
+	waitlistGroup := centerGroup.Group("/waitlist")
+	waitlistGroup.GET("", deps.CenterAccess.Require(domain.PermissionLeadsRead), func(ctx *gin.Context) { controllers.ListWaitlistController(ctx, deps.WaitlistService) })
+	waitlistGroup.POST("", deps.CenterAccess.Require(domain.PermissionLeadsManage), func(ctx *gin.Context) { controllers.CreateWaitlistEntryController(ctx, deps.WaitlistService) })
+	waitlistGroup.DELETE("/:entryId", deps.CenterAccess.Require(domain.PermissionLeadsManage), func(ctx *gin.Context) { controllers.DeleteWaitlistEntryController(ctx, deps.WaitlistService) })
+
+	reviewsGroup := centerGroup.Group("/reviews")
+	reviewsGroup.GET("", deps.CenterAccess.Require(domain.PermissionReviewsRead), func(ctx *gin.Context) { controllers.ListReviewsController(ctx, deps.ReviewService) })
+	reviewsGroup.PATCH("/:reviewId", deps.CenterAccess.Require(domain.PermissionReviewsModerate), func(ctx *gin.Context) { controllers.ModerateReviewController(ctx, deps.ReviewService) })
+
+	closuresGroup := centerGroup.Group("/closures")
+	closuresGroup.GET("", deps.CenterAccess.Require(domain.PermissionScheduleRead), func(ctx *gin.Context) { controllers.ListTimeBlocksController(ctx, deps.TimeBlockService) })
+	closuresGroup.POST("", deps.CenterAccess.Require(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.CreateTimeBlockController(ctx, deps.TimeBlockService) })
+	closuresGroup.PUT("/:blockId", deps.CenterAccess.Require(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.UpdateTimeBlockController(ctx, deps.TimeBlockService) })
+	closuresGroup.DELETE("/:blockId", deps.CenterAccess.Require(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.DeleteTimeBlockController(ctx, deps.TimeBlockService) })
+
+	membersGroup := centerGroup.Group("/members")
+	membersGroup.GET("", deps.CenterAccess.Require(domain.PermissionMembersRead), func(ctx *gin.Context) { controllers.ListMembersController(ctx, deps.MembershipService) })
+	membersGroup.POST("", deps.CenterAccess.Require(domain.PermissionMembersManage), func(ctx *gin.Context) { controllers.AddMemberController(ctx, deps.MembershipService) })
+	membersGroup.PATCH("/:userId", deps.CenterAccess.Require(domain.PermissionMembersManage), func(ctx *gin.Context) { controllers.UpdateMemberRoleController(ctx, deps.MembershipService) })
+	membersGroup.DELETE("/:userId", deps.CenterAccess.Require(domain.PermissionMembersManage), func(ctx *gin.Context) { controllers.RemoveMemberController(ctx, deps.MembershipService) })
+
+	// Schedule of a staff member, staff members can always manage their own
+	staffGroup := centerGroup.Group("/staff/:" + middleware.StaffIDParam)
+
+	availabilityGroup := staffGroup.Group("/availability")
+	availabilityGroup.GET("", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleRead), func(ctx *gin.Context) { controllers.ListAvailabilityController(ctx, deps.AvailabilityService) })
+	availabilityGroup.POST("", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.CreateAvailabilityController(ctx, deps.AvailabilityService) })
+	availabilityGroup.PUT("/:ruleId", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.UpdateAvailabilityController(ctx, deps.AvailabilityService) })
+	availabilityGroup.DELETE("/:ruleId", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.DeleteAvailabilityController(ctx, deps.AvailabilityService) })
+
+	timeBlocksGroup := staffGroup.Group("/time-blocks")
+	timeBlocksGroup.GET("", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleRead), func(ctx *gin.Context) { controllers.ListTimeBlocksController(ctx, deps.TimeBlockService) })
+	timeBlocksGroup.POST("", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.CreateTimeBlockController(ctx, deps.TimeBlockService) })
+	timeBlocksGroup.PUT("/:blockId", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.UpdateTimeBlockController(ctx, deps.TimeBlockService) })
+	timeBlocksGroup.DELETE("/:blockId", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.DeleteTimeBlockController(ctx, deps.TimeBlockService) })
+
+	// Busy times imported from the staff member's own calendar, as time blocks
+	busyTimesGroup := staffGroup.Group("/busy-times")
+	busyTimesGroup.POST("/import", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.ImportBusyTimesController(ctx, deps.BusyImportService) })
+	busyTimesGroup.GET("/calendar", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleRead), func(ctx *gin.Context) { controllers.GetExternalCalendarController(ctx, deps.BusyImportService) })
+	busyTimesGroup.PUT("/calendar", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.SetExternalCalendarController(ctx, deps.BusyImportService) })
+	busyTimesGroup.DELETE("/calendar", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.DeleteExternalCalendarController(ctx, deps.BusyImportService) })
+	busyTimesGroup.POST("/calendar/sync", deps.CenterAccess.RequireOnStaff(domain.PermissionScheduleManage), func(ctx *gin.Context) { controllers.SyncExternalCalendarController(ctx, deps.BusyImportService) })
 }
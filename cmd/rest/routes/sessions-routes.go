@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"bifur.app/core/cmd/rest/controllers"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionsRoutesDeps struct {
+	SessionService ports.SessionService
+}
+
+func SetupSessionsRoutes(router *gin.RouterGroup, deps *SessionsRoutesDeps) {
+	router.GET("", func(ctx *gin.Context) { controllers.ListSessionsController(ctx, deps.SessionService) })
+	router.DELETE("/:id", func(ctx *gin.Context) { controllers.RevokeSessionController(ctx, deps.SessionService) })
+}
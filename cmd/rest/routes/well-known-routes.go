@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"bifur.app/core/cmd/rest/controllers"
+	"bifur.app/core/internal/ports"
+	"github.com/gin-gonic/gin"
+)
+
+type WellKnownRoutesDeps struct {
+	AuthService ports.AuthService
+}
+
+func SetupWellKnownRoutes(router *gin.Engine, deps *WellKnownRoutesDeps) {
+	router.GET("/.well-known/jwks.json", func(ctx *gin.Context) { controllers.JWKSController(ctx, deps.AuthService) })
+}
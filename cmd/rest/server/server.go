@@ -11,10 +11,14 @@ import (
 
 	"bifur.app/core/cmd/rest/middleware"
 	"bifur.app/core/cmd/rest/routes"
+	"bifur.app/core/internal/adapters/geoip"
+	"bifur.app/core/internal/adapters/ics"
 	"bifur.app/core/internal/adapters/local"
 	pg_repos "bifur.app/core/internal/adapters/postgres/repositories"
+	"bifur.app/core/internal/adapters/smtp"
 	"bifur.app/core/internal/ports"
 	"bifur.app/core/internal/services"
+	"bifur.app/core/internal/utils/token"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -46,21 +50,130 @@ func initializeLogger() ports.Logger {
 	return local.NewLocalLogger()
 }
 
+func initializeGeoIPResolver(cfg *config.Config) ports.GeoIPResolver {
+	if cfg.GeoIP.DatabasePath == "" {
+		return geoip.NewNoopResolver()
+	}
+
+	resolver, err := geoip.NewMaxMindResolver(cfg.GeoIP.DatabasePath)
+	if err != nil {
+		log.Printf("Failed to open GeoIP database, location lookups disabled: %v", err)
+		return geoip.NewNoopResolver()
+	}
+	return resolver
+}
+
+// initializeSigningKeys loads the asymmetric access token keys, falling back to
+// the shared HS256 secret when no key manifest is configured
+func initializeSigningKeys(cfg *config.Config) *token.KeySet {
+	if cfg.JWT.KeysFile == "" {
+		log.Printf("JWT_KEYS_FILE is not set, access tokens are signed with the shared HS256 secret")
+		return token.NewHMACKeySet([]byte(cfg.JWT.AtkSecret))
+	}
+
+	keys, err := token.LoadKeySet(cfg.JWT.KeysFile)
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
+	return keys
+}
+
+func initializeMailer(cfg *config.Config) ports.Mailer {
+	if cfg.Mail.Driver == "smtp" {
+		mailer, err := smtp.NewSMTPMailer(smtp.SMTPMailerConfig{
+			Host:     cfg.Mail.SMTPHost,
+			Port:     cfg.Mail.SMTPPort,
+			Username: cfg.Mail.SMTPUser,
+			Password: cfg.Mail.SMTPPassword,
+			From:     cfg.Mail.From,
+			Timeout:  cfg.Mail.SMTPTimeout,
+		})
+		if err != nil {
+			log.Fatalf("Failed to configure the SMTP mailer: %v", err)
+		}
+		return mailer
+	}
+	return local.NewOutboxMailer(cfg.Mail.OutboxDir, cfg.Mail.From)
+}
+
+// runWaitlistSweep passes the expired waitlist offers on to the next leads
+// every interval, until ctx is done
+func runWaitlistSweep(ctx context.Context, waitlistService ports.WaitlistService, interval time.Duration) {
+	if interval <= 0 {
+		log.Printf("BOOKING_WAITLIST_SWEEP_INTERVAL is not positive, expired waitlist offers are not passed on")
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := waitlistService.ExpireOffers(ctx, now); err != nil {
+				log.Printf("Failed to expire waitlist offers: %v", err)
+			}
+		}
+	}
+}
+
 func (app *RestApp) Run() error {
 	router := gin.Default()
 	// Initialize logger
 	logger := initializeLogger()
+	geoIPResolver := initializeGeoIPResolver(app.cfg)
+	mailer := initializeMailer(app.cfg)
+	signingKeys := initializeSigningKeys(app.cfg)
+	calendarFetcher := ics.NewHTTPFetcher(app.cfg.Calendar.FetchTimeout, app.cfg.Calendar.ImportMaxBytes)
 
 	// Initialize repositories
 	userRepository := pg_repos.NewUserRepository(app.db, logger)
 	sourceRepository := pg_repos.NewSourceRepository(app.db, logger)
 	centersRepository := pg_repos.NewPgCenterRepository(app.db, logger)
+	accountTokenRepository := pg_repos.NewAccountTokenRepository(app.db, logger)
+	recoveryCodeRepository := pg_repos.NewRecoveryCodeRepository(app.db, logger)
+	loginThrottleRepository := pg_repos.NewLoginThrottleRepository(app.db, logger)
+	membershipRepository := pg_repos.NewMembershipRepository(app.db, logger)
+	availabilityRepository := pg_repos.NewAvailabilityRepository(app.db, logger)
+	timeBlockRepository := pg_repos.NewTimeBlockRepository(app.db, logger)
+	appointmentRepository := pg_repos.NewAppointmentRepository(app.db, logger)
+	appointmentSeriesRepository := pg_repos.NewAppointmentSeriesRepository(app.db, logger)
+	calendarFeedRepository := pg_repos.NewCalendarFeedRepository(app.db, logger)
+	externalCalendarRepository := pg_repos.NewExternalCalendarRepository(app.db, logger)
+	leadRepository := pg_repos.NewLeadRepository(app.db, logger)
+	slotHoldRepository := pg_repos.NewSlotHoldRepository(app.db, logger)
+	waitlistRepository := pg_repos.NewWaitlistRepository(app.db, logger)
+	reviewRepository := pg_repos.NewReviewRepository(app.db, logger)
+
+	// Keys for the opaque and signed tokens, derived from the refresh token
+	// secret for the purposes that have their own
+	tokenSecret := []byte(app.cfg.JWT.RtkSecret)
+	linkSecret := token.DeriveKey(tokenSecret, "appointment-links")
+	feedSecret := token.DeriveKey(tokenSecret, "calendar-feeds")
+	holdSecret := token.DeriveKey(tokenSecret, "slot-holds")
+	offerSecret := token.DeriveKey(tokenSecret, "waitlist-offers")
 
 	// Initialize services
-	authService := services.NewAuthService(userRepository, sourceRepository, app.cfg.JWT, logger)
+	authService := services.NewAuthService(userRepository, sourceRepository, accountTokenRepository, recoveryCodeRepository, loginThrottleRepository, geoIPResolver, mailer, app.cfg.JWT, signingKeys, app.cfg.Account, logger)
+	sessionService := services.NewSessionService(sourceRepository, logger)
+	membershipService := services.NewMembershipService(membershipRepository, userRepository, logger)
+	centersService := services.NewCentersService(centersRepository, logger)
+	availabilityService := services.NewAvailabilityService(availabilityRepository, membershipRepository, logger)
+	timeBlockService := services.NewTimeBlockService(timeBlockRepository, centersRepository, membershipRepository, logger)
+	slotService := services.NewSlotService(centersRepository, availabilityRepository, timeBlockRepository, appointmentRepository, appointmentSeriesRepository, slotHoldRepository, membershipRepository, logger)
+	waitlistService := services.NewWaitlistService(waitlistRepository, centersRepository, membershipRepository, appointmentSeriesRepository, appointmentRepository, slotHoldRepository, leadRepository, slotService, mailer, app.cfg.Booking, app.cfg.Account.AppURL, linkSecret, offerSecret, logger)
+	reviewService := services.NewReviewService(reviewRepository, appointmentRepository, centersRepository, leadRepository, mailer, app.cfg.Booking, app.cfg.Account.AppURL, linkSecret, logger)
+	appointmentService := services.NewAppointmentService(appointmentRepository, appointmentSeriesRepository, slotHoldRepository, centersRepository, membershipRepository, waitlistService, reviewService, logger)
+	appointmentLinkService := services.NewAppointmentLinkService(appointmentRepository, appointmentSeriesRepository, slotHoldRepository, centersRepository, membershipRepository, slotService, waitlistService, app.cfg.Account.AppURL, linkSecret, logger)
+	appointmentSeriesService := services.NewAppointmentSeriesService(appointmentSeriesRepository, appointmentRepository, slotHoldRepository, centersRepository, availabilityRepository, timeBlockRepository, membershipRepository, waitlistService, logger)
+	calendarFeedService := services.NewCalendarFeedService(calendarFeedRepository, appointmentRepository, appointmentSeriesRepository, centersRepository, membershipRepository, app.cfg.Calendar, feedSecret, tokenSecret, logger)
+	leadService := services.NewLeadService(leadRepository, logger)
+	bookingService := services.NewBookingService(centersRepository, membershipRepository, appointmentSeriesRepository, appointmentRepository, slotHoldRepository, leadRepository, slotService, app.cfg.Booking, app.cfg.Account.AppURL, linkSecret, holdSecret, logger)
+	busyImportService := services.NewBusyImportService(timeBlockRepository, externalCalendarRepository, centersRepository, membershipRepository, calendarFetcher, app.cfg.Calendar, logger)
 
 	// Initialize middlewares
-	authMiddleware := middleware.NewAuthMiddleware(authService, sourceRepository, app.cfg.JWT)
+	authMiddleware := middleware.NewAuthMiddleware(authService, sourceRepository, app.cfg.JWT, app.cfg.Account.UnverifiedEmailPolicy)
+	centerAccessMiddleware := middleware.NewCenterAccessMiddleware(membershipService)
 
 	// Setup Gin router
 
@@ -76,15 +189,45 @@ func (app *RestApp) Run() error {
 
 	// Health Routes
 	routes.SetupHealthRoutes(router)
+	// Well-known Routes
+	routes.SetupWellKnownRoutes(router, &routes.WellKnownRoutesDeps{AuthService: authService})
 	// Auth Routes
 	routes.SetupPublicAuthRoutes(publicGroup, &routes.AuthRoutesDeps{AuthService: authService})
 	routes.SetupProtectedAuthRoutes(protectedGroup, &routes.AuthRoutesDeps{AuthService: authService})
+	// Sessions Routes
+	routes.SetupSessionsRoutes(protectedGroup.Group("/sessions"), &routes.SessionsRoutesDeps{SessionService: sessionService})
+	// Calendar Feed Routes
+	routes.SetupCalendarFeedRoutes(publicGroup.Group("/calendar-feeds"), &routes.CalendarFeedRoutesDeps{CalendarFeedService: calendarFeedService})
+	// Booking Routes
+	routes.SetupBookingRoutes(publicGroup.Group("/booking"), &routes.BookingRoutesDeps{BookingService: bookingService, WaitlistService: waitlistService, ReviewService: reviewService})
+	// Appointment Link Routes
+	routes.SetupAppointmentLinkRoutes(publicGroup.Group("/appointment-links"), &routes.AppointmentLinkRoutesDeps{LinkService: appointmentLinkService, ReviewService: reviewService})
 	// Centers Routes
-	routes.SetupCentersRoutes(protectedGroup.Group("/centers"), &routes.CentersRoutesDeps{CentersRepository: centersRepository})
+	routes.SetupCentersRoutes(protectedGroup.Group("/centers"), &routes.CentersRoutesDeps{
+		CentersService:      centersService,
+		MembershipService:   membershipService,
+		AvailabilityService: availabilityService,
+		TimeBlockService:    timeBlockService,
+		SlotService:         slotService,
+		AppointmentService:  appointmentService,
+		SeriesService:       appointmentSeriesService,
+		CalendarFeedService: calendarFeedService,
+		BusyImportService:   busyImportService,
+		LeadService:         leadService,
+		WaitlistService:     waitlistService,
+		LinkService:         appointmentLinkService,
+		ReviewService:       reviewService,
+		CenterAccess:        centerAccessMiddleware,
+	})
 
 	// Create the server
 	server := createServer(app.cfg, router)
 
+	// Expired waitlist offers are passed on in the background
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	defer stopSweep()
+	go runWaitlistSweep(sweepCtx, waitlistService, app.cfg.Booking.WaitlistSweepInterval)
+
 	serverErrors := make(chan error, 1)
 
 	go func() {
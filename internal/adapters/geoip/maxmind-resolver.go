@@ -0,0 +1,59 @@
+package geoip
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/oschwald/geoip2-golang"
+)
+
+// MaxMindResolver resolves locations offline from a MaxMind format (.mmdb)
+// database, either a City or a Country edition
+type MaxMindResolver struct {
+	reader *geoip2.Reader
+}
+
+func NewMaxMindResolver(databasePath string) (*MaxMindResolver, error) {
+	reader, err := geoip2.Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	return &MaxMindResolver{reader: reader}, nil
+}
+
+var _ ports.GeoIPResolver = (*MaxMindResolver)(nil)
+
+func (r *MaxMindResolver) Resolve(ctx context.Context, ipAddress string) (*domain.GeoLocation, error) {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return nil, exceptions.ErrGeoIPInvalidAddress
+	}
+
+	city, err := r.reader.City(ip)
+	if err == nil {
+		return &domain.GeoLocation{
+			City:        city.City.Names["en"],
+			CountryCode: city.Country.IsoCode,
+		}, nil
+	}
+
+	var invalidMethod geoip2.InvalidMethodError
+	if !errors.As(err, &invalidMethod) {
+		return nil, err
+	}
+
+	// Country editions cannot answer City lookups
+	country, err := r.reader.Country(ip)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.GeoLocation{CountryCode: country.Country.IsoCode}, nil
+}
+
+func (r *MaxMindResolver) Close() error {
+	return r.reader.Close()
+}
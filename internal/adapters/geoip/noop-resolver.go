@@ -0,0 +1,22 @@
+package geoip
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/ports"
+)
+
+// NoopResolver is used when no GeoIP database is configured, every address
+// resolves to an unknown location
+type NoopResolver struct{}
+
+func NewNoopResolver() *NoopResolver {
+	return &NoopResolver{}
+}
+
+var _ ports.GeoIPResolver = (*NoopResolver)(nil)
+
+func (r *NoopResolver) Resolve(ctx context.Context, ipAddress string) (*domain.GeoLocation, error) {
+	return &domain.GeoLocation{}, nil
+}
@@ -0,0 +1,114 @@
+package ics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+)
+
+const maxRedirects = 5
+
+// HTTPFetcher downloads calendars over http and https, webcal URLs being
+// https ones. The addresses are given by the users, so it only connects to
+// public IPs, whatever the host resolves to and wherever redirects lead.
+type HTTPFetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+func NewHTTPFetcher(timeout time.Duration, maxBytes int64) *HTTPFetcher {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !isPublic(ip) {
+				return exceptions.ErrExternalCalendarInvalidURL
+			}
+			return nil
+		},
+	}
+
+	return &HTTPFetcher{
+		client: &http.Client{
+			Timeout: timeout,
+			// No proxy from the environment, it would be the one dialed
+			Transport: &http.Transport{
+				DialContext:           dialer.DialContext,
+				TLSHandshakeTimeout:   timeout,
+				ResponseHeaderTimeout: timeout,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return exceptions.ErrExternalCalendarUnreachable
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return exceptions.ErrExternalCalendarInvalidURL
+				}
+				return nil
+			},
+		},
+		maxBytes: maxBytes,
+	}
+}
+
+var _ ports.CalendarFetcher = (*HTTPFetcher)(nil)
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	calendarURL, err := url.Parse(rawURL)
+	if err != nil || calendarURL.Host == "" {
+		return nil, exceptions.ErrExternalCalendarInvalidURL
+	}
+	switch strings.ToLower(calendarURL.Scheme) {
+	case "http", "https":
+	case "webcal":
+		calendarURL.Scheme = "https"
+	default:
+		return nil, exceptions.ErrExternalCalendarInvalidURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, calendarURL.String(), nil)
+	if err != nil {
+		return nil, exceptions.ErrExternalCalendarInvalidURL
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrExternalCalendarInvalidURL) {
+			return nil, exceptions.ErrExternalCalendarInvalidURL
+		}
+		return nil, exceptions.ErrExternalCalendarUnreachable
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, exceptions.ErrExternalCalendarUnreachable
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes+1))
+	if err != nil {
+		return nil, exceptions.ErrExternalCalendarUnreachable
+	}
+	if int64(len(data)) > f.maxBytes {
+		return nil, exceptions.ErrExternalCalendarTooLarge
+	}
+	return data, nil
+}
+
+func isPublic(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast()
+}
@@ -0,0 +1,38 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/utils/mail"
+	"github.com/google/uuid"
+)
+
+// OutboxMailer writes every email as an .eml file into a directory instead of
+// delivering it, meant for local development
+type OutboxMailer struct {
+	dir  string
+	from string
+}
+
+func NewOutboxMailer(dir, from string) *OutboxMailer {
+	return &OutboxMailer{dir: dir, from: from}
+}
+
+func (m *OutboxMailer) Send(ctx context.Context, message *domain.EmailMessage) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("%w: %v", exceptions.ErrMailSendFailed, err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405"), uuid.NewString())
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, mail.Compose(m.from, message), 0o644); err != nil {
+		return fmt.Errorf("%w: %v", exceptions.ErrMailSendFailed, err)
+	}
+	return nil
+}
@@ -35,6 +35,8 @@ func getGormConfig(cfg GormPostgreSQLConfig) *gorm.Config {
 		PrepareStmt:            true,
 		AllowGlobalUpdate:      false,
 		SkipDefaultTransaction: true,
+		// Exposes constraint violations as gorm.ErrDuplicatedKey, ...
+		TranslateError: true,
 	}
 	if cfg.LogEnabled {
 		config.Logger = logger.Default.LogMode(logger.Info)
@@ -0,0 +1,31 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AccountToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	User      User      `gorm:"foreignKey:UserID;references:ID"`
+	Purpose   string    `gorm:"not null;index"`
+	TokenHash string    `gorm:"not null;unique"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+func (a *AccountToken) TableName() string {
+	return "account_tokens"
+}
+
+func (a *AccountToken) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	a.CreatedAt = time.Now()
+	return
+}
@@ -0,0 +1,51 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AppointmentSeries struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	CenterID   uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Center     Center          `gorm:"foreignKey:CenterID;references:ID"`
+	StaffID    uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Staff      User            `gorm:"foreignKey:StaffID;references:ID"`
+	ServiceID  uuid.UUID       `gorm:"type:uuid;not null"`
+	Service    CenterService   `gorm:"foreignKey:ServiceID;references:ID"`
+	ResourceID *uuid.UUID      `gorm:"type:uuid"`
+	Resource   *CenterResource `gorm:"foreignKey:ResourceID;references:ID"`
+	RRule      string          `gorm:"column:rrule;not null"`
+	StartsAt   time.Time       `gorm:"not null"`
+	// EndsAt is the end of the last occurrence, empty while the series
+	// repeats forever. It keeps finished series out of the range queries.
+	EndsAt          *time.Time
+	DurationMinutes int    `gorm:"not null;check:duration_minutes > 0"`
+	Timezone        string `gorm:"not null"`
+	// ExDates is the comma separated list of the excluded dates
+	ExDates       string `gorm:"not null;default:''"`
+	Status        string `gorm:"not null"`
+	CustomerName  string `gorm:"not null"`
+	CustomerEmail string
+	CustomerPhone string
+	Notes         string
+	CreatedBy     *uuid.UUID `gorm:"type:uuid"`
+	CancelledAt   *time.Time `gorm:"index"`
+}
+
+func (s *AppointmentSeries) TableName() string {
+	return "appointment_series"
+}
+
+func (s *AppointmentSeries) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return
+}
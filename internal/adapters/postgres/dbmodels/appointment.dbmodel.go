@@ -0,0 +1,63 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Exclusion constraints that keep the appointments that take time, see
+// domain.AppointmentStatus.BlocksTime, from overlapping for the same staff
+// member or resource. They are added by the migrations.
+const (
+	AppointmentStaffOverlapConstraint    = "appointments_staff_no_overlap"
+	AppointmentResourceOverlapConstraint = "appointments_resource_no_overlap"
+)
+
+type Appointment struct {
+	ID                 uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	CenterID           uuid.UUID          `gorm:"type:uuid;not null;index:idx_appointments_center_starts_at"`
+	Center             Center             `gorm:"foreignKey:CenterID;references:ID"`
+	StaffID            uuid.UUID          `gorm:"type:uuid;not null;index:idx_appointments_staff_starts_at"`
+	Staff              User               `gorm:"foreignKey:StaffID;references:ID"`
+	ServiceID          uuid.UUID          `gorm:"type:uuid;not null"`
+	Service            CenterService      `gorm:"foreignKey:ServiceID;references:ID"`
+	ResourceID         *uuid.UUID         `gorm:"type:uuid;index"`
+	Resource           *CenterResource    `gorm:"foreignKey:ResourceID;references:ID"`
+	LeadID             *uuid.UUID         `gorm:"type:uuid;index"`
+	Lead               *Lead              `gorm:"foreignKey:LeadID;references:ID;constraint:OnDelete:SET NULL"`
+	SeriesID           *uuid.UUID         `gorm:"type:uuid;uniqueIndex:idx_appointments_series_occurrence"`
+	Series             *AppointmentSeries `gorm:"foreignKey:SeriesID;references:ID"`
+	OccurrenceDate     *time.Time         `gorm:"type:date;uniqueIndex:idx_appointments_series_occurrence"`
+	StartsAt           time.Time          `gorm:"not null;index:idx_appointments_center_starts_at;index:idx_appointments_staff_starts_at"`
+	EndsAt             time.Time          `gorm:"not null;check:ends_at > starts_at"`
+	Status             string             `gorm:"not null;index"`
+	CustomerName       string             `gorm:"not null"`
+	CustomerEmail      string
+	CustomerPhone      string
+	Notes              string
+	CancellationReason string
+	CreatedBy          *uuid.UUID `gorm:"type:uuid"`
+	ConfirmedAt        *time.Time
+	CheckedInAt        *time.Time
+	CompletedAt        *time.Time
+	CancelledAt        *time.Time
+	NoShowAt           *time.Time
+	LinkVersion        int `gorm:"not null;default:0"`
+}
+
+func (a *Appointment) TableName() string {
+	return "appointments"
+}
+
+func (a *Appointment) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	a.CreatedAt = time.Now()
+	a.UpdatedAt = time.Now()
+	return
+}
@@ -0,0 +1,38 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AvailabilityRule struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CenterID  uuid.UUID `gorm:"type:uuid;not null;index:idx_availability_rules_center_user"`
+	Center    Center    `gorm:"foreignKey:CenterID;references:ID"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index:idx_availability_rules_center_user"`
+	User      User      `gorm:"foreignKey:UserID;references:ID"`
+	Weekday   int       `gorm:"type:smallint;not null;check:weekday BETWEEN 0 AND 6"`
+	// Minutes since midnight
+	StartTime      int        `gorm:"type:smallint;not null"`
+	EndTime        int        `gorm:"type:smallint;not null;check:end_time > start_time"`
+	EffectiveFrom  *time.Time `gorm:"type:date"`
+	EffectiveUntil *time.Time `gorm:"type:date"`
+	IsActive       bool       `gorm:"not null;default:true"`
+}
+
+func (r *AvailabilityRule) TableName() string {
+	return "availability_rules"
+}
+
+func (r *AvailabilityRule) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = time.Now()
+	return
+}
@@ -0,0 +1,32 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CalendarFeed struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	// A staff member has a single active feed per center
+	CenterID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_calendar_feeds_active,where:revoked_at IS NULL"`
+	Center    Center    `gorm:"foreignKey:CenterID;references:ID"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_calendar_feeds_active,where:revoked_at IS NULL"`
+	User      User      `gorm:"foreignKey:UserID;references:ID"`
+	TokenHash string    `gorm:"not null;unique"`
+	RevokedAt *time.Time
+}
+
+func (f *CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
+
+func (f *CalendarFeed) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	f.CreatedAt = time.Now()
+	return
+}
@@ -0,0 +1,32 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CenterMembership struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CenterID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_center_memberships_center_user"`
+	Center    Center    `gorm:"foreignKey:CenterID;references:ID"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_center_memberships_center_user;index"`
+	User      User      `gorm:"foreignKey:UserID;references:ID"`
+	Role      string    `gorm:"not null"`
+}
+
+func (m *CenterMembership) TableName() string {
+	return "center_memberships"
+}
+
+func (m *CenterMembership) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	m.CreatedAt = time.Now()
+	m.UpdatedAt = time.Now()
+	return
+}
@@ -0,0 +1,31 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CenterResource struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CenterID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Center    Center    `gorm:"foreignKey:CenterID;references:ID"`
+	Name      string    `gorm:"not null"`
+	IsActive  bool      `gorm:"not null;default:true"`
+}
+
+func (r *CenterResource) TableName() string {
+	return "center_resources"
+}
+
+func (r *CenterResource) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = time.Now()
+	return
+}
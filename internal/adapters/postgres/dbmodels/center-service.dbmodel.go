@@ -0,0 +1,34 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CenterService struct {
+	ID                  uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	CenterID            uuid.UUID `gorm:"type:uuid;not null;index"`
+	Center              Center    `gorm:"foreignKey:CenterID;references:ID"`
+	Name                string    `gorm:"not null"`
+	DurationMinutes     int       `gorm:"not null;check:duration_minutes > 0"`
+	BufferBeforeMinutes int       `gorm:"not null;default:0;check:buffer_before_minutes >= 0"`
+	BufferAfterMinutes  int       `gorm:"not null;default:0;check:buffer_after_minutes >= 0"`
+	IsActive            bool      `gorm:"not null;default:true"`
+}
+
+func (s *CenterService) TableName() string {
+	return "center_services"
+}
+
+func (s *CenterService) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return
+}
@@ -13,8 +13,26 @@ type Center struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 	Name      string
-	OwnerID   uuid.UUID `gorm:"type:uuid;not null"`
-	Owner     User      `gorm:"foreignKey:OwnerID;references:ID"`
+	Slug      string        `gorm:"not null;default:'';uniqueIndex:idx_centers_slug,where:slug <> ''"`
+	OwnerID   uuid.UUID     `gorm:"type:uuid;not null"`
+	Owner     User          `gorm:"foreignKey:OwnerID;references:ID"`
+	Address   CenterAddress `gorm:"embedded;embeddedPrefix:address_"`
+	Phone     string
+	Email     string
+	Timezone  string `gorm:"not null;default:UTC"`
+	Locale    string `gorm:"not null;default:en"`
+	Currency  string `gorm:"size:3;not null;default:EUR"`
+	// CancellationWindowHours is 0 for the centers created before it existed
+	CancellationWindowHours int `gorm:"not null;default:0"`
+}
+
+type CenterAddress struct {
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string `gorm:"size:2"`
 }
 
 func (c *Center) TableName() string {
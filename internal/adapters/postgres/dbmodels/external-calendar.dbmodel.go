@@ -0,0 +1,34 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ExternalCalendar struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// A staff member has a single external calendar per center
+	CenterID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_external_calendars_center_user"`
+	Center       Center    `gorm:"foreignKey:CenterID;references:ID"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_external_calendars_center_user"`
+	User         User      `gorm:"foreignKey:UserID;references:ID"`
+	URL          string    `gorm:"not null"`
+	LastSyncedAt *time.Time
+}
+
+func (c *ExternalCalendar) TableName() string {
+	return "external_calendars"
+}
+
+func (c *ExternalCalendar) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = time.Now()
+	return
+}
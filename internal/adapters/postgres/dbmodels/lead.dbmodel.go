@@ -0,0 +1,63 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Lead struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CenterID  uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_leads_center_email,where:email <> '';uniqueIndex:idx_leads_center_phone,where:phone <> ''"`
+	Center    Center    `gorm:"foreignKey:CenterID;references:ID"`
+	Name      string    `gorm:"not null"`
+	// Normalized, a center has a single lead with each email and phone
+	Email    string `gorm:"not null;default:'';uniqueIndex:idx_leads_center_email,where:email <> ''"`
+	Phone    string `gorm:"not null;default:'';uniqueIndex:idx_leads_center_phone,where:phone <> ''"`
+	Notes    string
+	Consents []LeadConsent `gorm:"foreignKey:LeadID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (l *Lead) TableName() string {
+	return "leads"
+}
+
+func (l *Lead) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	l.CreatedAt = time.Now()
+	l.UpdatedAt = time.Now()
+	return
+}
+
+// LeadConsent rows are only ever inserted, they are the proof of consent
+type LeadConsent struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt     time.Time `gorm:"index:idx_lead_consents_lead_created_at"`
+	LeadID        uuid.UUID `gorm:"type:uuid;not null;index:idx_lead_consents_lead_created_at"`
+	Purpose       string    `gorm:"not null"`
+	Granted       bool      `gorm:"not null"`
+	PolicyVersion string    `gorm:"not null"`
+	Statement     string
+	Source        string `gorm:"not null"`
+	IPAddress     string
+	UserAgent     string
+	RecordedBy    *uuid.UUID `gorm:"type:uuid"`
+	Recorder      *User      `gorm:"foreignKey:RecordedBy;references:ID"`
+}
+
+func (c *LeadConsent) TableName() string {
+	return "lead_consents"
+}
+
+func (c *LeadConsent) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	c.CreatedAt = time.Now()
+	return
+}
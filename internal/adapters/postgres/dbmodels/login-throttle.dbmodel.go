@@ -0,0 +1,17 @@
+package dbmodels
+
+import (
+	"time"
+)
+
+type LoginThrottle struct {
+	Key             string    `gorm:"primaryKey"`
+	FailedCount     int       `gorm:"not null;default:0"`
+	WindowStartedAt time.Time `gorm:"not null"`
+	LastFailedAt    time.Time `gorm:"not null;index"`
+	LockedUntil     *time.Time
+}
+
+func (l *LoginThrottle) TableName() string {
+	return "login_throttles"
+}
@@ -0,0 +1,27 @@
+package dbmodels
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OpeningHours struct {
+	ID       uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CenterID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Center   Center    `gorm:"foreignKey:CenterID;references:ID"`
+	Weekday  int       `gorm:"type:smallint;not null;check:weekday BETWEEN 0 AND 6"`
+	// Minutes since midnight
+	OpensAt  int `gorm:"type:smallint;not null"`
+	ClosesAt int `gorm:"type:smallint;not null;check:closes_at > opens_at"`
+}
+
+func (o *OpeningHours) TableName() string {
+	return "center_opening_hours"
+}
+
+func (o *OpeningHours) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,42 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AppointmentReview struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CenterID       uuid.UUID   `gorm:"type:uuid;not null;index:idx_appointment_reviews_center_status"`
+	Center         Center      `gorm:"foreignKey:CenterID;references:ID"`
+	AppointmentID  uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex"`
+	Appointment    Appointment `gorm:"foreignKey:AppointmentID;references:ID;constraint:OnDelete:CASCADE"`
+	StaffID        uuid.UUID   `gorm:"type:uuid;not null;index"`
+	ServiceID      uuid.UUID   `gorm:"type:uuid;not null"`
+	LeadID         *uuid.UUID  `gorm:"type:uuid"`
+	Lead           *Lead       `gorm:"foreignKey:LeadID;references:ID;constraint:OnDelete:SET NULL"`
+	AuthorName     string      `gorm:"not null"`
+	Rating         int         `gorm:"not null;check:rating BETWEEN 1 AND 5"`
+	Comment        string
+	Status         string `gorm:"not null;index:idx_appointment_reviews_center_status"`
+	ModerationNote string
+	ModeratedBy    *uuid.UUID `gorm:"type:uuid"`
+	ModeratedAt    *time.Time
+}
+
+func (r *AppointmentReview) TableName() string {
+	return "appointment_reviews"
+}
+
+func (r *AppointmentReview) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = time.Now()
+	return
+}
@@ -0,0 +1,31 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RotatedToken keeps track of the hashes of refresh tokens that were already
+// exchanged for a new one, so a replayed token can be traced back to its source
+// (token family).
+type RotatedToken struct {
+	ID               uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt        time.Time
+	SourceID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	Source           Source    `gorm:"foreignKey:SourceID;references:ID"`
+	RefreshTokenHash string    `gorm:"not null;unique"`
+}
+
+func (r *RotatedToken) TableName() string {
+	return "rotated_tokens"
+}
+
+func (r *RotatedToken) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	r.CreatedAt = time.Now()
+	return
+}
@@ -0,0 +1,42 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SlotHoldStaffOverlapConstraint is the exclusion constraint that keeps the
+// holds of a staff member from overlapping, it is added by the migrations.
+// Expired holds are deleted before a new one is stored, so they never get in
+// the way.
+const SlotHoldStaffOverlapConstraint = "slot_holds_staff_no_overlap"
+
+type SlotHold struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	CenterID  uuid.UUID     `gorm:"type:uuid;not null;index:idx_slot_holds_center_starts_at;index:idx_slot_holds_center_ip"`
+	Center    Center        `gorm:"foreignKey:CenterID;references:ID"`
+	StaffID   uuid.UUID     `gorm:"type:uuid;not null"`
+	Staff     User          `gorm:"foreignKey:StaffID;references:ID"`
+	ServiceID uuid.UUID     `gorm:"type:uuid;not null"`
+	Service   CenterService `gorm:"foreignKey:ServiceID;references:ID;constraint:OnDelete:CASCADE"`
+	StartsAt  time.Time     `gorm:"not null;index:idx_slot_holds_center_starts_at"`
+	EndsAt    time.Time     `gorm:"not null;check:ends_at > starts_at"`
+	ExpiresAt time.Time     `gorm:"not null;index"`
+	TokenHash string        `gorm:"not null"`
+	IPAddress string        `gorm:"index:idx_slot_holds_center_ip"`
+}
+
+func (h *SlotHold) TableName() string {
+	return "slot_holds"
+}
+
+func (h *SlotHold) BeforeCreate(tx *gorm.DB) (err error) {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	h.CreatedAt = time.Now()
+	return
+}
@@ -15,7 +15,7 @@ type Source struct {
 	DeletedAt             gorm.DeletedAt `gorm:"index"`
 	IPAddress             string         `gorm:"not null"`
 	UserID                uuid.UUID      `gorm:"not null;index"`
-	RefreshToken          string         `gorm:"not null;unique"`
+	RefreshTokenHash      string         `gorm:"not null;unique"`
 	RefreshTokenExpiresAt time.Time      `gorm:"not null"`
 	UserAgent             string         `gorm:"not null"`
 	IsActive              bool           `gorm:"default:true"`
@@ -40,7 +40,7 @@ func (s *Source) ToDomain() *domain.Source {
 		CreatedAt:             s.CreatedAt,
 		UpdatedAt:             s.UpdatedAt,
 		UserID:                s.UserID,
-		RefreshToken:          s.RefreshToken,
+		RefreshTokenHash:      s.RefreshTokenHash,
 		RefreshTokenExpiresAt: s.RefreshTokenExpiresAt,
 		UserAgent:             s.UserAgent,
 		IPAddress:             s.IPAddress,
@@ -0,0 +1,41 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TimeBlock struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CenterID  uuid.UUID `gorm:"type:uuid;not null;index:idx_time_blocks_center_starts_at;uniqueIndex:idx_time_blocks_external,priority:1,where:external_key <> ''"`
+	Center    Center    `gorm:"foreignKey:CenterID;references:ID"`
+	// Closures of the center have no user
+	UserID   *uuid.UUID `gorm:"type:uuid;index;uniqueIndex:idx_time_blocks_external,priority:2,where:external_key <> ''"`
+	User     *User      `gorm:"foreignKey:UserID;references:ID"`
+	StartsAt time.Time  `gorm:"not null;index:idx_time_blocks_center_starts_at"`
+	EndsAt   time.Time  `gorm:"not null;check:ends_at > starts_at"`
+	AllDay   bool       `gorm:"not null;default:false"`
+	Reason   string     `gorm:"not null"`
+	Note     string
+	// Imported blocks are unique per staff member, source and occurrence
+	Source      string `gorm:"not null;default:manual;uniqueIndex:idx_time_blocks_external,priority:3,where:external_key <> ''"`
+	ExternalUID string `gorm:"not null;default:''"`
+	ExternalKey string `gorm:"not null;default:'';uniqueIndex:idx_time_blocks_external,priority:4,where:external_key <> ''"`
+}
+
+func (b *TimeBlock) TableName() string {
+	return "time_blocks"
+}
+
+func (b *TimeBlock) BeforeCreate(tx *gorm.DB) (err error) {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	b.CreatedAt = time.Now()
+	b.UpdatedAt = time.Now()
+	return
+}
@@ -16,6 +16,11 @@ type User struct {
 	Password  string         `gorm:"not null"`
 	FirstName string         `gorm:"not null"`
 	LastName  string         `gorm:"not null"`
+
+	EmailVerifiedAt *time.Time
+	MFASecret       string
+	MFAEnabledAt    *time.Time
+	MFALastUsedStep int64 `gorm:"not null;default:0"`
 }
 
 func (u *User) TableName() string {
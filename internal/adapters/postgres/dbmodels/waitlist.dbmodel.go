@@ -0,0 +1,71 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WaitlistEntry struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CenterID  uuid.UUID     `gorm:"type:uuid;not null;index:idx_waitlist_entries_center_status"`
+	Center    Center        `gorm:"foreignKey:CenterID;references:ID"`
+	LeadID    uuid.UUID     `gorm:"type:uuid;not null;index"`
+	Lead      Lead          `gorm:"foreignKey:LeadID;references:ID;constraint:OnDelete:CASCADE"`
+	ServiceID uuid.UUID     `gorm:"type:uuid;not null"`
+	Service   CenterService `gorm:"foreignKey:ServiceID;references:ID;constraint:OnDelete:CASCADE"`
+	StaffID   *uuid.UUID    `gorm:"type:uuid"`
+	Staff     *User         `gorm:"foreignKey:StaffID;references:ID;constraint:OnDelete:SET NULL"`
+	FromDate  time.Time     `gorm:"type:date;not null"`
+	ToDate    time.Time     `gorm:"type:date;not null;check:to_date >= from_date"`
+	Status    string        `gorm:"not null;index:idx_waitlist_entries_center_status"`
+	Notes     string
+}
+
+func (e *WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
+
+func (e *WaitlistEntry) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	e.CreatedAt = time.Now()
+	e.UpdatedAt = time.Now()
+	return
+}
+
+// WaitlistOffer rows are kept once answered, an entry is never offered the
+// same slot twice
+type WaitlistOffer struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	CreatedAt     time.Time
+	CenterID      uuid.UUID     `gorm:"type:uuid;not null;index"`
+	EntryID       uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_waitlist_offers_entry_slot"`
+	Entry         WaitlistEntry `gorm:"foreignKey:EntryID;references:ID;constraint:OnDelete:CASCADE"`
+	HoldID        uuid.UUID     `gorm:"type:uuid;not null"`
+	StaffID       uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_waitlist_offers_entry_slot"`
+	ServiceID     uuid.UUID     `gorm:"type:uuid;not null"`
+	StartsAt      time.Time     `gorm:"not null;uniqueIndex:idx_waitlist_offers_entry_slot"`
+	EndsAt        time.Time     `gorm:"not null"`
+	ExpiresAt     time.Time     `gorm:"not null;index:idx_waitlist_offers_status_expires_at"`
+	Status        string        `gorm:"not null;index:idx_waitlist_offers_status_expires_at"`
+	AppointmentID *uuid.UUID    `gorm:"type:uuid"`
+	TokenHash     string        `gorm:"not null"`
+	RespondedAt   *time.Time
+}
+
+func (o *WaitlistOffer) TableName() string {
+	return "waitlist_offers"
+}
+
+func (o *WaitlistOffer) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	o.CreatedAt = time.Now()
+	return
+}
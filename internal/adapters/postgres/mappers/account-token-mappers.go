@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type AccountTokenMapper struct{}
+
+func NewAccountTokenMapper() *AccountTokenMapper {
+	return &AccountTokenMapper{}
+}
+
+func (m *AccountTokenMapper) ToDbModel(token *domain.AccountToken) *dbmodels.AccountToken {
+	return &dbmodels.AccountToken{
+		ID:        token.ID,
+		CreatedAt: token.CreatedAt,
+		UserID:    token.UserID,
+		Purpose:   string(token.Purpose),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+	}
+}
+
+func (m *AccountTokenMapper) ToDomain(token *dbmodels.AccountToken) *domain.AccountToken {
+	return &domain.AccountToken{
+		ID:        token.ID,
+		CreatedAt: token.CreatedAt,
+		UserID:    token.UserID,
+		Purpose:   domain.AccountTokenPurpose(token.Purpose),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+	}
+}
@@ -0,0 +1,72 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type AppointmentMapper struct{}
+
+func NewAppointmentMapper() *AppointmentMapper {
+	return &AppointmentMapper{}
+}
+
+func (m *AppointmentMapper) ToDbModel(appointment *domain.Appointment) *dbmodels.Appointment {
+	return &dbmodels.Appointment{
+		ID:                 appointment.ID,
+		CreatedAt:          appointment.CreatedAt,
+		UpdatedAt:          appointment.UpdatedAt,
+		CenterID:           appointment.CenterID,
+		StaffID:            appointment.StaffID,
+		ServiceID:          appointment.ServiceID,
+		ResourceID:         appointment.ResourceID,
+		LeadID:             appointment.LeadID,
+		SeriesID:           appointment.SeriesID,
+		OccurrenceDate:     dateToDbModel(appointment.OccurrenceDate),
+		StartsAt:           appointment.StartsAt,
+		EndsAt:             appointment.EndsAt,
+		Status:             string(appointment.Status),
+		CustomerName:       appointment.CustomerName,
+		CustomerEmail:      appointment.CustomerEmail,
+		CustomerPhone:      appointment.CustomerPhone,
+		Notes:              appointment.Notes,
+		CancellationReason: appointment.CancellationReason,
+		CreatedBy:          appointment.CreatedBy,
+		ConfirmedAt:        appointment.ConfirmedAt,
+		CheckedInAt:        appointment.CheckedInAt,
+		CompletedAt:        appointment.CompletedAt,
+		CancelledAt:        appointment.CancelledAt,
+		NoShowAt:           appointment.NoShowAt,
+		LinkVersion:        appointment.LinkVersion,
+	}
+}
+
+func (m *AppointmentMapper) ToDomain(appointment *dbmodels.Appointment) *domain.Appointment {
+	return &domain.Appointment{
+		ID:                 appointment.ID,
+		CreatedAt:          appointment.CreatedAt,
+		UpdatedAt:          appointment.UpdatedAt,
+		CenterID:           appointment.CenterID,
+		StaffID:            appointment.StaffID,
+		ServiceID:          appointment.ServiceID,
+		ResourceID:         appointment.ResourceID,
+		LeadID:             appointment.LeadID,
+		SeriesID:           appointment.SeriesID,
+		OccurrenceDate:     dateToDomain(appointment.OccurrenceDate),
+		StartsAt:           appointment.StartsAt,
+		EndsAt:             appointment.EndsAt,
+		Status:             domain.AppointmentStatus(appointment.Status),
+		CustomerName:       appointment.CustomerName,
+		CustomerEmail:      appointment.CustomerEmail,
+		CustomerPhone:      appointment.CustomerPhone,
+		Notes:              appointment.Notes,
+		CancellationReason: appointment.CancellationReason,
+		CreatedBy:          appointment.CreatedBy,
+		ConfirmedAt:        appointment.ConfirmedAt,
+		CheckedInAt:        appointment.CheckedInAt,
+		CompletedAt:        appointment.CompletedAt,
+		CancelledAt:        appointment.CancelledAt,
+		NoShowAt:           appointment.NoShowAt,
+		LinkVersion:        appointment.LinkVersion,
+	}
+}
@@ -0,0 +1,78 @@
+package mappers
+
+import (
+	"strings"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type AppointmentSeriesMapper struct{}
+
+func NewAppointmentSeriesMapper() *AppointmentSeriesMapper {
+	return &AppointmentSeriesMapper{}
+}
+
+func (m *AppointmentSeriesMapper) ToDbModel(series *domain.AppointmentSeries) *dbmodels.AppointmentSeries {
+	exDates := make([]string, len(series.ExDates))
+	for i, date := range series.ExDates {
+		exDates[i] = date.String()
+	}
+
+	return &dbmodels.AppointmentSeries{
+		ID:              series.ID,
+		CreatedAt:       series.CreatedAt,
+		UpdatedAt:       series.UpdatedAt,
+		CenterID:        series.CenterID,
+		StaffID:         series.StaffID,
+		ServiceID:       series.ServiceID,
+		ResourceID:      series.ResourceID,
+		RRule:           series.RRule,
+		StartsAt:        series.StartsAt,
+		EndsAt:          series.EndsAt,
+		DurationMinutes: series.DurationMinutes,
+		Timezone:        series.Timezone,
+		ExDates:         strings.Join(exDates, ","),
+		Status:          string(series.Status),
+		CustomerName:    series.CustomerName,
+		CustomerEmail:   series.CustomerEmail,
+		CustomerPhone:   series.CustomerPhone,
+		Notes:           series.Notes,
+		CreatedBy:       series.CreatedBy,
+		CancelledAt:     series.CancelledAt,
+	}
+}
+
+func (m *AppointmentSeriesMapper) ToDomain(series *dbmodels.AppointmentSeries) *domain.AppointmentSeries {
+	exDates := []domain.Date{}
+	if series.ExDates != "" {
+		for _, value := range strings.Split(series.ExDates, ",") {
+			// Only valid dates are ever stored
+			date, _ := domain.ParseDate(value)
+			exDates = append(exDates, date)
+		}
+	}
+
+	return &domain.AppointmentSeries{
+		ID:              series.ID,
+		CreatedAt:       series.CreatedAt,
+		UpdatedAt:       series.UpdatedAt,
+		CenterID:        series.CenterID,
+		StaffID:         series.StaffID,
+		ServiceID:       series.ServiceID,
+		ResourceID:      series.ResourceID,
+		RRule:           series.RRule,
+		StartsAt:        series.StartsAt,
+		EndsAt:          series.EndsAt,
+		DurationMinutes: series.DurationMinutes,
+		Timezone:        series.Timezone,
+		ExDates:         exDates,
+		Status:          domain.AppointmentStatus(series.Status),
+		CustomerName:    series.CustomerName,
+		CustomerEmail:   series.CustomerEmail,
+		CustomerPhone:   series.CustomerPhone,
+		Notes:           series.Notes,
+		CreatedBy:       series.CreatedBy,
+		CancelledAt:     series.CancelledAt,
+	}
+}
@@ -0,0 +1,63 @@
+package mappers
+
+import (
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type AvailabilityMapper struct{}
+
+func NewAvailabilityMapper() *AvailabilityMapper {
+	return &AvailabilityMapper{}
+}
+
+func (m *AvailabilityMapper) ToDbModel(rule *domain.AvailabilityRule) *dbmodels.AvailabilityRule {
+	return &dbmodels.AvailabilityRule{
+		ID:             rule.ID,
+		CreatedAt:      rule.CreatedAt,
+		UpdatedAt:      rule.UpdatedAt,
+		CenterID:       rule.CenterID,
+		UserID:         rule.UserID,
+		Weekday:        int(rule.Weekday),
+		StartTime:      int(rule.StartTime),
+		EndTime:        int(rule.EndTime),
+		EffectiveFrom:  dateToDbModel(rule.EffectiveFrom),
+		EffectiveUntil: dateToDbModel(rule.EffectiveUntil),
+		IsActive:       rule.IsActive,
+	}
+}
+
+func (m *AvailabilityMapper) ToDomain(rule *dbmodels.AvailabilityRule) *domain.AvailabilityRule {
+	return &domain.AvailabilityRule{
+		ID:             rule.ID,
+		CreatedAt:      rule.CreatedAt,
+		UpdatedAt:      rule.UpdatedAt,
+		CenterID:       rule.CenterID,
+		UserID:         rule.UserID,
+		Weekday:        time.Weekday(rule.Weekday),
+		StartTime:      domain.TimeOfDay(rule.StartTime),
+		EndTime:        domain.TimeOfDay(rule.EndTime),
+		EffectiveFrom:  dateToDomain(rule.EffectiveFrom),
+		EffectiveUntil: dateToDomain(rule.EffectiveUntil),
+		IsActive:       rule.IsActive,
+	}
+}
+
+// Postgres date columns are read back as midnight UTC
+func dateToDbModel(date *domain.Date) *time.Time {
+	if date == nil {
+		return nil
+	}
+	t := date.In(time.UTC)
+	return &t
+}
+
+func dateToDomain(t *time.Time) *domain.Date {
+	if t == nil {
+		return nil
+	}
+	date := domain.DateOf(t.UTC())
+	return &date
+}
@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type CalendarFeedMapper struct{}
+
+func NewCalendarFeedMapper() *CalendarFeedMapper {
+	return &CalendarFeedMapper{}
+}
+
+func (m *CalendarFeedMapper) ToDbModel(feed *domain.CalendarFeed) *dbmodels.CalendarFeed {
+	return &dbmodels.CalendarFeed{
+		ID:        feed.ID,
+		CreatedAt: feed.CreatedAt,
+		CenterID:  feed.CenterID,
+		UserID:    feed.UserID,
+		TokenHash: feed.TokenHash,
+		RevokedAt: feed.RevokedAt,
+	}
+}
+
+func (m *CalendarFeedMapper) ToDomain(feed *dbmodels.CalendarFeed) *domain.CalendarFeed {
+	return &domain.CalendarFeed{
+		ID:        feed.ID,
+		CreatedAt: feed.CreatedAt,
+		CenterID:  feed.CenterID,
+		UserID:    feed.UserID,
+		TokenHash: feed.TokenHash,
+		RevokedAt: feed.RevokedAt,
+	}
+}
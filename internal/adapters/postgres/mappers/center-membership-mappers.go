@@ -0,0 +1,48 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type CenterMembershipMapper struct{}
+
+func NewCenterMembershipMapper() *CenterMembershipMapper {
+	return &CenterMembershipMapper{}
+}
+
+func (m *CenterMembershipMapper) ToDbModel(membership *domain.CenterMembership) *dbmodels.CenterMembership {
+	return &dbmodels.CenterMembership{
+		ID:        membership.ID,
+		CreatedAt: membership.CreatedAt,
+		UpdatedAt: membership.UpdatedAt,
+		CenterID:  membership.CenterID,
+		UserID:    membership.UserID,
+		Role:      string(membership.Role),
+	}
+}
+
+func (m *CenterMembershipMapper) ToDomain(membership *dbmodels.CenterMembership) *domain.CenterMembership {
+	return &domain.CenterMembership{
+		ID:        membership.ID,
+		CreatedAt: membership.CreatedAt,
+		UpdatedAt: membership.UpdatedAt,
+		CenterID:  membership.CenterID,
+		UserID:    membership.UserID,
+		Role:      domain.CenterRole(membership.Role),
+	}
+}
+
+// ToMember builds the member view of a membership loaded with its user
+func (m *CenterMembershipMapper) ToMember(membership *dbmodels.CenterMembership) *domain.CenterMember {
+	role := domain.CenterRole(membership.Role)
+	return &domain.CenterMember{
+		UserID:      membership.UserID,
+		Email:       membership.User.Email,
+		FirstName:   membership.User.FirstName,
+		LastName:    membership.User.LastName,
+		Role:        role,
+		Permissions: role.Permissions(),
+		JoinedAt:    membership.CreatedAt,
+	}
+}
@@ -1,8 +1,11 @@
 package mappers
 
 import (
+	"time"
+
 	"bifur.app/core/internal/adapters/postgres/dbmodels"
 	"bifur.app/core/internal/domain"
+	"gorm.io/gorm"
 )
 
 type CenterMapper struct{}
@@ -12,17 +15,135 @@ func NewCenterMapper() *CenterMapper {
 }
 
 func (m *CenterMapper) ToDbModel(center *domain.Center) *dbmodels.Center {
-	return &dbmodels.Center{
-		Name:    center.Name,
-		ID:      center.ID,
-		OwnerID: center.OwnerID,
+	dbCenter := &dbmodels.Center{
+		Name:                    center.Name,
+		ID:                      center.ID,
+		Slug:                    center.Slug,
+		OwnerID:                 center.OwnerID,
+		Phone:                   center.Phone,
+		Email:                   center.Email,
+		Timezone:                center.Timezone,
+		Locale:                  center.Locale,
+		Currency:                center.Currency,
+		CancellationWindowHours: center.CancellationWindowHours,
+		CreatedAt:               center.CreatedAt,
+		UpdatedAt:               center.UpdatedAt,
+	}
+	if center.Address != nil {
+		dbCenter.Address = dbmodels.CenterAddress{
+			Line1:      center.Address.Line1,
+			Line2:      center.Address.Line2,
+			City:       center.Address.City,
+			Region:     center.Address.Region,
+			PostalCode: center.Address.PostalCode,
+			Country:    center.Address.Country,
+		}
+	}
+	if center.DeletedAt != nil {
+		dbCenter.DeletedAt = gorm.DeletedAt{Time: *center.DeletedAt, Valid: true}
 	}
+	return dbCenter
 }
 
 func (m *CenterMapper) ToDomain(center *dbmodels.Center) *domain.Center {
-	return &domain.Center{
-		ID:      center.ID,
-		Name:    center.Name,
-		OwnerID: center.OwnerID,
+	domainCenter := &domain.Center{
+		ID:                      center.ID,
+		Name:                    center.Name,
+		Slug:                    center.Slug,
+		OwnerID:                 center.OwnerID,
+		Phone:                   center.Phone,
+		Email:                   center.Email,
+		Timezone:                center.Timezone,
+		Locale:                  center.Locale,
+		Currency:                center.Currency,
+		CancellationWindowHours: center.CancellationWindowHours,
+		CreatedAt:               center.CreatedAt,
+		UpdatedAt:               center.UpdatedAt,
+	}
+	// Centers created before addresses existed have none
+	if center.Address.Line1 != "" {
+		domainCenter.Address = &domain.Address{
+			Line1:      center.Address.Line1,
+			Line2:      center.Address.Line2,
+			City:       center.Address.City,
+			Region:     center.Address.Region,
+			PostalCode: center.Address.PostalCode,
+			Country:    center.Address.Country,
+		}
+	}
+	if center.DeletedAt.Valid {
+		deletedAt := center.DeletedAt.Time
+		domainCenter.DeletedAt = &deletedAt
+	}
+	return domainCenter
+}
+
+func (m *CenterMapper) OpeningHoursToDbModel(hours *domain.OpeningHours) *dbmodels.OpeningHours {
+	return &dbmodels.OpeningHours{
+		ID:       hours.ID,
+		CenterID: hours.CenterID,
+		Weekday:  int(hours.Weekday),
+		OpensAt:  int(hours.OpensAt),
+		ClosesAt: int(hours.ClosesAt),
+	}
+}
+
+func (m *CenterMapper) OpeningHoursToDomain(hours *dbmodels.OpeningHours) *domain.OpeningHours {
+	return &domain.OpeningHours{
+		ID:       hours.ID,
+		CenterID: hours.CenterID,
+		Weekday:  time.Weekday(hours.Weekday),
+		OpensAt:  domain.TimeOfDay(hours.OpensAt),
+		ClosesAt: domain.TimeOfDay(hours.ClosesAt),
+	}
+}
+
+func (m *CenterMapper) ServiceToDbModel(service *domain.CenterService) *dbmodels.CenterService {
+	return &dbmodels.CenterService{
+		ID:                  service.ID,
+		CreatedAt:           service.CreatedAt,
+		UpdatedAt:           service.UpdatedAt,
+		CenterID:            service.CenterID,
+		Name:                service.Name,
+		DurationMinutes:     service.DurationMinutes,
+		BufferBeforeMinutes: service.BufferBeforeMinutes,
+		BufferAfterMinutes:  service.BufferAfterMinutes,
+		IsActive:            service.IsActive,
+	}
+}
+
+func (m *CenterMapper) ServiceToDomain(service *dbmodels.CenterService) *domain.CenterService {
+	return &domain.CenterService{
+		ID:                  service.ID,
+		CreatedAt:           service.CreatedAt,
+		UpdatedAt:           service.UpdatedAt,
+		CenterID:            service.CenterID,
+		Name:                service.Name,
+		DurationMinutes:     service.DurationMinutes,
+		BufferBeforeMinutes: service.BufferBeforeMinutes,
+		BufferAfterMinutes:  service.BufferAfterMinutes,
+		IsActive:            service.IsActive,
+	}
+}
+
+func (m *CenterMapper) ResourceToDbModel(resource *domain.CenterResource) *dbmodels.CenterResource {
+	return &dbmodels.CenterResource{
+		ID:        resource.ID,
+		CreatedAt: resource.CreatedAt,
+		UpdatedAt: resource.UpdatedAt,
+		CenterID:  resource.CenterID,
+		Name:      resource.Name,
+		IsActive:  resource.IsActive,
+	}
+}
+
+func (m *CenterMapper) ResourceToDomain(resource *dbmodels.CenterResource) *domain.CenterResource {
+	return &domain.CenterResource{
+		ID:        resource.ID,
+		CreatedAt: resource.CreatedAt,
+		UpdatedAt: resource.UpdatedAt,
+		CenterID:  resource.CenterID,
+		Name:      resource.Name,
+		IsActive:  resource.IsActive,
 	}
 }
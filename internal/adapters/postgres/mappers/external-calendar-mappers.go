@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type ExternalCalendarMapper struct{}
+
+func NewExternalCalendarMapper() *ExternalCalendarMapper {
+	return &ExternalCalendarMapper{}
+}
+
+func (m *ExternalCalendarMapper) ToDbModel(calendar *domain.ExternalCalendar) *dbmodels.ExternalCalendar {
+	return &dbmodels.ExternalCalendar{
+		ID:           calendar.ID,
+		CreatedAt:    calendar.CreatedAt,
+		UpdatedAt:    calendar.UpdatedAt,
+		CenterID:     calendar.CenterID,
+		UserID:       calendar.UserID,
+		URL:          calendar.URL,
+		LastSyncedAt: calendar.LastSyncedAt,
+	}
+}
+
+func (m *ExternalCalendarMapper) ToDomain(calendar *dbmodels.ExternalCalendar) *domain.ExternalCalendar {
+	return &domain.ExternalCalendar{
+		ID:           calendar.ID,
+		CreatedAt:    calendar.CreatedAt,
+		UpdatedAt:    calendar.UpdatedAt,
+		CenterID:     calendar.CenterID,
+		UserID:       calendar.UserID,
+		URL:          calendar.URL,
+		LastSyncedAt: calendar.LastSyncedAt,
+	}
+}
@@ -0,0 +1,70 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type LeadMapper struct{}
+
+func NewLeadMapper() *LeadMapper {
+	return &LeadMapper{}
+}
+
+func (m *LeadMapper) ToDbModel(lead *domain.Lead) *dbmodels.Lead {
+	return &dbmodels.Lead{
+		ID:        lead.ID,
+		CreatedAt: lead.CreatedAt,
+		UpdatedAt: lead.UpdatedAt,
+		CenterID:  lead.CenterID,
+		Name:      lead.Name,
+		Email:     lead.Email,
+		Phone:     lead.Phone,
+		Notes:     lead.Notes,
+	}
+}
+
+func (m *LeadMapper) ToDomain(lead *dbmodels.Lead) *domain.Lead {
+	return &domain.Lead{
+		ID:        lead.ID,
+		CreatedAt: lead.CreatedAt,
+		UpdatedAt: lead.UpdatedAt,
+		CenterID:  lead.CenterID,
+		Name:      lead.Name,
+		Email:     lead.Email,
+		Phone:     lead.Phone,
+		Notes:     lead.Notes,
+	}
+}
+
+func (m *LeadMapper) ConsentToDbModel(consent *domain.LeadConsent) *dbmodels.LeadConsent {
+	return &dbmodels.LeadConsent{
+		ID:            consent.ID,
+		CreatedAt:     consent.CreatedAt,
+		LeadID:        consent.LeadID,
+		Purpose:       string(consent.Purpose),
+		Granted:       consent.Granted,
+		PolicyVersion: consent.PolicyVersion,
+		Statement:     consent.Statement,
+		Source:        string(consent.Source),
+		IPAddress:     consent.IPAddress,
+		UserAgent:     consent.UserAgent,
+		RecordedBy:    consent.RecordedBy,
+	}
+}
+
+func (m *LeadMapper) ConsentToDomain(consent *dbmodels.LeadConsent) *domain.LeadConsent {
+	return &domain.LeadConsent{
+		ID:            consent.ID,
+		CreatedAt:     consent.CreatedAt,
+		LeadID:        consent.LeadID,
+		Purpose:       domain.ConsentPurpose(consent.Purpose),
+		Granted:       consent.Granted,
+		PolicyVersion: consent.PolicyVersion,
+		Statement:     consent.Statement,
+		Source:        domain.ConsentSource(consent.Source),
+		IPAddress:     consent.IPAddress,
+		UserAgent:     consent.UserAgent,
+		RecordedBy:    consent.RecordedBy,
+	}
+}
@@ -0,0 +1,32 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type LoginThrottleMapper struct{}
+
+func NewLoginThrottleMapper() *LoginThrottleMapper {
+	return &LoginThrottleMapper{}
+}
+
+func (m *LoginThrottleMapper) ToDbModel(throttle *domain.LoginThrottle) *dbmodels.LoginThrottle {
+	return &dbmodels.LoginThrottle{
+		Key:             throttle.Key,
+		FailedCount:     throttle.FailedCount,
+		WindowStartedAt: throttle.WindowStartedAt,
+		LastFailedAt:    throttle.LastFailedAt,
+		LockedUntil:     throttle.LockedUntil,
+	}
+}
+
+func (m *LoginThrottleMapper) ToDomain(throttle *dbmodels.LoginThrottle) *domain.LoginThrottle {
+	return &domain.LoginThrottle{
+		Key:             throttle.Key,
+		FailedCount:     throttle.FailedCount,
+		WindowStartedAt: throttle.WindowStartedAt,
+		LastFailedAt:    throttle.LastFailedAt,
+		LockedUntil:     throttle.LockedUntil,
+	}
+}
@@ -0,0 +1,32 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type RecoveryCodeMapper struct{}
+
+func NewRecoveryCodeMapper() *RecoveryCodeMapper {
+	return &RecoveryCodeMapper{}
+}
+
+func (m *RecoveryCodeMapper) ToDbModel(code *domain.RecoveryCode) *dbmodels.RecoveryCode {
+	return &dbmodels.RecoveryCode{
+		ID:        code.ID,
+		CreatedAt: code.CreatedAt,
+		UserID:    code.UserID,
+		CodeHash:  code.CodeHash,
+		UsedAt:    code.UsedAt,
+	}
+}
+
+func (m *RecoveryCodeMapper) ToDomain(code *dbmodels.RecoveryCode) *domain.RecoveryCode {
+	return &domain.RecoveryCode{
+		ID:        code.ID,
+		CreatedAt: code.CreatedAt,
+		UserID:    code.UserID,
+		CodeHash:  code.CodeHash,
+		UsedAt:    code.UsedAt,
+	}
+}
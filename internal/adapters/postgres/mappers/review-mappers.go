@@ -0,0 +1,52 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type ReviewMapper struct{}
+
+func NewReviewMapper() *ReviewMapper {
+	return &ReviewMapper{}
+}
+
+func (m *ReviewMapper) ToDbModel(review *domain.AppointmentReview) *dbmodels.AppointmentReview {
+	return &dbmodels.AppointmentReview{
+		ID:             review.ID,
+		CreatedAt:      review.CreatedAt,
+		UpdatedAt:      review.UpdatedAt,
+		CenterID:       review.CenterID,
+		AppointmentID:  review.AppointmentID,
+		StaffID:        review.StaffID,
+		ServiceID:      review.ServiceID,
+		LeadID:         review.LeadID,
+		AuthorName:     review.AuthorName,
+		Rating:         review.Rating,
+		Comment:        review.Comment,
+		Status:         string(review.Status),
+		ModerationNote: review.ModerationNote,
+		ModeratedBy:    review.ModeratedBy,
+		ModeratedAt:    review.ModeratedAt,
+	}
+}
+
+func (m *ReviewMapper) ToDomain(review *dbmodels.AppointmentReview) *domain.AppointmentReview {
+	return &domain.AppointmentReview{
+		ID:             review.ID,
+		CreatedAt:      review.CreatedAt,
+		UpdatedAt:      review.UpdatedAt,
+		CenterID:       review.CenterID,
+		AppointmentID:  review.AppointmentID,
+		StaffID:        review.StaffID,
+		ServiceID:      review.ServiceID,
+		LeadID:         review.LeadID,
+		AuthorName:     review.AuthorName,
+		Rating:         review.Rating,
+		Comment:        review.Comment,
+		Status:         domain.ReviewStatus(review.Status),
+		ModerationNote: review.ModerationNote,
+		ModeratedBy:    review.ModeratedBy,
+		ModeratedAt:    review.ModeratedAt,
+	}
+}
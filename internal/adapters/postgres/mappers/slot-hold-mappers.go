@@ -0,0 +1,42 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type SlotHoldMapper struct{}
+
+func NewSlotHoldMapper() *SlotHoldMapper {
+	return &SlotHoldMapper{}
+}
+
+func (m *SlotHoldMapper) ToDbModel(hold *domain.SlotHold) *dbmodels.SlotHold {
+	return &dbmodels.SlotHold{
+		ID:        hold.ID,
+		CreatedAt: hold.CreatedAt,
+		CenterID:  hold.CenterID,
+		StaffID:   hold.StaffID,
+		ServiceID: hold.ServiceID,
+		StartsAt:  hold.StartsAt,
+		EndsAt:    hold.EndsAt,
+		ExpiresAt: hold.ExpiresAt,
+		TokenHash: hold.TokenHash,
+		IPAddress: hold.IPAddress,
+	}
+}
+
+func (m *SlotHoldMapper) ToDomain(hold *dbmodels.SlotHold) *domain.SlotHold {
+	return &domain.SlotHold{
+		ID:        hold.ID,
+		CreatedAt: hold.CreatedAt,
+		CenterID:  hold.CenterID,
+		StaffID:   hold.StaffID,
+		ServiceID: hold.ServiceID,
+		StartsAt:  hold.StartsAt,
+		EndsAt:    hold.EndsAt,
+		ExpiresAt: hold.ExpiresAt,
+		TokenHash: hold.TokenHash,
+		IPAddress: hold.IPAddress,
+	}
+}
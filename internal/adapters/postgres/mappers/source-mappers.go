@@ -18,11 +18,13 @@ func (m *SourceMapper) DomainToDBModel(source *domain.Source) *dbmodels.Source {
 		CreatedAt:             source.CreatedAt,
 		UpdatedAt:             source.UpdatedAt,
 		UserID:                source.UserID,
-		RefreshToken:          source.RefreshToken,
+		RefreshTokenHash:      source.RefreshTokenHash,
 		UserAgent:             source.UserAgent,
 		IPAddress:             source.IPAddress,
 		IsActive:              source.IsActive,
 		RefreshTokenExpiresAt: source.RefreshTokenExpiresAt,
+		City:                  source.City,
+		CountryCode:           source.CountryCode,
 	}
 }
 
@@ -30,11 +32,13 @@ func (m *SourceMapper) DBModelToDomain(source *dbmodels.Source) *domain.Source {
 	return &domain.Source{
 		ID:                    source.ID,
 		UserID:                source.UserID,
-		RefreshToken:          source.RefreshToken,
+		RefreshTokenHash:      source.RefreshTokenHash,
 		UserAgent:             source.UserAgent,
 		IPAddress:             source.IPAddress,
 		IsActive:              source.IsActive,
 		RefreshTokenExpiresAt: source.RefreshTokenExpiresAt,
+		City:                  source.City,
+		CountryCode:           source.CountryCode,
 		CreatedAt:             source.CreatedAt,
 		UpdatedAt:             source.UpdatedAt,
 	}
@@ -0,0 +1,48 @@
+package mappers
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type TimeBlockMapper struct{}
+
+func NewTimeBlockMapper() *TimeBlockMapper {
+	return &TimeBlockMapper{}
+}
+
+func (m *TimeBlockMapper) ToDbModel(block *domain.TimeBlock) *dbmodels.TimeBlock {
+	return &dbmodels.TimeBlock{
+		ID:          block.ID,
+		CreatedAt:   block.CreatedAt,
+		UpdatedAt:   block.UpdatedAt,
+		CenterID:    block.CenterID,
+		UserID:      block.UserID,
+		StartsAt:    block.StartsAt,
+		EndsAt:      block.EndsAt,
+		AllDay:      block.AllDay,
+		Reason:      string(block.Reason),
+		Note:        block.Note,
+		Source:      string(block.Source),
+		ExternalUID: block.ExternalUID,
+		ExternalKey: block.ExternalKey,
+	}
+}
+
+func (m *TimeBlockMapper) ToDomain(block *dbmodels.TimeBlock) *domain.TimeBlock {
+	return &domain.TimeBlock{
+		ID:          block.ID,
+		CreatedAt:   block.CreatedAt,
+		UpdatedAt:   block.UpdatedAt,
+		CenterID:    block.CenterID,
+		UserID:      block.UserID,
+		StartsAt:    block.StartsAt,
+		EndsAt:      block.EndsAt,
+		AllDay:      block.AllDay,
+		Reason:      domain.TimeBlockReason(block.Reason),
+		Note:        block.Note,
+		Source:      domain.TimeBlockSource(block.Source),
+		ExternalUID: block.ExternalUID,
+		ExternalKey: block.ExternalKey,
+	}
+}
@@ -21,6 +21,11 @@ func (m *UserMapper) ToDbModel(user *domain.User) *dbmodels.User {
 		Password:  user.Password,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
+
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		MFASecret:       user.MFASecret,
+		MFAEnabledAt:    user.MFAEnabledAt,
+		MFALastUsedStep: user.MFALastUsedStep,
 	}
 }
 
@@ -33,5 +38,10 @@ func (m *UserMapper) ToDomain(u *dbmodels.User) *domain.User {
 		LastName:  u.LastName,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
+
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		MFASecret:       u.MFASecret,
+		MFAEnabledAt:    u.MFAEnabledAt,
+		MFALastUsedStep: u.MFALastUsedStep,
 	}
 }
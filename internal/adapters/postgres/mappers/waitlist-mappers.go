@@ -0,0 +1,84 @@
+package mappers
+
+import (
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/domain"
+)
+
+type WaitlistMapper struct{}
+
+func NewWaitlistMapper() *WaitlistMapper {
+	return &WaitlistMapper{}
+}
+
+func (m *WaitlistMapper) ToDbModel(entry *domain.WaitlistEntry) *dbmodels.WaitlistEntry {
+	return &dbmodels.WaitlistEntry{
+		ID:        entry.ID,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+		CenterID:  entry.CenterID,
+		LeadID:    entry.LeadID,
+		ServiceID: entry.ServiceID,
+		StaffID:   entry.StaffID,
+		FromDate:  entry.FromDate.In(time.UTC),
+		ToDate:    entry.ToDate.In(time.UTC),
+		Status:    string(entry.Status),
+		Notes:     entry.Notes,
+	}
+}
+
+func (m *WaitlistMapper) ToDomain(entry *dbmodels.WaitlistEntry) *domain.WaitlistEntry {
+	return &domain.WaitlistEntry{
+		ID:        entry.ID,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+		CenterID:  entry.CenterID,
+		LeadID:    entry.LeadID,
+		ServiceID: entry.ServiceID,
+		StaffID:   entry.StaffID,
+		FromDate:  domain.DateOf(entry.FromDate.UTC()),
+		ToDate:    domain.DateOf(entry.ToDate.UTC()),
+		Status:    domain.WaitlistStatus(entry.Status),
+		Notes:     entry.Notes,
+	}
+}
+
+func (m *WaitlistMapper) OfferToDbModel(offer *domain.WaitlistOffer) *dbmodels.WaitlistOffer {
+	return &dbmodels.WaitlistOffer{
+		ID:            offer.ID,
+		CreatedAt:     offer.CreatedAt,
+		CenterID:      offer.CenterID,
+		EntryID:       offer.EntryID,
+		HoldID:        offer.HoldID,
+		StaffID:       offer.StaffID,
+		ServiceID:     offer.ServiceID,
+		StartsAt:      offer.StartsAt,
+		EndsAt:        offer.EndsAt,
+		ExpiresAt:     offer.ExpiresAt,
+		Status:        string(offer.Status),
+		AppointmentID: offer.AppointmentID,
+		TokenHash:     offer.TokenHash,
+		RespondedAt:   offer.RespondedAt,
+	}
+}
+
+func (m *WaitlistMapper) OfferToDomain(offer *dbmodels.WaitlistOffer) *domain.WaitlistOffer {
+	return &domain.WaitlistOffer{
+		ID:            offer.ID,
+		CreatedAt:     offer.CreatedAt,
+		CenterID:      offer.CenterID,
+		EntryID:       offer.EntryID,
+		HoldID:        offer.HoldID,
+		StaffID:       offer.StaffID,
+		ServiceID:     offer.ServiceID,
+		StartsAt:      offer.StartsAt,
+		EndsAt:        offer.EndsAt,
+		ExpiresAt:     offer.ExpiresAt,
+		Status:        domain.WaitlistOfferStatus(offer.Status),
+		AppointmentID: offer.AppointmentID,
+		TokenHash:     offer.TokenHash,
+		RespondedAt:   offer.RespondedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"fmt"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"gorm.io/gorm"
+)
+
+// addAppointmentOverlapConstraints makes the database reject an appointment
+// that overlaps another one of the same staff member or resource, so two
+// concurrent bookings of the same time can never both succeed. Cancelled and
+// no show appointments do not take time. It is idempotent.
+func addAppointmentOverlapConstraints(db *gorm.DB) error {
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS btree_gist`).Error; err != nil {
+		return err
+	}
+
+	return addConstraints(db, "appointments", map[string]string{
+		dbmodels.AppointmentStaffOverlapConstraint: `EXCLUDE USING gist (
+			staff_id WITH =,
+			tstzrange(starts_at, ends_at, '[)') WITH &&
+		) WHERE (status NOT IN ('cancelled', 'no_show'))`,
+		dbmodels.AppointmentResourceOverlapConstraint: `EXCLUDE USING gist (
+			resource_id WITH =,
+			tstzrange(starts_at, ends_at, '[)') WITH &&
+		) WHERE (resource_id IS NOT NULL AND status NOT IN ('cancelled', 'no_show'))`,
+	})
+}
+
+// addConstraints adds the constraints the table does not have yet
+func addConstraints(db *gorm.DB, table string, constraints map[string]string) error {
+	for name, definition := range constraints {
+		var exists bool
+		err := db.Raw(`SELECT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = ?)`, name).Scan(&exists).Error
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		err = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT %s %s`, table, name, definition)).Error
+		if err != nil {
+			return fmt.Errorf("adding %s, overlapping rows of %s must be removed first: %w", name, table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// backfillOwnerMemberships gives the owner of every center created before
+// memberships existed an owner membership. It is idempotent.
+func backfillOwnerMemberships(db *gorm.DB) error {
+	result := db.Exec(`
+		INSERT INTO center_memberships (id, created_at, updated_at, center_id, user_id, role)
+		SELECT gen_random_uuid(), now(), now(), centers.id, centers.owner_id, 'owner'
+		FROM centers
+		WHERE NOT EXISTS (
+			SELECT 1 FROM center_memberships
+			WHERE center_memberships.center_id = centers.id AND center_memberships.user_id = centers.owner_id
+		)`)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Created %d owner memberships for existing centers", result.RowsAffected)
+	}
+	return nil
+}
@@ -7,13 +7,51 @@ import (
 )
 
 func Migrate(db *gorm.DB) error {
+	// One-shot data migrations that must run before the schema is updated
+	if err := invalidatePlaintextRefreshTokens(db); err != nil {
+		return err
+	}
+
 	// Models to migrate
 	models := []interface{}{
 		&dbmodels.User{},
 		&dbmodels.Source{},
+		&dbmodels.RotatedToken{},
 		&dbmodels.Center{},
+		&dbmodels.CenterMembership{},
+		&dbmodels.OpeningHours{},
+		&dbmodels.CenterService{},
+		&dbmodels.CenterResource{},
+		&dbmodels.AvailabilityRule{},
+		&dbmodels.TimeBlock{},
+		&dbmodels.AppointmentSeries{},
+		&dbmodels.Appointment{},
+		&dbmodels.CalendarFeed{},
+		&dbmodels.ExternalCalendar{},
+		&dbmodels.Lead{},
+		&dbmodels.LeadConsent{},
+		&dbmodels.SlotHold{},
+		&dbmodels.WaitlistEntry{},
+		&dbmodels.WaitlistOffer{},
+		&dbmodels.AppointmentReview{},
+		&dbmodels.AccountToken{},
+		&dbmodels.RecoveryCode{},
+		&dbmodels.LoginThrottle{},
 	}
 
 	// Auto-migrate all models
-	return db.AutoMigrate(models...)
+	if err := db.AutoMigrate(models...); err != nil {
+		return err
+	}
+
+	// Constraints that gorm can't declare
+	if err := addAppointmentOverlapConstraints(db); err != nil {
+		return err
+	}
+	if err := addSlotHoldOverlapConstraint(db); err != nil {
+		return err
+	}
+
+	// Data migrations that need the updated schema
+	return backfillOwnerMemberships(db)
 }
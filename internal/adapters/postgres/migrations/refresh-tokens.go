@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"log"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"gorm.io/gorm"
+)
+
+// invalidatePlaintextRefreshTokens moves sources and rotated tokens from the
+// plaintext refresh_token column to refresh_token_hash. Plaintext tokens cannot
+// be kept, so every existing source is deactivated and its users have to log in
+// again. It only runs while the legacy column is still present.
+func invalidatePlaintextRefreshTokens(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		migrator := tx.Migrator()
+
+		if migrator.HasTable(&dbmodels.Source{}) && migrator.HasColumn(&dbmodels.Source{}, "refresh_token") {
+			log.Println("Invalidating plaintext refresh tokens in sources")
+			statements := []string{
+				`ALTER TABLE sources ADD COLUMN IF NOT EXISTS refresh_token_hash text`,
+				`UPDATE sources SET is_active = false, refresh_token_hash = 'invalidated:' || id::text`,
+				`ALTER TABLE sources DROP COLUMN refresh_token`,
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if migrator.HasTable(&dbmodels.RotatedToken{}) && migrator.HasColumn(&dbmodels.RotatedToken{}, "refresh_token") {
+			log.Println("Dropping plaintext rotated refresh tokens")
+			statements := []string{
+				`DELETE FROM rotated_tokens`,
+				`ALTER TABLE rotated_tokens DROP COLUMN refresh_token`,
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"gorm.io/gorm"
+)
+
+// addSlotHoldOverlapConstraint keeps two customers from holding the same time
+// of a staff member. It needs btree_gist, see
+// addAppointmentOverlapConstraints.
+func addSlotHoldOverlapConstraint(db *gorm.DB) error {
+	return addConstraints(db, "slot_holds", map[string]string{
+		dbmodels.SlotHoldStaffOverlapConstraint: `EXCLUDE USING gist (
+			staff_id WITH =,
+			tstzrange(starts_at, ends_at, '[)') WITH &&
+		)`,
+	})
+}
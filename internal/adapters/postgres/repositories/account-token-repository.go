@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PGAccountTokenRepository struct {
+	db     *gorm.DB
+	mapper *mappers.AccountTokenMapper
+	logger ports.Logger
+}
+
+func NewAccountTokenRepository(db *gorm.DB, logger ports.Logger) ports.AccountTokenRepository {
+	return &PGAccountTokenRepository{
+		db:     db,
+		mapper: mappers.NewAccountTokenMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGAccountTokenRepository) Create(ctx context.Context, token *domain.AccountToken) error {
+	dbToken := repo.mapper.ToDbModel(token)
+	result := repo.db.WithContext(ctx).Create(dbToken)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	token.ID = dbToken.ID
+	return nil
+}
+
+func (repo *PGAccountTokenRepository) GetValid(ctx context.Context, purpose domain.AccountTokenPurpose, tokenHash string) (*domain.AccountToken, error) {
+	var dbToken dbmodels.AccountToken
+	result := repo.db.WithContext(ctx).
+		Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", string(purpose), tokenHash, time.Now()).
+		First(&dbToken)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrAccountTokenInvalid
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbToken), nil
+}
+
+// Consume flags the token as used in a single conditional update, so the same
+// token can never be redeemed twice even under concurrent requests
+func (repo *PGAccountTokenRepository) Consume(ctx context.Context, purpose domain.AccountTokenPurpose, tokenHash string) (*domain.AccountToken, error) {
+	var dbTokens []dbmodels.AccountToken
+	now := time.Now()
+	result := repo.db.WithContext(ctx).
+		Model(&dbTokens).
+		Clauses(clause.Returning{}).
+		Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", string(purpose), tokenHash, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 || len(dbTokens) == 0 {
+		return nil, exceptions.ErrAccountTokenInvalid
+	}
+
+	return repo.mapper.ToDomain(&dbTokens[0]), nil
+}
+
+func (repo *PGAccountTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose domain.AccountTokenPurpose) error {
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.AccountToken{}, "user_id = ? AND purpose = ?", userID, string(purpose))
+	return result.Error
+}
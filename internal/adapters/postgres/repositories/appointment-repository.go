@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// exclusionViolation is the SQLSTATE raised when an exclusion constraint fails
+const exclusionViolation = "23P01"
+
+// busyAppointmentStatuses are the statuses that take the time of the staff
+// member, see AppointmentStatus.BlocksTime
+var busyAppointmentStatuses = []string{
+	string(domain.AppointmentStatusRequested),
+	string(domain.AppointmentStatusConfirmed),
+	string(domain.AppointmentStatusCheckedIn),
+	string(domain.AppointmentStatusCompleted),
+}
+
+type PGAppointmentRepository struct {
+	db     *gorm.DB
+	mapper *mappers.AppointmentMapper
+	logger ports.Logger
+}
+
+func NewAppointmentRepository(db *gorm.DB, logger ports.Logger) ports.AppointmentRepository {
+	return &PGAppointmentRepository{
+		db:     db,
+		mapper: mappers.NewAppointmentMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGAppointmentRepository) Create(ctx context.Context, appointment *domain.Appointment) error {
+	dbAppointment := repo.mapper.ToDbModel(appointment)
+	result := repo.db.WithContext(ctx).Omit("Center", "Staff", "Service", "Resource", "Lead", "Series").Create(dbAppointment)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return exceptions.ErrAppointmentOccurrenceExists
+	}
+	if result.Error != nil {
+		return translateOverlapError(result.Error)
+	}
+
+	appointment.ID = dbAppointment.ID
+	appointment.CreatedAt = dbAppointment.CreatedAt
+	appointment.UpdatedAt = dbAppointment.UpdatedAt
+	return nil
+}
+
+func (repo *PGAppointmentRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Appointment, error) {
+	var dbAppointment dbmodels.Appointment
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, id).
+		First(&dbAppointment)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrAppointmentNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbAppointment), nil
+}
+
+func (repo *PGAppointmentRepository) List(ctx context.Context, centerID uuid.UUID, filter *domain.AppointmentFilter) ([]*domain.Appointment, error) {
+	query := repo.db.WithContext(ctx).Where("center_id = ?", centerID)
+	if filter.StaffID != nil {
+		query = query.Where("staff_id = ?", *filter.StaffID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.From != nil {
+		query = query.Where("ends_at > ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("starts_at < ?", *filter.To)
+	}
+
+	dbAppointments := []dbmodels.Appointment{}
+	result := query.Order("starts_at ASC").Find(&dbAppointments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	appointments := make([]*domain.Appointment, len(dbAppointments))
+	for i := range dbAppointments {
+		appointments[i] = repo.mapper.ToDomain(&dbAppointments[i])
+	}
+	return appointments, nil
+}
+
+func (repo *PGAppointmentRepository) Update(ctx context.Context, appointment *domain.Appointment) error {
+	appointment.UpdatedAt = time.Now()
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.Appointment{}).
+		Where("center_id = ? AND id = ?", appointment.CenterID, appointment.ID).
+		Updates(map[string]interface{}{
+			"staff_id":       appointment.StaffID,
+			"service_id":     appointment.ServiceID,
+			"resource_id":    appointment.ResourceID,
+			"starts_at":      appointment.StartsAt,
+			"ends_at":        appointment.EndsAt,
+			"customer_name":  appointment.CustomerName,
+			"customer_email": appointment.CustomerEmail,
+			"customer_phone": appointment.CustomerPhone,
+			"notes":          appointment.Notes,
+			"link_version":   gorm.Expr("link_version + 1"),
+			"updated_at":     appointment.UpdatedAt,
+		})
+	if result.Error != nil {
+		return translateOverlapError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrAppointmentNotFound
+	}
+	appointment.LinkVersion++
+	return nil
+}
+
+func (repo *PGAppointmentRepository) RevokeLinks(ctx context.Context, appointment *domain.Appointment) error {
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.Appointment{}).
+		Where("center_id = ? AND id = ?", appointment.CenterID, appointment.ID).
+		Update("link_version", gorm.Expr("link_version + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrAppointmentNotFound
+	}
+	appointment.LinkVersion++
+	return nil
+}
+
+func (repo *PGAppointmentRepository) UpdateStatus(ctx context.Context, appointment *domain.Appointment, from domain.AppointmentStatus) error {
+	appointment.UpdatedAt = time.Now()
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.Appointment{}).
+		Where("center_id = ? AND id = ? AND status = ?", appointment.CenterID, appointment.ID, string(from)).
+		Updates(map[string]interface{}{
+			"status":              string(appointment.Status),
+			"cancellation_reason": appointment.CancellationReason,
+			"confirmed_at":        appointment.ConfirmedAt,
+			"checked_in_at":       appointment.CheckedInAt,
+			"completed_at":        appointment.CompletedAt,
+			"cancelled_at":        appointment.CancelledAt,
+			"no_show_at":          appointment.NoShowAt,
+			"updated_at":          appointment.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrAppointmentStatusChanged
+	}
+	return nil
+}
+
+func (repo *PGAppointmentRepository) ListBusyInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.Appointment, error) {
+	dbAppointments := []dbmodels.Appointment{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND status IN ? AND ends_at > ? AND starts_at < ?", centerID, busyAppointmentStatuses, from, to).
+		Order("starts_at ASC").
+		Find(&dbAppointments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	appointments := make([]*domain.Appointment, len(dbAppointments))
+	for i := range dbAppointments {
+		appointments[i] = repo.mapper.ToDomain(&dbAppointments[i])
+	}
+	return appointments, nil
+}
+
+// translateOverlapError turns a violation of the overlap constraints into a
+// conflict, two concurrent bookings of the same time can't both be stored
+func translateOverlapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != exclusionViolation {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case dbmodels.AppointmentStaffOverlapConstraint:
+		return exceptions.StaffDoubleBooked()
+	case dbmodels.AppointmentResourceOverlapConstraint:
+		return exceptions.ResourceDoubleBooked()
+	default:
+		return err
+	}
+}
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGAppointmentSeriesRepository struct {
+	db                *gorm.DB
+	mapper            *mappers.AppointmentSeriesMapper
+	appointmentMapper *mappers.AppointmentMapper
+	logger            ports.Logger
+}
+
+func NewAppointmentSeriesRepository(db *gorm.DB, logger ports.Logger) ports.AppointmentSeriesRepository {
+	return &PGAppointmentSeriesRepository{
+		db:                db,
+		mapper:            mappers.NewAppointmentSeriesMapper(),
+		appointmentMapper: mappers.NewAppointmentMapper(),
+		logger:            logger,
+	}
+}
+
+func (repo *PGAppointmentSeriesRepository) Create(ctx context.Context, series *domain.AppointmentSeries) error {
+	return repo.create(repo.db.WithContext(ctx), series)
+}
+
+func (repo *PGAppointmentSeriesRepository) create(tx *gorm.DB, series *domain.AppointmentSeries) error {
+	dbSeries := repo.mapper.ToDbModel(series)
+	result := tx.Omit("Center", "Staff", "Service", "Resource").Create(dbSeries)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	series.ID = dbSeries.ID
+	series.CreatedAt = dbSeries.CreatedAt
+	series.UpdatedAt = dbSeries.UpdatedAt
+	return nil
+}
+
+func (repo *PGAppointmentSeriesRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AppointmentSeries, error) {
+	var dbSeries dbmodels.AppointmentSeries
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, id).
+		First(&dbSeries)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrAppointmentSeriesNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbSeries), nil
+}
+
+func (repo *PGAppointmentSeriesRepository) Update(ctx context.Context, series *domain.AppointmentSeries) error {
+	return repo.update(repo.db.WithContext(ctx), series)
+}
+
+func (repo *PGAppointmentSeriesRepository) update(tx *gorm.DB, series *domain.AppointmentSeries) error {
+	series.UpdatedAt = time.Now()
+	dbSeries := repo.mapper.ToDbModel(series)
+	result := tx.
+		Model(&dbmodels.AppointmentSeries{}).
+		Where("center_id = ? AND id = ?", series.CenterID, series.ID).
+		Updates(map[string]interface{}{
+			"staff_id":         dbSeries.StaffID,
+			"service_id":       dbSeries.ServiceID,
+			"resource_id":      dbSeries.ResourceID,
+			"rrule":            dbSeries.RRule,
+			"starts_at":        dbSeries.StartsAt,
+			"ends_at":          dbSeries.EndsAt,
+			"duration_minutes": dbSeries.DurationMinutes,
+			"ex_dates":         dbSeries.ExDates,
+			"customer_name":    dbSeries.CustomerName,
+			"customer_email":   dbSeries.CustomerEmail,
+			"customer_phone":   dbSeries.CustomerPhone,
+			"notes":            dbSeries.Notes,
+			"cancelled_at":     dbSeries.CancelledAt,
+			"updated_at":       dbSeries.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrAppointmentSeriesNotFound
+	}
+	return nil
+}
+
+func (repo *PGAppointmentSeriesRepository) Move(ctx context.Context, series *domain.AppointmentSeries, days int) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := repo.update(tx, series); err != nil {
+			return err
+		}
+		if days == 0 {
+			return nil
+		}
+
+		// The occurrences are moved one at a time, the furthest first, so
+		// none lands on the date of another before it moves
+		order := "occurrence_date DESC"
+		if days < 0 {
+			order = "occurrence_date ASC"
+		}
+		var stored []dbmodels.Appointment
+		err := tx.Select("id", "occurrence_date").
+			Where("center_id = ? AND series_id = ?", series.CenterID, series.ID).
+			Order(order).
+			Find(&stored).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, appointment := range stored {
+			err = tx.Model(&dbmodels.Appointment{}).
+				Where("id = ?", appointment.ID).
+				Updates(map[string]interface{}{
+					"occurrence_date": appointment.OccurrenceDate.AddDate(0, 0, days),
+					"updated_at":      now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (repo *PGAppointmentSeriesRepository) Split(ctx context.Context, series *domain.AppointmentSeries, next *domain.AppointmentSeries, from domain.Date) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := repo.update(tx, series); err != nil {
+			return err
+		}
+		if err := repo.create(tx, next); err != nil {
+			return err
+		}
+
+		return tx.Model(&dbmodels.Appointment{}).
+			Where("center_id = ? AND series_id = ? AND occurrence_date >= ?", series.CenterID, series.ID, from.In(time.UTC)).
+			Updates(map[string]interface{}{
+				"series_id":  next.ID,
+				"updated_at": time.Now(),
+			}).Error
+	})
+}
+
+func (repo *PGAppointmentSeriesRepository) ListActive(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.AppointmentSeries, error) {
+	dbSeries := []dbmodels.AppointmentSeries{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND cancelled_at IS NULL AND starts_at < ? AND (ends_at IS NULL OR ends_at > ?)", centerID, to, from).
+		Order("starts_at ASC").
+		Find(&dbSeries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	series := make([]*domain.AppointmentSeries, len(dbSeries))
+	for i := range dbSeries {
+		series[i] = repo.mapper.ToDomain(&dbSeries[i])
+	}
+	return series, nil
+}
+
+func (repo *PGAppointmentSeriesRepository) ListOccurrences(ctx context.Context, centerID uuid.UUID, seriesIDs []uuid.UUID, from domain.Date, to domain.Date) ([]*domain.Appointment, error) {
+	if len(seriesIDs) == 0 {
+		return []*domain.Appointment{}, nil
+	}
+
+	dbAppointments := []dbmodels.Appointment{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND series_id IN ? AND occurrence_date BETWEEN ? AND ?", centerID, seriesIDs, from.In(time.UTC), to.In(time.UTC)).
+		Order("occurrence_date ASC").
+		Find(&dbAppointments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	appointments := make([]*domain.Appointment, len(dbAppointments))
+	for i := range dbAppointments {
+		appointments[i] = repo.appointmentMapper.ToDomain(&dbAppointments[i])
+	}
+	return appointments, nil
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGAvailabilityRepository struct {
+	db     *gorm.DB
+	mapper *mappers.AvailabilityMapper
+	logger ports.Logger
+}
+
+func NewAvailabilityRepository(db *gorm.DB, logger ports.Logger) ports.AvailabilityRepository {
+	return &PGAvailabilityRepository{
+		db:     db,
+		mapper: mappers.NewAvailabilityMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGAvailabilityRepository) Create(ctx context.Context, rule *domain.AvailabilityRule) error {
+	dbRule := repo.mapper.ToDbModel(rule)
+	result := repo.db.WithContext(ctx).Omit("Center", "User").Create(dbRule)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	rule.ID = dbRule.ID
+	rule.CreatedAt = dbRule.CreatedAt
+	rule.UpdatedAt = dbRule.UpdatedAt
+	return nil
+}
+
+func (repo *PGAvailabilityRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AvailabilityRule, error) {
+	var dbRule dbmodels.AvailabilityRule
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, id).
+		First(&dbRule)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrAvailabilityRuleNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbRule), nil
+}
+
+func (repo *PGAvailabilityRepository) ListByStaff(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) ([]*domain.AvailabilityRule, error) {
+	dbRules := []dbmodels.AvailabilityRule{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND user_id = ?", centerID, userID).
+		Order("weekday ASC, start_time ASC").
+		Find(&dbRules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rules := make([]*domain.AvailabilityRule, len(dbRules))
+	for i := range dbRules {
+		rules[i] = repo.mapper.ToDomain(&dbRules[i])
+	}
+	return rules, nil
+}
+
+func (repo *PGAvailabilityRepository) ListActiveByCenter(ctx context.Context, centerID uuid.UUID) ([]*domain.AvailabilityRule, error) {
+	dbRules := []dbmodels.AvailabilityRule{}
+	result := repo.db.WithContext(ctx).
+		Joins("JOIN center_memberships ON center_memberships.center_id = availability_rules.center_id AND center_memberships.user_id = availability_rules.user_id").
+		Where("availability_rules.center_id = ? AND availability_rules.is_active", centerID).
+		Order("availability_rules.user_id ASC, availability_rules.weekday ASC, availability_rules.start_time ASC").
+		Find(&dbRules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rules := make([]*domain.AvailabilityRule, len(dbRules))
+	for i := range dbRules {
+		rules[i] = repo.mapper.ToDomain(&dbRules[i])
+	}
+	return rules, nil
+}
+
+func (repo *PGAvailabilityRepository) Update(ctx context.Context, rule *domain.AvailabilityRule) error {
+	rule.UpdatedAt = time.Now()
+	dbRule := repo.mapper.ToDbModel(rule)
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.AvailabilityRule{}).
+		Where("center_id = ? AND id = ?", rule.CenterID, rule.ID).
+		Updates(map[string]interface{}{
+			"weekday":         dbRule.Weekday,
+			"start_time":      dbRule.StartTime,
+			"end_time":        dbRule.EndTime,
+			"effective_from":  dbRule.EffectiveFrom,
+			"effective_until": dbRule.EffectiveUntil,
+			"is_active":       dbRule.IsActive,
+			"updated_at":      dbRule.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrAvailabilityRuleNotFound
+	}
+	return nil
+}
+
+func (repo *PGAvailabilityRepository) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.AvailabilityRule{}, "center_id = ? AND id = ?", centerID, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrAvailabilityRuleNotFound
+	}
+	return nil
+}
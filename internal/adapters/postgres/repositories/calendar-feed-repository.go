@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGCalendarFeedRepository struct {
+	db     *gorm.DB
+	mapper *mappers.CalendarFeedMapper
+	logger ports.Logger
+}
+
+func NewCalendarFeedRepository(db *gorm.DB, logger ports.Logger) ports.CalendarFeedRepository {
+	return &PGCalendarFeedRepository{
+		db:     db,
+		mapper: mappers.NewCalendarFeedMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGCalendarFeedRepository) Rotate(ctx context.Context, feed *domain.CalendarFeed) error {
+	dbFeed := repo.mapper.ToDbModel(feed)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&dbmodels.CalendarFeed{}).
+			Where("center_id = ? AND user_id = ? AND revoked_at IS NULL", feed.CenterID, feed.UserID).
+			Update("revoked_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Omit("Center", "User").Create(dbFeed).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	feed.ID = dbFeed.ID
+	feed.CreatedAt = dbFeed.CreatedAt
+	return nil
+}
+
+func (repo *PGCalendarFeedRepository) Revoke(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) error {
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.CalendarFeed{}).
+		Where("center_id = ? AND user_id = ? AND revoked_at IS NULL", centerID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrCalendarFeedNotFound
+	}
+	return nil
+}
+
+func (repo *PGCalendarFeedRepository) GetActive(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	return repo.first(repo.db.WithContext(ctx).Where("center_id = ? AND user_id = ? AND revoked_at IS NULL", centerID, userID))
+}
+
+func (repo *PGCalendarFeedRepository) GetActiveByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error) {
+	return repo.first(repo.db.WithContext(ctx).Where("token_hash = ? AND revoked_at IS NULL", tokenHash))
+}
+
+func (repo *PGCalendarFeedRepository) first(query *gorm.DB) (*domain.CalendarFeed, error) {
+	var dbFeed dbmodels.CalendarFeed
+	result := query.First(&dbFeed)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrCalendarFeedNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbFeed), nil
+}
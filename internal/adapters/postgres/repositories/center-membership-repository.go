@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGMembershipRepository struct {
+	db     *gorm.DB
+	mapper *mappers.CenterMembershipMapper
+	logger ports.Logger
+}
+
+func NewMembershipRepository(db *gorm.DB, logger ports.Logger) ports.MembershipRepository {
+	return &PGMembershipRepository{
+		db:     db,
+		mapper: mappers.NewCenterMembershipMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGMembershipRepository) Create(ctx context.Context, membership *domain.CenterMembership) error {
+	dbMembership := repo.mapper.ToDbModel(membership)
+	result := repo.db.WithContext(ctx).Omit("Center", "User").Create(dbMembership)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return exceptions.ErrMembershipAlreadyExists
+		}
+		return result.Error
+	}
+
+	membership.ID = dbMembership.ID
+	membership.CreatedAt = dbMembership.CreatedAt
+	membership.UpdatedAt = dbMembership.UpdatedAt
+	return nil
+}
+
+// GetByCenterAndUser ignores memberships of soft deleted centers
+func (repo *PGMembershipRepository) GetByCenterAndUser(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CenterMembership, error) {
+	var dbMembership dbmodels.CenterMembership
+	result := repo.db.WithContext(ctx).
+		Joins("JOIN centers ON centers.id = center_memberships.center_id AND centers.deleted_at IS NULL").
+		Where("center_memberships.center_id = ? AND center_memberships.user_id = ?", centerID, userID).
+		First(&dbMembership)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrMembershipNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbMembership), nil
+}
+
+func (repo *PGMembershipRepository) ListMembers(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterMember, error) {
+	dbMemberships := []dbmodels.CenterMembership{}
+	result := repo.db.WithContext(ctx).
+		Preload("User").
+		Where("center_id = ?", centerID).
+		Order("created_at ASC").
+		Find(&dbMemberships)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	members := make([]*domain.CenterMember, len(dbMemberships))
+	for i := range dbMemberships {
+		members[i] = repo.mapper.ToMember(&dbMemberships[i])
+	}
+	return members, nil
+}
+
+func (repo *PGMembershipRepository) UpdateRole(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, role domain.CenterRole) error {
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.CenterMembership{}).
+		Where("center_id = ? AND user_id = ?", centerID, userID).
+		Updates(map[string]interface{}{"role": string(role), "updated_at": time.Now()})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrMembershipNotFound
+	}
+	return nil
+}
+
+func (repo *PGMembershipRepository) Delete(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.CenterMembership{}, "center_id = ? AND user_id = ?", centerID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrMembershipNotFound
+	}
+	return nil
+}
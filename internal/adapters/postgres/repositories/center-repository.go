@@ -2,10 +2,13 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"bifur.app/core/internal/adapters/postgres/dbmodels"
 	"bifur.app/core/internal/adapters/postgres/mappers"
 	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
 	"bifur.app/core/internal/ports"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -27,7 +30,11 @@ func NewPgCenterRepository(db *gorm.DB, logger ports.Logger) ports.CentersReposi
 
 func (repo *PGCenterRepository) GetAll(ctx context.Context, userID uuid.UUID) ([]*domain.Center, error) {
 	dbCenters := []dbmodels.Center{}
-	result := repo.db.WithContext(ctx).Where("owner_id = ?", userID).Find(&dbCenters)
+	result := repo.db.WithContext(ctx).
+		Joins("JOIN center_memberships ON center_memberships.center_id = centers.id").
+		Where("center_memberships.user_id = ?", userID).
+		Order("centers.created_at ASC").
+		Find(&dbCenters)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -38,3 +45,262 @@ func (repo *PGCenterRepository) GetAll(ctx context.Context, userID uuid.UUID) ([
 	}
 	return centers, nil
 }
+
+func (repo *PGCenterRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Center, error) {
+	var dbCenter dbmodels.Center
+	result := repo.db.WithContext(ctx).Where("id = ?", id).First(&dbCenter)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrCenterNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbCenter), nil
+}
+
+func (repo *PGCenterRepository) GetBySlug(ctx context.Context, slug string) (*domain.Center, error) {
+	var dbCenter dbmodels.Center
+	result := repo.db.WithContext(ctx).Where("slug = ? AND slug <> ''", slug).First(&dbCenter)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrCenterNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbCenter), nil
+}
+
+func (repo *PGCenterRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Center, error) {
+	var dbCenter dbmodels.Center
+	result := repo.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&dbCenter)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrCenterNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbCenter), nil
+}
+
+func (repo *PGCenterRepository) Create(ctx context.Context, center *domain.Center) error {
+	return repo.CreateWithSetup(ctx, &domain.CenterSetup{Center: center})
+}
+
+func (repo *PGCenterRepository) CreateWithSetup(ctx context.Context, setup *domain.CenterSetup) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		dbCenter := repo.mapper.ToDbModel(setup.Center)
+		if err := tx.Omit("Owner").Create(dbCenter).Error; err != nil {
+			return err
+		}
+		setup.Center.ID = dbCenter.ID
+		setup.Center.CreatedAt = dbCenter.CreatedAt
+		setup.Center.UpdatedAt = dbCenter.UpdatedAt
+
+		ownerMembership := &dbmodels.CenterMembership{
+			CenterID: dbCenter.ID,
+			UserID:   dbCenter.OwnerID,
+			Role:     string(domain.CenterRoleOwner),
+		}
+		if err := tx.Omit("Center", "User").Create(ownerMembership).Error; err != nil {
+			return err
+		}
+
+		for _, hours := range setup.OpeningHours {
+			hours.CenterID = dbCenter.ID
+			dbHours := repo.mapper.OpeningHoursToDbModel(hours)
+			if err := tx.Omit("Center").Create(dbHours).Error; err != nil {
+				return err
+			}
+			hours.ID = dbHours.ID
+		}
+
+		for _, service := range setup.Services {
+			service.CenterID = dbCenter.ID
+			dbService := repo.mapper.ServiceToDbModel(service)
+			if err := tx.Omit("Center").Create(dbService).Error; err != nil {
+				return err
+			}
+			service.ID = dbService.ID
+			service.CreatedAt = dbService.CreatedAt
+			service.UpdatedAt = dbService.UpdatedAt
+		}
+		return nil
+	})
+}
+
+func (repo *PGCenterRepository) Update(ctx context.Context, center *domain.Center) error {
+	center.UpdatedAt = time.Now()
+	dbCenter := repo.mapper.ToDbModel(center)
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.Center{}).
+		Where("id = ?", center.ID).
+		Updates(map[string]interface{}{
+			"name":                      center.Name,
+			"slug":                      center.Slug,
+			"address_line1":             dbCenter.Address.Line1,
+			"address_line2":             dbCenter.Address.Line2,
+			"address_city":              dbCenter.Address.City,
+			"address_region":            dbCenter.Address.Region,
+			"address_postal_code":       dbCenter.Address.PostalCode,
+			"address_country":           dbCenter.Address.Country,
+			"phone":                     center.Phone,
+			"email":                     center.Email,
+			"timezone":                  center.Timezone,
+			"locale":                    center.Locale,
+			"currency":                  center.Currency,
+			"cancellation_window_hours": center.CancellationWindowHours,
+			"updated_at":                center.UpdatedAt,
+		})
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return exceptions.ErrCenterSlugTaken
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrCenterNotFound
+	}
+	return nil
+}
+
+func (repo *PGCenterRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.Center{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrCenterNotFound
+	}
+	return nil
+}
+
+func (repo *PGCenterRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).
+		Unscoped().
+		Model(&dbmodels.Center{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrCenterNotDeleted
+	}
+	return nil
+}
+
+func (repo *PGCenterRepository) ListOpeningHours(ctx context.Context, centerID uuid.UUID) ([]*domain.OpeningHours, error) {
+	dbHours := []dbmodels.OpeningHours{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ?", centerID).
+		Order("weekday ASC, opens_at ASC").
+		Find(&dbHours)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	hours := make([]*domain.OpeningHours, len(dbHours))
+	for i := range dbHours {
+		hours[i] = repo.mapper.OpeningHoursToDomain(&dbHours[i])
+	}
+	return hours, nil
+}
+
+func (repo *PGCenterRepository) GetService(ctx context.Context, centerID uuid.UUID, serviceID uuid.UUID) (*domain.CenterService, error) {
+	var dbService dbmodels.CenterService
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, serviceID).
+		First(&dbService)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrCenterServiceNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ServiceToDomain(&dbService), nil
+}
+
+func (repo *PGCenterRepository) ListServices(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterService, error) {
+	dbServices := []dbmodels.CenterService{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ?", centerID).
+		Order("created_at ASC").
+		Find(&dbServices)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	services := make([]*domain.CenterService, len(dbServices))
+	for i := range dbServices {
+		services[i] = repo.mapper.ServiceToDomain(&dbServices[i])
+	}
+	return services, nil
+}
+
+func (repo *PGCenterRepository) ListResources(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterResource, error) {
+	dbResources := []dbmodels.CenterResource{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ?", centerID).
+		Order("created_at ASC").
+		Find(&dbResources)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	resources := make([]*domain.CenterResource, len(dbResources))
+	for i := range dbResources {
+		resources[i] = repo.mapper.ResourceToDomain(&dbResources[i])
+	}
+	return resources, nil
+}
+
+func (repo *PGCenterRepository) GetResource(ctx context.Context, centerID uuid.UUID, resourceID uuid.UUID) (*domain.CenterResource, error) {
+	var dbResource dbmodels.CenterResource
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, resourceID).
+		First(&dbResource)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrCenterResourceNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ResourceToDomain(&dbResource), nil
+}
+
+func (repo *PGCenterRepository) CreateResource(ctx context.Context, resource *domain.CenterResource) error {
+	dbResource := repo.mapper.ResourceToDbModel(resource)
+	result := repo.db.WithContext(ctx).Omit("Center").Create(dbResource)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	resource.ID = dbResource.ID
+	resource.CreatedAt = dbResource.CreatedAt
+	resource.UpdatedAt = dbResource.UpdatedAt
+	return nil
+}
+
+func (repo *PGCenterRepository) UpdateResource(ctx context.Context, resource *domain.CenterResource) error {
+	resource.UpdatedAt = time.Now()
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.CenterResource{}).
+		Where("center_id = ? AND id = ?", resource.CenterID, resource.ID).
+		Updates(map[string]interface{}{
+			"name":       resource.Name,
+			"is_active":  resource.IsActive,
+			"updated_at": resource.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrCenterResourceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGExternalCalendarRepository struct {
+	db     *gorm.DB
+	mapper *mappers.ExternalCalendarMapper
+	logger ports.Logger
+}
+
+func NewExternalCalendarRepository(db *gorm.DB, logger ports.Logger) ports.ExternalCalendarRepository {
+	return &PGExternalCalendarRepository{
+		db:     db,
+		mapper: mappers.NewExternalCalendarMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGExternalCalendarRepository) Save(ctx context.Context, calendar *domain.ExternalCalendar) error {
+	var dbCalendar dbmodels.ExternalCalendar
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("center_id = ? AND user_id = ?", calendar.CenterID, calendar.UserID).First(&dbCalendar)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			dbCalendar = *repo.mapper.ToDbModel(calendar)
+			return tx.Omit("Center", "User").Create(&dbCalendar).Error
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		dbCalendar.URL = calendar.URL
+		dbCalendar.LastSyncedAt = nil
+		dbCalendar.UpdatedAt = time.Now()
+		return tx.Model(&dbmodels.ExternalCalendar{}).
+			Where("id = ?", dbCalendar.ID).
+			Updates(map[string]interface{}{
+				"url":            dbCalendar.URL,
+				"last_synced_at": nil,
+				"updated_at":     dbCalendar.UpdatedAt,
+			}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	*calendar = *repo.mapper.ToDomain(&dbCalendar)
+	return nil
+}
+
+func (repo *PGExternalCalendarRepository) Get(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.ExternalCalendar, error) {
+	var dbCalendar dbmodels.ExternalCalendar
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND user_id = ?", centerID, userID).
+		First(&dbCalendar)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrExternalCalendarNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbCalendar), nil
+}
+
+func (repo *PGExternalCalendarRepository) MarkSynced(ctx context.Context, id uuid.UUID, syncedAt time.Time) error {
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.ExternalCalendar{}).
+		Where("id = ?", id).
+		Update("last_synced_at", syncedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrExternalCalendarNotFound
+	}
+	return nil
+}
+
+func (repo *PGExternalCalendarRepository) Delete(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, from time.Time) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&dbmodels.ExternalCalendar{}, "center_id = ? AND user_id = ?", centerID, userID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return exceptions.ErrExternalCalendarNotFound
+		}
+		return tx.Delete(&dbmodels.TimeBlock{}, "center_id = ? AND user_id = ? AND source = ? AND ends_at > ?", centerID, userID, string(domain.TimeBlockSourceICSURL), from).Error
+	})
+}
@@ -0,0 +1,214 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// minPhoneQueryDigits is the number of digits from which a search also looks
+// into the phones
+const minPhoneQueryDigits = 3
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+type PGLeadRepository struct {
+	db     *gorm.DB
+	mapper *mappers.LeadMapper
+	logger ports.Logger
+}
+
+func NewLeadRepository(db *gorm.DB, logger ports.Logger) ports.LeadRepository {
+	return &PGLeadRepository{
+		db:     db,
+		mapper: mappers.NewLeadMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGLeadRepository) Create(ctx context.Context, lead *domain.Lead) error {
+	dbLead := repo.mapper.ToDbModel(lead)
+	dbConsents := make([]*dbmodels.LeadConsent, len(lead.Consents))
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Center", "Consents").Create(dbLead).Error; err != nil {
+			return err
+		}
+		for i, consent := range lead.Consents {
+			consent.LeadID = dbLead.ID
+			dbConsents[i] = repo.mapper.ConsentToDbModel(consent)
+			if err := tx.Omit("Recorder").Create(dbConsents[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return exceptions.ErrLeadDuplicate
+		}
+		return err
+	}
+
+	lead.ID = dbLead.ID
+	lead.CreatedAt = dbLead.CreatedAt
+	lead.UpdatedAt = dbLead.UpdatedAt
+	for i, consent := range lead.Consents {
+		consent.ID = dbConsents[i].ID
+		consent.CreatedAt = dbConsents[i].CreatedAt
+	}
+	return nil
+}
+
+func (repo *PGLeadRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Lead, error) {
+	var dbLead dbmodels.Lead
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, id).
+		First(&dbLead)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrLeadNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbLead), nil
+}
+
+func (repo *PGLeadRepository) FindByContact(ctx context.Context, centerID uuid.UUID, email string, phone string) ([]*domain.Lead, error) {
+	if email == "" && phone == "" {
+		return []*domain.Lead{}, nil
+	}
+	contact := repo.db.WithContext(ctx)
+	if email != "" {
+		contact = contact.Or("email = ?", email)
+	}
+	if phone != "" {
+		contact = contact.Or("phone = ?", phone)
+	}
+
+	dbLeads := []dbmodels.Lead{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ?", centerID).
+		Where(contact).
+		Order("created_at ASC").
+		Find(&dbLeads)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return repo.toDomainList(dbLeads), nil
+}
+
+// List matches the query anywhere in the name and the email, and in the phone
+// by its digits, whatever the way it is written
+func (repo *PGLeadRepository) List(ctx context.Context, centerID uuid.UUID, filter *domain.LeadFilter) ([]*domain.Lead, error) {
+	query := repo.db.WithContext(ctx).Where("center_id = ?", centerID)
+	if text := strings.TrimSpace(filter.Query); text != "" {
+		pattern := "%" + likeEscaper.Replace(text) + "%"
+		match := repo.db.WithContext(ctx).Where("name ILIKE ?", pattern).Or("email ILIKE ?", pattern)
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, text)
+		if len(digits) >= minPhoneQueryDigits {
+			match = match.Or("phone LIKE ?", "%"+digits+"%")
+		}
+		query = query.Where(match)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	dbLeads := []dbmodels.Lead{}
+	result := query.Order("name ASC, id ASC").Find(&dbLeads)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return repo.toDomainList(dbLeads), nil
+}
+
+func (repo *PGLeadRepository) Update(ctx context.Context, lead *domain.Lead) error {
+	lead.UpdatedAt = time.Now()
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.Lead{}).
+		Where("center_id = ? AND id = ?", lead.CenterID, lead.ID).
+		Updates(map[string]interface{}{
+			"name":       lead.Name,
+			"email":      lead.Email,
+			"phone":      lead.Phone,
+			"notes":      lead.Notes,
+			"updated_at": lead.UpdatedAt,
+		})
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return exceptions.ErrLeadDuplicate
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrLeadNotFound
+	}
+	return nil
+}
+
+// Delete relies on the foreign key of the consents to erase them too
+func (repo *PGLeadRepository) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.Lead{}, "center_id = ? AND id = ?", centerID, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrLeadNotFound
+	}
+	return nil
+}
+
+func (repo *PGLeadRepository) CreateConsent(ctx context.Context, consent *domain.LeadConsent) error {
+	dbConsent := repo.mapper.ConsentToDbModel(consent)
+	result := repo.db.WithContext(ctx).Omit("Recorder").Create(dbConsent)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	consent.ID = dbConsent.ID
+	consent.CreatedAt = dbConsent.CreatedAt
+	return nil
+}
+
+func (repo *PGLeadRepository) ListConsents(ctx context.Context, leadID uuid.UUID) ([]*domain.LeadConsent, error) {
+	dbConsents := []dbmodels.LeadConsent{}
+	result := repo.db.WithContext(ctx).
+		Where("lead_id = ?", leadID).
+		Order("created_at DESC").
+		Find(&dbConsents)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	consents := make([]*domain.LeadConsent, len(dbConsents))
+	for i := range dbConsents {
+		consents[i] = repo.mapper.ConsentToDomain(&dbConsents[i])
+	}
+	return consents, nil
+}
+
+func (repo *PGLeadRepository) toDomainList(dbLeads []dbmodels.Lead) []*domain.Lead {
+	leads := make([]*domain.Lead, len(dbLeads))
+	for i := range dbLeads {
+		leads[i] = repo.mapper.ToDomain(&dbLeads[i])
+	}
+	return leads
+}
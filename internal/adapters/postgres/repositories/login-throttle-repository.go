@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/ports"
+	"gorm.io/gorm"
+)
+
+type PGLoginThrottleRepository struct {
+	db     *gorm.DB
+	mapper *mappers.LoginThrottleMapper
+	logger ports.Logger
+}
+
+func NewLoginThrottleRepository(db *gorm.DB, logger ports.Logger) ports.LoginThrottleRepository {
+	return &PGLoginThrottleRepository{
+		db:     db,
+		mapper: mappers.NewLoginThrottleMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGLoginThrottleRepository) Get(ctx context.Context, key string) (*domain.LoginThrottle, error) {
+	var dbThrottle dbmodels.LoginThrottle
+	result := repo.db.WithContext(ctx).Where("key = ?", key).First(&dbThrottle)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbThrottle), nil
+}
+
+// RecordFailure upserts the counter in a single statement so concurrent
+// attempts cannot overwrite each other
+func (repo *PGLoginThrottleRepository) RecordFailure(ctx context.Context, key string, at time.Time, windowStart time.Time) (*domain.LoginThrottle, error) {
+	var dbThrottle dbmodels.LoginThrottle
+	result := repo.db.WithContext(ctx).Raw(`
+		INSERT INTO login_throttles (key, failed_count, window_started_at, last_failed_at)
+		VALUES (@key, 1, @at, @at)
+		ON CONFLICT (key) DO UPDATE SET
+			failed_count = CASE WHEN login_throttles.window_started_at < @window_start THEN 1 ELSE login_throttles.failed_count + 1 END,
+			window_started_at = CASE WHEN login_throttles.window_started_at < @window_start THEN @at ELSE login_throttles.window_started_at END,
+			last_failed_at = @at
+		RETURNING *`,
+		map[string]interface{}{"key": key, "at": at, "window_start": windowStart},
+	).Scan(&dbThrottle)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbThrottle), nil
+}
+
+func (repo *PGLoginThrottleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.LoginThrottle{}).
+		Where("key = ?", key).
+		Update("locked_until", until)
+	return result.Error
+}
+
+func (repo *PGLoginThrottleRepository) Reset(ctx context.Context, key string) error {
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.LoginThrottle{}, "key = ?", key)
+	return result.Error
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGRecoveryCodeRepository struct {
+	db     *gorm.DB
+	mapper *mappers.RecoveryCodeMapper
+	logger ports.Logger
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB, logger ports.Logger) ports.RecoveryCodeRepository {
+	return &PGRecoveryCodeRepository{
+		db:     db,
+		mapper: mappers.NewRecoveryCodeMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGRecoveryCodeRepository) ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []*domain.RecoveryCode) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&dbmodels.RecoveryCode{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		dbCodes := make([]*dbmodels.RecoveryCode, len(codes))
+		for i, code := range codes {
+			dbCodes[i] = repo.mapper.ToDbModel(code)
+		}
+		if len(dbCodes) == 0 {
+			return nil
+		}
+		return tx.Create(dbCodes).Error
+	})
+}
+
+func (repo *PGRecoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrMFAInvalidCode
+	}
+	return nil
+}
+
+func (repo *PGRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.RecoveryCode{}, "user_id = ?", userID)
+	return result.Error
+}
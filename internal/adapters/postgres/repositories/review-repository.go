@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGReviewRepository struct {
+	db     *gorm.DB
+	mapper *mappers.ReviewMapper
+	logger ports.Logger
+}
+
+func NewReviewRepository(db *gorm.DB, logger ports.Logger) ports.ReviewRepository {
+	return &PGReviewRepository{
+		db:     db,
+		mapper: mappers.NewReviewMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGReviewRepository) Create(ctx context.Context, review *domain.AppointmentReview) error {
+	dbReview := repo.mapper.ToDbModel(review)
+	result := repo.db.WithContext(ctx).Omit("Center", "Appointment", "Lead").Create(dbReview)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return exceptions.ErrReviewAlreadySubmitted
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+
+	review.ID = dbReview.ID
+	review.CreatedAt = dbReview.CreatedAt
+	review.UpdatedAt = dbReview.UpdatedAt
+	return nil
+}
+
+func (repo *PGReviewRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AppointmentReview, error) {
+	return repo.get(ctx, "center_id = ? AND id = ?", centerID, id)
+}
+
+func (repo *PGReviewRepository) GetByAppointment(ctx context.Context, centerID uuid.UUID, appointmentID uuid.UUID) (*domain.AppointmentReview, error) {
+	return repo.get(ctx, "center_id = ? AND appointment_id = ?", centerID, appointmentID)
+}
+
+func (repo *PGReviewRepository) List(ctx context.Context, centerID uuid.UUID, filter *domain.ReviewFilter) ([]*domain.AppointmentReview, error) {
+	query := repo.db.WithContext(ctx).Where("center_id = ?", centerID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.StaffID != nil {
+		query = query.Where("staff_id = ?", *filter.StaffID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	dbReviews := []dbmodels.AppointmentReview{}
+	result := query.Order("created_at DESC, id ASC").Find(&dbReviews)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	reviews := make([]*domain.AppointmentReview, len(dbReviews))
+	for i := range dbReviews {
+		reviews[i] = repo.mapper.ToDomain(&dbReviews[i])
+	}
+	return reviews, nil
+}
+
+func (repo *PGReviewRepository) Moderate(ctx context.Context, review *domain.AppointmentReview) error {
+	review.UpdatedAt = time.Now()
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.AppointmentReview{}).
+		Where("center_id = ? AND id = ?", review.CenterID, review.ID).
+		Updates(map[string]interface{}{
+			"status":          string(review.Status),
+			"moderation_note": review.ModerationNote,
+			"moderated_by":    review.ModeratedBy,
+			"moderated_at":    review.ModeratedAt,
+			"updated_at":      review.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrReviewNotFound
+	}
+	return nil
+}
+
+func (repo *PGReviewRepository) CountRatings(ctx context.Context, centerID uuid.UUID) ([]*domain.RatingCount, error) {
+	counts := []*domain.RatingCount{}
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.AppointmentReview{}).
+		Select("staff_id, rating, COUNT(*) AS count").
+		Where("center_id = ? AND status = ?", centerID, string(domain.ReviewStatusPublished)).
+		Group("staff_id, rating").
+		Order("staff_id, rating").
+		Scan(&counts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return counts, nil
+}
+
+func (repo *PGReviewRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.AppointmentReview, error) {
+	var dbReview dbmodels.AppointmentReview
+	result := repo.db.WithContext(ctx).Where(query, args...).First(&dbReview)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrReviewNotFound
+		}
+		return nil, result.Error
+	}
+	return repo.mapper.ToDomain(&dbReview), nil
+}
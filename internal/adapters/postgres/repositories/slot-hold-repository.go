@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type PGSlotHoldRepository struct {
+	db     *gorm.DB
+	mapper *mappers.SlotHoldMapper
+	logger ports.Logger
+}
+
+func NewSlotHoldRepository(db *gorm.DB, logger ports.Logger) ports.SlotHoldRepository {
+	return &PGSlotHoldRepository{
+		db:     db,
+		mapper: mappers.NewSlotHoldMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGSlotHoldRepository) Create(ctx context.Context, hold *domain.SlotHold) error {
+	dbHold := repo.mapper.ToDbModel(hold)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("staff_id = ? AND expires_at <= ?", hold.StaffID, time.Now()).Delete(&dbmodels.SlotHold{})
+		if result.Error != nil {
+			return result.Error
+		}
+		return tx.Omit("Center", "Staff", "Service").Create(dbHold).Error
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == exclusionViolation && pgErr.ConstraintName == dbmodels.SlotHoldStaffOverlapConstraint {
+		return exceptions.ErrSlotUnavailable
+	}
+	if err != nil {
+		return err
+	}
+
+	hold.ID = dbHold.ID
+	hold.CreatedAt = dbHold.CreatedAt
+	return nil
+}
+
+func (repo *PGSlotHoldRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.SlotHold, error) {
+	var dbHold dbmodels.SlotHold
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, id).
+		First(&dbHold)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrSlotHoldNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbHold), nil
+}
+
+func (repo *PGSlotHoldRepository) ListActiveInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time, now time.Time) ([]*domain.SlotHold, error) {
+	dbHolds := []dbmodels.SlotHold{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND expires_at > ? AND ends_at > ? AND starts_at < ?", centerID, now, from, to).
+		Order("starts_at ASC").
+		Find(&dbHolds)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	holds := make([]*domain.SlotHold, len(dbHolds))
+	for i := range dbHolds {
+		holds[i] = repo.mapper.ToDomain(&dbHolds[i])
+	}
+	return holds, nil
+}
+
+func (repo *PGSlotHoldRepository) CountActiveByIP(ctx context.Context, centerID uuid.UUID, ipAddress string, now time.Time) (int64, error) {
+	var count int64
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.SlotHold{}).
+		Where("center_id = ? AND ip_address = ? AND expires_at > ?", centerID, ipAddress, now).
+		Count(&count)
+	return count, result.Error
+}
+
+func (repo *PGSlotHoldRepository) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Where("center_id = ? AND id = ?", centerID, id).Delete(&dbmodels.SlotHold{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrSlotHoldNotFound
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"bifur.app/core/internal/domain"
 	"bifur.app/core/internal/exceptions"
 	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -51,6 +52,63 @@ func (repo *PGSourceRepository) GetByID(ctx context.Context, id string) (*domain
 	return repo.mapper.DBModelToDomain(&dbSource), nil
 }
 
+func (repo *PGSourceRepository) GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Source, error) {
+	var dbSource dbmodels.Source
+	result := repo.db.WithContext(ctx).Where("refresh_token_hash = ?", refreshTokenHash).First(&dbSource)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrAuthSourceNotFound
+		}
+		return nil, result.Error
+	}
+	return repo.mapper.DBModelToDomain(&dbSource), nil
+}
+
+func (repo *PGSourceRepository) GetByRotatedRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Source, error) {
+	var dbRotated dbmodels.RotatedToken
+	result := repo.db.WithContext(ctx).Preload("Source").Where("refresh_token_hash = ?", refreshTokenHash).First(&dbRotated)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrAuthSourceNotFound
+		}
+		return nil, result.Error
+	}
+	if dbRotated.Source.ID == uuid.Nil {
+		return nil, exceptions.ErrAuthSourceNotFound
+	}
+	return repo.mapper.DBModelToDomain(&dbRotated.Source), nil
+}
+
+// Rotate swaps the refresh token hash of an active source and archives the
+// previous one. The update is conditioned on the previous hash so that two
+// concurrent refreshes with the same token cannot both succeed.
+func (repo *PGSourceRepository) Rotate(ctx context.Context, source *domain.Source, previousRefreshTokenHash string) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&dbmodels.Source{}).
+			Where("id = ? AND refresh_token_hash = ? AND is_active = ?", source.ID, previousRefreshTokenHash, true).
+			Updates(map[string]interface{}{
+				"refresh_token_hash":       source.RefreshTokenHash,
+				"refresh_token_expires_at": source.RefreshTokenExpiresAt,
+				"user_agent":               source.UserAgent,
+				"ip_address":               source.IPAddress,
+				"city":                     source.City,
+				"country_code":             source.CountryCode,
+				"updated_at":               source.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return exceptions.ErrAuthRefreshTokenReused
+		}
+
+		return tx.Create(&dbmodels.RotatedToken{
+			SourceID:         source.ID,
+			RefreshTokenHash: previousRefreshTokenHash,
+		}).Error
+	})
+}
+
 func (repo *PGSourceRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Source, error) {
 	var dbSources []dbmodels.Source
 	result := repo.db.WithContext(ctx).Where("user_id = ? AND is_active = ?", userID, true).Find(&dbSources)
@@ -83,6 +141,6 @@ func (repo *PGSourceRepository) DeleteByUserID(ctx context.Context, userID strin
 }
 
 func (repo *PGSourceRepository) DeleteExpired(ctx context.Context) error {
-	result := repo.db.WithContext(ctx).Delete(&dbmodels.Source{}, "expires_at < ?", time.Now())
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.Source{}, "refresh_token_expires_at < ?", time.Now())
 	return result.Error
 }
@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGTimeBlockRepository struct {
+	db     *gorm.DB
+	mapper *mappers.TimeBlockMapper
+	logger ports.Logger
+}
+
+func NewTimeBlockRepository(db *gorm.DB, logger ports.Logger) ports.TimeBlockRepository {
+	return &PGTimeBlockRepository{
+		db:     db,
+		mapper: mappers.NewTimeBlockMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGTimeBlockRepository) Create(ctx context.Context, block *domain.TimeBlock) error {
+	dbBlock := repo.mapper.ToDbModel(block)
+	result := repo.db.WithContext(ctx).Omit("Center", "User").Create(dbBlock)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	block.ID = dbBlock.ID
+	block.CreatedAt = dbBlock.CreatedAt
+	block.UpdatedAt = dbBlock.UpdatedAt
+	return nil
+}
+
+func (repo *PGTimeBlockRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.TimeBlock, error) {
+	var dbBlock dbmodels.TimeBlock
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, id).
+		First(&dbBlock)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrTimeBlockNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbBlock), nil
+}
+
+func (repo *PGTimeBlockRepository) List(ctx context.Context, centerID uuid.UUID, userID *uuid.UUID, from *time.Time, to *time.Time) ([]*domain.TimeBlock, error) {
+	query := repo.db.WithContext(ctx).Where("center_id = ?", centerID)
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	} else {
+		query = query.Where("user_id IS NULL")
+	}
+	if from != nil {
+		query = query.Where("ends_at > ?", *from)
+	}
+	if to != nil {
+		query = query.Where("starts_at < ?", *to)
+	}
+
+	dbBlocks := []dbmodels.TimeBlock{}
+	result := query.Order("starts_at ASC").Find(&dbBlocks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	blocks := make([]*domain.TimeBlock, len(dbBlocks))
+	for i := range dbBlocks {
+		blocks[i] = repo.mapper.ToDomain(&dbBlocks[i])
+	}
+	return blocks, nil
+}
+
+func (repo *PGTimeBlockRepository) ListInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.TimeBlock, error) {
+	dbBlocks := []dbmodels.TimeBlock{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND ends_at > ? AND starts_at < ?", centerID, from, to).
+		Order("starts_at ASC").
+		Find(&dbBlocks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	blocks := make([]*domain.TimeBlock, len(dbBlocks))
+	for i := range dbBlocks {
+		blocks[i] = repo.mapper.ToDomain(&dbBlocks[i])
+	}
+	return blocks, nil
+}
+
+func (repo *PGTimeBlockRepository) Update(ctx context.Context, block *domain.TimeBlock) error {
+	block.UpdatedAt = time.Now()
+	result := repo.db.WithContext(ctx).
+		Model(&dbmodels.TimeBlock{}).
+		Where("center_id = ? AND id = ?", block.CenterID, block.ID).
+		Updates(map[string]interface{}{
+			"starts_at":  block.StartsAt,
+			"ends_at":    block.EndsAt,
+			"all_day":    block.AllDay,
+			"reason":     string(block.Reason),
+			"note":       block.Note,
+			"updated_at": block.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrTimeBlockNotFound
+	}
+	return nil
+}
+
+func (repo *PGTimeBlockRepository) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Delete(&dbmodels.TimeBlock{}, "center_id = ? AND id = ?", centerID, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrTimeBlockNotFound
+	}
+	return nil
+}
+
+func (repo *PGTimeBlockRepository) SyncImported(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, source domain.TimeBlockSource, from time.Time, uids []string, blocks []*domain.TimeBlock) (*domain.BusyImportResult, error) {
+	keys := make([]string, len(blocks))
+	for i, block := range blocks {
+		keys[i] = block.ExternalKey
+	}
+
+	result := &domain.BusyImportResult{}
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The blocks in the past are kept, unless an event moved from the past
+		// to the future brings its block along
+		replaced := tx.Where("ends_at > ?", from)
+		if uids != nil {
+			replaced = replaced.Where("external_uid IN ?", uids)
+		}
+		if len(keys) > 0 {
+			replaced = replaced.Or("external_key IN ?", keys)
+		}
+		existing := []dbmodels.TimeBlock{}
+		err := tx.Where("center_id = ? AND user_id = ? AND source = ?", centerID, userID, string(source)).
+			Where(replaced).
+			Find(&existing).Error
+		if err != nil {
+			return err
+		}
+		byKey := make(map[string]*dbmodels.TimeBlock, len(existing))
+		for i := range existing {
+			byKey[existing[i].ExternalKey] = &existing[i]
+		}
+
+		now := time.Now()
+		for _, block := range blocks {
+			current, found := byKey[block.ExternalKey]
+			if !found {
+				dbBlock := repo.mapper.ToDbModel(block)
+				if err := tx.Omit("Center", "User").Create(dbBlock).Error; err != nil {
+					return err
+				}
+				block.ID = dbBlock.ID
+				block.CreatedAt = dbBlock.CreatedAt
+				block.UpdatedAt = dbBlock.UpdatedAt
+				result.Created++
+				continue
+			}
+
+			delete(byKey, block.ExternalKey)
+			block.ID = current.ID
+			block.CreatedAt = current.CreatedAt
+			block.UpdatedAt = current.UpdatedAt
+			if current.StartsAt.Equal(block.StartsAt) && current.EndsAt.Equal(block.EndsAt) && current.AllDay == block.AllDay {
+				continue
+			}
+			block.UpdatedAt = now
+			err := tx.Model(&dbmodels.TimeBlock{}).
+				Where("id = ?", current.ID).
+				Updates(map[string]interface{}{
+					"starts_at":  block.StartsAt,
+					"ends_at":    block.EndsAt,
+					"all_day":    block.AllDay,
+					"updated_at": block.UpdatedAt,
+				}).Error
+			if err != nil {
+				return err
+			}
+			result.Updated++
+		}
+
+		if len(byKey) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, 0, len(byKey))
+		for _, block := range byKey {
+			ids = append(ids, block.ID)
+		}
+		if err := tx.Delete(&dbmodels.TimeBlock{}, "id IN ?", ids).Error; err != nil {
+			return err
+		}
+		result.Deleted = len(ids)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
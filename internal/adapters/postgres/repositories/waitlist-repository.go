@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/adapters/postgres/dbmodels"
+	"bifur.app/core/internal/adapters/postgres/mappers"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PGWaitlistRepository struct {
+	db     *gorm.DB
+	mapper *mappers.WaitlistMapper
+	logger ports.Logger
+}
+
+func NewWaitlistRepository(db *gorm.DB, logger ports.Logger) ports.WaitlistRepository {
+	return &PGWaitlistRepository{
+		db:     db,
+		mapper: mappers.NewWaitlistMapper(),
+		logger: logger,
+	}
+}
+
+func (repo *PGWaitlistRepository) CreateEntry(ctx context.Context, entry *domain.WaitlistEntry) error {
+	dbEntry := repo.mapper.ToDbModel(entry)
+	result := repo.db.WithContext(ctx).Omit("Center", "Lead", "Service", "Staff").Create(dbEntry)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	entry.ID = dbEntry.ID
+	entry.CreatedAt = dbEntry.CreatedAt
+	entry.UpdatedAt = dbEntry.UpdatedAt
+	return nil
+}
+
+func (repo *PGWaitlistRepository) GetEntry(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.WaitlistEntry, error) {
+	var dbEntry dbmodels.WaitlistEntry
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, id).
+		First(&dbEntry)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrWaitlistEntryNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.ToDomain(&dbEntry), nil
+}
+
+func (repo *PGWaitlistRepository) ListEntries(ctx context.Context, centerID uuid.UUID, filter *domain.WaitlistFilter) ([]*domain.WaitlistEntry, error) {
+	query := repo.db.WithContext(ctx).Where("center_id = ?", centerID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.ServiceID != nil {
+		query = query.Where("service_id = ?", *filter.ServiceID)
+	}
+
+	dbEntries := []dbmodels.WaitlistEntry{}
+	result := query.Order("created_at ASC").Find(&dbEntries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return repo.toDomainList(dbEntries), nil
+}
+
+func (repo *PGWaitlistRepository) ListWaiting(ctx context.Context, centerID uuid.UUID, date domain.Date) ([]*domain.WaitlistEntry, error) {
+	dbEntries := []dbmodels.WaitlistEntry{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND status = ? AND from_date <= ? AND to_date >= ?", centerID, string(domain.WaitlistStatusWaiting), date.In(time.UTC), date.In(time.UTC)).
+		Order("created_at ASC").
+		Find(&dbEntries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return repo.toDomainList(dbEntries), nil
+}
+
+func (repo *PGWaitlistRepository) DeleteEntry(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	result := repo.db.WithContext(ctx).Where("center_id = ? AND id = ?", centerID, id).Delete(&dbmodels.WaitlistEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exceptions.ErrWaitlistEntryNotFound
+	}
+	return nil
+}
+
+func (repo *PGWaitlistRepository) CreateOffer(ctx context.Context, offer *domain.WaitlistOffer) error {
+	dbOffer := repo.mapper.OfferToDbModel(offer)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&dbmodels.WaitlistEntry{}).
+			Where("id = ? AND status = ?", offer.EntryID, string(domain.WaitlistStatusWaiting)).
+			Updates(map[string]interface{}{
+				"status":     string(domain.WaitlistStatusOffered),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return exceptions.ErrWaitlistEntryNotFound
+		}
+		return tx.Omit("Entry").Create(dbOffer).Error
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return exceptions.ErrSlotUnavailable
+	}
+	if err != nil {
+		return err
+	}
+
+	offer.ID = dbOffer.ID
+	offer.CreatedAt = dbOffer.CreatedAt
+	return nil
+}
+
+func (repo *PGWaitlistRepository) GetOffer(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.WaitlistOffer, error) {
+	var dbOffer dbmodels.WaitlistOffer
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND id = ?", centerID, id).
+		First(&dbOffer)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, exceptions.ErrWaitlistOfferNotFound
+		}
+		return nil, result.Error
+	}
+
+	return repo.mapper.OfferToDomain(&dbOffer), nil
+}
+
+func (repo *PGWaitlistRepository) ListSlotOffers(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, startsAt time.Time) ([]*domain.WaitlistOffer, error) {
+	dbOffers := []dbmodels.WaitlistOffer{}
+	result := repo.db.WithContext(ctx).
+		Where("center_id = ? AND staff_id = ? AND starts_at = ?", centerID, staffID, startsAt).
+		Order("created_at ASC").
+		Find(&dbOffers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return repo.toDomainOffers(dbOffers), nil
+}
+
+func (repo *PGWaitlistRepository) ListExpiredOffers(ctx context.Context, now time.Time, limit int) ([]*domain.WaitlistOffer, error) {
+	dbOffers := []dbmodels.WaitlistOffer{}
+	result := repo.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", string(domain.WaitlistOfferStatusPending), now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&dbOffers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return repo.toDomainOffers(dbOffers), nil
+}
+
+func (repo *PGWaitlistRepository) CloseOffer(ctx context.Context, offer *domain.WaitlistOffer, entryStatus domain.WaitlistStatus) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&dbmodels.WaitlistOffer{}).
+			Where("center_id = ? AND id = ? AND status = ?", offer.CenterID, offer.ID, string(domain.WaitlistOfferStatusPending)).
+			Updates(map[string]interface{}{
+				"status":         string(offer.Status),
+				"appointment_id": offer.AppointmentID,
+				"responded_at":   offer.RespondedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return exceptions.ErrWaitlistOfferClosed
+		}
+		return tx.Model(&dbmodels.WaitlistEntry{}).
+			Where("id = ?", offer.EntryID).
+			Updates(map[string]interface{}{
+				"status":     string(entryStatus),
+				"updated_at": time.Now(),
+			}).Error
+	})
+}
+
+func (repo *PGWaitlistRepository) toDomainList(dbEntries []dbmodels.WaitlistEntry) []*domain.WaitlistEntry {
+	entries := make([]*domain.WaitlistEntry, len(dbEntries))
+	for i := range dbEntries {
+		entries[i] = repo.mapper.ToDomain(&dbEntries[i])
+	}
+	return entries
+}
+
+func (repo *PGWaitlistRepository) toDomainOffers(dbOffers []dbmodels.WaitlistOffer) []*domain.WaitlistOffer {
+	offers := make([]*domain.WaitlistOffer, len(dbOffers))
+	for i := range dbOffers {
+		offers[i] = repo.mapper.OfferToDomain(&dbOffers[i])
+	}
+	return offers
+}
@@ -0,0 +1,114 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/utils/mail"
+)
+
+type SMTPMailerConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	// Timeout bounds a whole delivery, from the dial to the QUIT
+	Timeout time.Duration
+}
+
+type SMTPMailer struct {
+	cfg SMTPMailerConfig
+	// sender is the bare address of From, used as the envelope sender
+	sender string
+}
+
+// NewSMTPMailer fails when From is not a valid address, it may carry a
+// display name, e.g. "Scheduly <no-reply@example.com>"
+func NewSMTPMailer(cfg SMTPMailerConfig) (*SMTPMailer, error) {
+	from, err := netmail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+	return &SMTPMailer{cfg: cfg, sender: from.Address}, nil
+}
+
+var _ ports.Mailer = (*SMTPMailer)(nil)
+
+func (m *SMTPMailer) Send(ctx context.Context, message *domain.EmailMessage) error {
+	if m.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.cfg.Timeout)
+		defer cancel()
+	}
+
+	err := m.send(ctx, message)
+	if err != nil {
+		return fmt.Errorf("%w: %v", exceptions.ErrMailSendFailed, err)
+	}
+	return nil
+}
+
+// send delivers the message like smtp.SendMail, over a connection that
+// can't outlive ctx
+func (m *SMTPMailer) send(ctx context.Context, message *domain.EmailMessage) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.cfg.Host, m.cfg.Port))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+	// Closing the connection unblocks any pending command once ctx is done
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.sender); err != nil {
+		return err
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	// The header keeps the display name of the sender
+	if _, err := writer.Write(mail.Compose(m.cfg.From, message)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
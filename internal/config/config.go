@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"bifur.app/core/internal/domain"
@@ -14,6 +15,11 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	JWT      domain.JWTConfig
+	GeoIP    GeoIPConfig
+	Mail     MailConfig
+	Account  domain.AccountConfig
+	Calendar domain.CalendarConfig
+	Booking  domain.BookingConfig
 }
 
 // ServerConfig holds the server configuration
@@ -35,6 +41,25 @@ type DatabaseConfig struct {
 	LogEnabled bool
 }
 
+// GeoIPConfig holds the offline GeoIP configuration, an empty path disables
+// location lookups
+type GeoIPConfig struct {
+	DatabasePath string
+}
+
+// MailConfig holds the outgoing email configuration. The "outbox" driver
+// writes emails to OutboxDir instead of sending them
+type MailConfig struct {
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+	SMTPTimeout  time.Duration
+	OutboxDir    string
+}
+
 func getEnvVariable(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -51,7 +76,7 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
-/* func getEnvAsInt(key string, defaultValue int) int {
+func getEnvAsInt(key string, defaultValue int) int {
 	if value, exists := os.LookupEnv(key); exists {
 		if intVal, err := strconv.Atoi(value); err == nil {
 			return intVal
@@ -59,7 +84,7 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 		log.Printf("Invalid integer value for %s, defaulting to %d", key, defaultValue)
 	}
 	return defaultValue
-} */
+}
 
 func getJWTDuration(specificKey string, defaultValue time.Duration) time.Duration {
 	if value, exists := os.LookupEnv(specificKey); exists {
@@ -83,6 +108,16 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getUnverifiedEmailPolicy(key string, defaultValue domain.UnverifiedEmailPolicy) domain.UnverifiedEmailPolicy {
+	value := domain.UnverifiedEmailPolicy(getEnvVariable(key, string(defaultValue)))
+	switch value {
+	case domain.UnverifiedEmailPolicyAllow, domain.UnverifiedEmailPolicyRestrict, domain.UnverifiedEmailPolicyBlock:
+		return value
+	}
+	log.Printf("Invalid unverified email policy for %s, defaulting to %s", key, defaultValue)
+	return defaultValue
+}
+
 func New() *Config {
 	config := &Config{
 		Server: ServerConfig{
@@ -101,9 +136,56 @@ func New() *Config {
 			LogEnabled: getEnvAsBool("DB_LOG_ENABLED", false),
 		},
 		JWT: domain.JWTConfig{
-			AtkSecret: getEnvVariable("JWT_ATK_SECRET_KEY", "your_access_token_secret_key_here"),
-			RtkSecret: getEnvVariable("JWT_RTK_SECRET_KEY", "your_refresh_token_secret_key_here"),
-			Expiry:    getJWTDuration("JWT_ACCESS_TOKEN_DURATION", 15*time.Minute),
+			AtkSecret:     getEnvVariable("JWT_ATK_SECRET_KEY", "your_access_token_secret_key_here"),
+			RtkSecret:     getEnvVariable("JWT_RTK_SECRET_KEY", "your_refresh_token_secret_key_here"),
+			Expiry:        getJWTDuration("JWT_ACCESS_TOKEN_DURATION", 15*time.Minute),
+			RefreshExpiry: getJWTDuration("JWT_REFRESH_TOKEN_DURATION", 30*24*time.Hour),
+			KeysFile:      getEnvVariable("JWT_KEYS_FILE", ""),
+		},
+		GeoIP: GeoIPConfig{
+			DatabasePath: getEnvVariable("GEOIP_DATABASE_PATH", ""),
+		},
+		Mail: MailConfig{
+			Driver:       getEnvVariable("MAIL_DRIVER", "outbox"),
+			From:         getEnvVariable("MAIL_FROM", "Scheduly <no-reply@localhost>"),
+			SMTPHost:     getEnvVariable("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnvVariable("SMTP_PORT", "587"),
+			SMTPUser:     getEnvVariable("SMTP_USER", ""),
+			SMTPPassword: getEnvVariable("SMTP_PASSWORD", ""),
+			SMTPTimeout:  getDurationEnv("SMTP_TIMEOUT", 30*time.Second),
+			OutboxDir:    getEnvVariable("MAIL_OUTBOX_DIR", "tmp/outbox"),
+		},
+		Account: domain.AccountConfig{
+			AppURL:                  getEnvVariable("APP_URL", "http://localhost:3000"),
+			PasswordResetExpiry:     getDurationEnv("PASSWORD_RESET_TOKEN_DURATION", time.Hour),
+			EmailVerificationExpiry: getDurationEnv("EMAIL_VERIFICATION_TOKEN_DURATION", 48*time.Hour),
+			UnverifiedEmailPolicy:   getUnverifiedEmailPolicy("UNVERIFIED_EMAIL_POLICY", domain.UnverifiedEmailPolicyAllow),
+			MFAIssuer:               getEnvVariable("MFA_ISSUER", "Scheduly"),
+			MFAChallengeExpiry:      getDurationEnv("MFA_CHALLENGE_DURATION", 5*time.Minute),
+			LoginThrottle: domain.LoginThrottleConfig{
+				MaxFailedAttempts:      getEnvAsInt("LOGIN_MAX_FAILED_ATTEMPTS", 5),
+				MaxFailedAttemptsPerIP: getEnvAsInt("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", 50),
+				Window:                 getDurationEnv("LOGIN_FAILED_ATTEMPTS_WINDOW", 15*time.Minute),
+				LockoutDuration:        getDurationEnv("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+				DelayAfter:             getEnvAsInt("LOGIN_DELAY_AFTER_ATTEMPTS", 3),
+				BaseDelay:              getDurationEnv("LOGIN_BASE_DELAY", time.Second),
+				MaxDelay:               getDurationEnv("LOGIN_MAX_DELAY", 30*time.Second),
+			},
+		},
+		Calendar: domain.CalendarConfig{
+			FeedURL:        getEnvVariable("CALENDAR_FEED_URL", "http://localhost:8080/api/v1/calendar-feeds"),
+			FeedPast:       getDurationEnv("CALENDAR_FEED_PAST", 30*24*time.Hour),
+			FeedHorizon:    getDurationEnv("CALENDAR_FEED_HORIZON", 365*24*time.Hour),
+			ImportHorizon:  getDurationEnv("CALENDAR_IMPORT_HORIZON", 365*24*time.Hour),
+			ImportMaxBytes: int64(getEnvAsInt("CALENDAR_IMPORT_MAX_BYTES", 2<<20)),
+			FetchTimeout:   getDurationEnv("CALENDAR_FETCH_TIMEOUT", 10*time.Second),
+		},
+		Booking: domain.BookingConfig{
+			HoldDuration:          getDurationEnv("BOOKING_HOLD_DURATION", 10*time.Minute),
+			MaxHoldsPerIP:         getEnvAsInt("BOOKING_MAX_HOLDS_PER_IP", 3),
+			WaitlistOfferDuration: getDurationEnv("BOOKING_WAITLIST_OFFER_DURATION", 2*time.Hour),
+			WaitlistSweepInterval: getDurationEnv("BOOKING_WAITLIST_SWEEP_INTERVAL", time.Minute),
+			ReviewLinkDuration:    getDurationEnv("BOOKING_REVIEW_LINK_DURATION", 30*24*time.Hour),
 		},
 	}
 	return config
@@ -129,5 +211,46 @@ func Print(cfg Config) {
 	log.Printf("JWT ATK Secret: %s\n", cfg.JWT.AtkSecret)
 	log.Printf("JWT RTK Secret: %s\n", cfg.JWT.RtkSecret)
 	log.Printf("JWT Expiry: %s\n", cfg.JWT.Expiry)
+	log.Printf("JWT Refresh Expiry: %s\n", cfg.JWT.RefreshExpiry)
+	log.Printf("JWT Keys File: %s\n", cfg.JWT.KeysFile)
+	log.Printf("--------------------------------")
+	log.Printf("-------GEOIP CONFIG-------------")
+	log.Printf("--------------------------------")
+	log.Printf("GeoIP Database: %s\n", cfg.GeoIP.DatabasePath)
+	log.Printf("--------------------------------")
+	log.Printf("-------MAIL CONFIG--------------")
+	log.Printf("--------------------------------")
+	log.Printf("Mail Driver: %s\n", cfg.Mail.Driver)
+	log.Printf("Mail From: %s\n", cfg.Mail.From)
+	log.Printf("SMTP Host: %s:%s\n", cfg.Mail.SMTPHost, cfg.Mail.SMTPPort)
+	log.Printf("SMTP Timeout: %s\n", cfg.Mail.SMTPTimeout)
+	log.Printf("Mail Outbox Dir: %s\n", cfg.Mail.OutboxDir)
+	log.Printf("--------------------------------")
+	log.Printf("-------ACCOUNT CONFIG-----------")
+	log.Printf("--------------------------------")
+	log.Printf("App URL: %s\n", cfg.Account.AppURL)
+	log.Printf("Password Reset Expiry: %s\n", cfg.Account.PasswordResetExpiry)
+	log.Printf("Email Verification Expiry: %s\n", cfg.Account.EmailVerificationExpiry)
+	log.Printf("Unverified Email Policy: %s\n", cfg.Account.UnverifiedEmailPolicy)
+	log.Printf("MFA Issuer: %s\n", cfg.Account.MFAIssuer)
+	log.Printf("MFA Challenge Expiry: %s\n", cfg.Account.MFAChallengeExpiry)
+	log.Printf("Login Max Failed Attempts: %d (per IP: %d) in %s\n", cfg.Account.LoginThrottle.MaxFailedAttempts, cfg.Account.LoginThrottle.MaxFailedAttemptsPerIP, cfg.Account.LoginThrottle.Window)
+	log.Printf("Login Lockout Duration: %s\n", cfg.Account.LoginThrottle.LockoutDuration)
+	log.Printf("--------------------------------")
+	log.Printf("-------CALENDAR CONFIG----------")
+	log.Printf("--------------------------------")
+	log.Printf("Calendar Feed URL: %s\n", cfg.Calendar.FeedURL)
+	log.Printf("Calendar Feed Range: -%s to +%s\n", cfg.Calendar.FeedPast, cfg.Calendar.FeedHorizon)
+	log.Printf("Calendar Import Horizon: +%s\n", cfg.Calendar.ImportHorizon)
+	log.Printf("Calendar Import Max Bytes: %d\n", cfg.Calendar.ImportMaxBytes)
+	log.Printf("Calendar Fetch Timeout: %s\n", cfg.Calendar.FetchTimeout)
+	log.Printf("--------------------------------")
+	log.Printf("-------BOOKING CONFIG-----------")
+	log.Printf("--------------------------------")
+	log.Printf("Booking Hold Duration: %s\n", cfg.Booking.HoldDuration)
+	log.Printf("Booking Max Holds Per IP: %d\n", cfg.Booking.MaxHoldsPerIP)
+	log.Printf("Booking Waitlist Offer Duration: %s\n", cfg.Booking.WaitlistOfferDuration)
+	log.Printf("Booking Waitlist Sweep Interval: %s\n", cfg.Booking.WaitlistSweepInterval)
+	log.Printf("Booking Review Link Duration: %s\n", cfg.Booking.ReviewLinkDuration)
 	log.Printf("--------------------------------")
 }
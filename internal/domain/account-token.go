@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountTokenPurpose string
+
+const (
+	AccountTokenPurposePasswordReset     AccountTokenPurpose = "password_reset"
+	AccountTokenPurposeEmailVerification AccountTokenPurpose = "email_verification"
+	AccountTokenPurposeMFAChallenge      AccountTokenPurpose = "mfa_challenge"
+)
+
+// AccountToken is a single-use, expiring token sent to the user out of band.
+// Only the hash of the token is stored.
+type AccountToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   AccountTokenPurpose
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppointmentLinkPurpose is the single action a self-service link allows
+type AppointmentLinkPurpose string
+
+const (
+	AppointmentLinkPurposeConfirm    AppointmentLinkPurpose = "confirm"
+	AppointmentLinkPurposeCancel     AppointmentLinkPurpose = "cancel"
+	AppointmentLinkPurposeReschedule AppointmentLinkPurpose = "reschedule"
+	// AppointmentLinkPurposeReview links are sent once the appointment is
+	// completed, see AppointmentReview
+	AppointmentLinkPurposeReview AppointmentLinkPurpose = "review"
+)
+
+func (p AppointmentLinkPurpose) IsValid() bool {
+	switch p {
+	case AppointmentLinkPurposeConfirm, AppointmentLinkPurposeCancel, AppointmentLinkPurposeReschedule, AppointmentLinkPurposeReview:
+		return true
+	}
+	return false
+}
+
+// AppointmentLinks let the customer of an appointment manage it without an
+// account. Each link is signed for a single purpose and expires when the
+// appointment starts, or sooner when the appointment changes.
+type AppointmentLinks struct {
+	ConfirmURL    string    `json:"confirm_url"`
+	CancelURL     string    `json:"cancel_url"`
+	RescheduleURL string    `json:"reschedule_url"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// ManagedAppointment is the appointment as shown to the customer opening one
+// of its links
+type ManagedAppointment struct {
+	AppointmentID uuid.UUID              `json:"appointment_id"`
+	Purpose       AppointmentLinkPurpose `json:"purpose"`
+	CenterName    string                 `json:"center_name"`
+	Timezone      string                 `json:"timezone"`
+	ServiceID     uuid.UUID              `json:"service_id"`
+	ServiceName   string                 `json:"service_name"`
+	StaffID       uuid.UUID              `json:"staff_id"`
+	StartsAt      time.Time              `json:"starts_at"`
+	EndsAt        time.Time              `json:"ends_at"`
+	Status        AppointmentStatus      `json:"status"`
+	CustomerName  string                 `json:"customer_name"`
+	// ChangeableUntil is when the cancellation window of the center starts,
+	// the appointment can't be cancelled or rescheduled online after it
+	ChangeableUntil time.Time `json:"changeable_until"`
+	// Links replace the ones of the customer once the appointment is
+	// rescheduled
+	Links *AppointmentLinks `json:"links,omitempty"`
+}
+
+type CancelAppointmentLinkInput struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// RescheduleAppointmentLinkInput moves the appointment to another slot of
+// the same service with the same staff member
+type RescheduleAppointmentLinkInput struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+}
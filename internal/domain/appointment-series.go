@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeriesScope tells which occurrences of a series an edit or a cancellation
+// applies to
+type SeriesScope string
+
+const (
+	SeriesScopeThis      SeriesScope = "this"
+	SeriesScopeFollowing SeriesScope = "following"
+	SeriesScopeAll       SeriesScope = "all"
+)
+
+func (s SeriesScope) IsValid() bool {
+	return s == SeriesScopeThis || s == SeriesScopeFollowing || s == SeriesScopeAll
+}
+
+// AppointmentSeries is a recurring appointment. Its occurrences are expanded
+// from the RRULE on demand, starting at StartsAt and keeping its wall clock
+// time in the timezone of the series. An occurrence is only stored, as an
+// appointment of the series, once it is edited on its own.
+type AppointmentSeries struct {
+	ID         uuid.UUID  `json:"id"`
+	CenterID   uuid.UUID  `json:"center_id"`
+	StaffID    uuid.UUID  `json:"staff_id"`
+	ServiceID  uuid.UUID  `json:"service_id"`
+	ResourceID *uuid.UUID `json:"resource_id,omitempty"`
+	// RRule is the value of an RFC 5545 RRULE property
+	RRule    string    `json:"rrule"`
+	StartsAt time.Time `json:"starts_at"`
+	// EndsAt is the end of the last occurrence, nil while the series repeats
+	// forever
+	EndsAt          *time.Time        `json:"ends_at,omitempty"`
+	DurationMinutes int               `json:"duration_minutes"`
+	Timezone        string            `json:"timezone"`
+	ExDates         []Date            `json:"exdates"`
+	Status          AppointmentStatus `json:"status"`
+	CustomerName    string            `json:"customer_name"`
+	CustomerEmail   string            `json:"customer_email,omitempty"`
+	CustomerPhone   string            `json:"customer_phone,omitempty"`
+	Notes           string            `json:"notes,omitempty"`
+	CreatedBy       *uuid.UUID        `json:"created_by,omitempty"`
+	CancelledAt     *time.Time        `json:"cancelled_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+func (s *AppointmentSeries) Location() (*time.Location, error) {
+	return time.LoadLocation(s.Timezone)
+}
+
+func (s *AppointmentSeries) Duration() time.Duration {
+	return time.Duration(s.DurationMinutes) * time.Minute
+}
+
+// FirstDate is the date of the first occurrence, excluded or not
+func (s *AppointmentSeries) FirstDate() Date {
+	loc, err := s.Location()
+	if err != nil {
+		return DateOf(s.StartsAt)
+	}
+	return DateOf(s.StartsAt.In(loc))
+}
+
+func (s *AppointmentSeries) IsExcluded(date Date) bool {
+	for _, exDate := range s.ExDates {
+		if exDate == date {
+			return true
+		}
+	}
+	return false
+}
+
+// AppointmentOccurrence is an occurrence of a series. Date is the date the
+// rule gives it, it identifies the occurrence even after it is moved. An
+// occurrence edited on its own carries its appointment.
+type AppointmentOccurrence struct {
+	SeriesID    uuid.UUID         `json:"series_id"`
+	Date        Date              `json:"date"`
+	StaffID     uuid.UUID         `json:"staff_id"`
+	ResourceID  *uuid.UUID        `json:"resource_id,omitempty"`
+	StartsAt    time.Time         `json:"starts_at"`
+	EndsAt      time.Time         `json:"ends_at"`
+	Status      AppointmentStatus `json:"status"`
+	Appointment *Appointment      `json:"appointment,omitempty"`
+	// RuleStartsAt is the start the rule gives the occurrence
+	RuleStartsAt time.Time `json:"-"`
+}
+
+// Attach makes the occurrence take the time and the status of the appointment
+// storing it
+func (o *AppointmentOccurrence) Attach(appointment *Appointment) {
+	o.StaffID = appointment.StaffID
+	o.ResourceID = appointment.ResourceID
+	o.StartsAt = appointment.StartsAt
+	o.EndsAt = appointment.EndsAt
+	o.Status = appointment.Status
+	o.Appointment = appointment
+}
+
+// OccurrenceFilter selects the occurrences starting, as given by the rule,
+// in [From, To)
+type OccurrenceFilter struct {
+	SeriesID *uuid.UUID
+	StaffID  *uuid.UUID
+	From     time.Time
+	To       time.Time
+}
+
+// SeriesConflict is an occurrence that can't be booked
+type SeriesConflict struct {
+	Date     Date      `json:"date"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// Reason is unavailable when the staff member does not work then, or
+	// conflict when the time is already taken
+	Reason string `json:"reason"`
+}
+
+const (
+	SeriesConflictUnavailable = "unavailable"
+	SeriesConflictBooked      = "conflict"
+)
+
+// SeriesConflictError lists the occurrences of a series that can't be booked
+type SeriesConflictError struct {
+	Err       error
+	Conflicts []SeriesConflict
+}
+
+func (e *SeriesConflictError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *SeriesConflictError) Unwrap() error {
+	return e.Err
+}
+
+// CreateAppointmentSeriesInput books a recurring service. The occurrences
+// that can't be booked fail the creation, unless SkipConflicts excludes them
+// from the series.
+type CreateAppointmentSeriesInput struct {
+	StaffID       uuid.UUID         `json:"staff_id" binding:"required"`
+	ServiceID     uuid.UUID         `json:"service_id" binding:"required"`
+	ResourceID    *uuid.UUID        `json:"resource_id"`
+	StartsAt      time.Time         `json:"starts_at" binding:"required"`
+	RRule         string            `json:"rrule" binding:"required,max=500"`
+	ExDates       []Date            `json:"exdates"`
+	Status        AppointmentStatus `json:"status"`
+	CustomerName  string            `json:"customer_name" binding:"required,max=200"`
+	CustomerEmail string            `json:"customer_email" binding:"omitempty,email,max=254"`
+	CustomerPhone string            `json:"customer_phone" binding:"omitempty,e164"`
+	Notes         string            `json:"notes" binding:"max=2000"`
+	SkipConflicts bool              `json:"skip_conflicts"`
+}
+
+// UpdateOccurrenceInput edits an occurrence, the following ones or the whole
+// series, it only changes the fields that are set. StartsAt is the new start
+// of the occurrence edited. The rule can't change for a single occurrence.
+type UpdateOccurrenceInput struct {
+	Scope         SeriesScope `json:"scope" binding:"required"`
+	StaffID       *uuid.UUID  `json:"staff_id"`
+	ServiceID     *uuid.UUID  `json:"service_id"`
+	ResourceID    *uuid.UUID  `json:"resource_id"`
+	StartsAt      *time.Time  `json:"starts_at"`
+	RRule         *string     `json:"rrule" binding:"omitempty,max=500"`
+	CustomerName  *string     `json:"customer_name" binding:"omitempty,min=1,max=200"`
+	CustomerEmail *string     `json:"customer_email" binding:"omitempty,email,max=254"`
+	CustomerPhone *string     `json:"customer_phone" binding:"omitempty,e164"`
+	Notes         *string     `json:"notes" binding:"omitempty,max=2000"`
+}
+
+type CancelOccurrenceInput struct {
+	Scope  SeriesScope `json:"scope" binding:"required"`
+	Reason string      `json:"reason" binding:"max=500"`
+}
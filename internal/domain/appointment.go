@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AppointmentStatus string
+
+const (
+	AppointmentStatusRequested AppointmentStatus = "requested"
+	AppointmentStatusConfirmed AppointmentStatus = "confirmed"
+	AppointmentStatusCheckedIn AppointmentStatus = "checked_in"
+	AppointmentStatusCompleted AppointmentStatus = "completed"
+	AppointmentStatusCancelled AppointmentStatus = "cancelled"
+	AppointmentStatusNoShow    AppointmentStatus = "no_show"
+)
+
+// appointmentTransitions is the state machine of appointments, completed,
+// cancelled and no-show appointments are final
+var appointmentTransitions = map[AppointmentStatus][]AppointmentStatus{
+	AppointmentStatusRequested: {AppointmentStatusConfirmed, AppointmentStatusCancelled},
+	AppointmentStatusConfirmed: {AppointmentStatusCheckedIn, AppointmentStatusCancelled, AppointmentStatusNoShow},
+	AppointmentStatusCheckedIn: {AppointmentStatusCompleted},
+}
+
+func (s AppointmentStatus) IsValid() bool {
+	switch s {
+	case AppointmentStatusRequested, AppointmentStatusConfirmed, AppointmentStatusCheckedIn,
+		AppointmentStatusCompleted, AppointmentStatusCancelled, AppointmentStatusNoShow:
+		return true
+	}
+	return false
+}
+
+func (s AppointmentStatus) CanTransitionTo(next AppointmentStatus) bool {
+	for _, allowed := range appointmentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s AppointmentStatus) IsFinal() bool {
+	return len(appointmentTransitions[s]) == 0
+}
+
+// BlocksTime tells whether an appointment in the status takes the time of its
+// staff member
+func (s AppointmentStatus) BlocksTime() bool {
+	return s != AppointmentStatusCancelled && s != AppointmentStatusNoShow
+}
+
+// Appointment is a service booked with a staff member of a center. Each
+// status records when the appointment reached it.
+type Appointment struct {
+	ID         uuid.UUID  `json:"id"`
+	CenterID   uuid.UUID  `json:"center_id"`
+	StaffID    uuid.UUID  `json:"staff_id"`
+	ServiceID  uuid.UUID  `json:"service_id"`
+	ResourceID *uuid.UUID `json:"resource_id,omitempty"`
+	// LeadID is the lead who booked the appointment online
+	LeadID *uuid.UUID `json:"lead_id,omitempty"`
+	// SeriesID and OccurrenceDate are set on an occurrence of a series edited
+	// on its own
+	SeriesID           *uuid.UUID        `json:"series_id,omitempty"`
+	OccurrenceDate     *Date             `json:"occurrence_date,omitempty"`
+	StartsAt           time.Time         `json:"starts_at"`
+	EndsAt             time.Time         `json:"ends_at"`
+	Status             AppointmentStatus `json:"status"`
+	CustomerName       string            `json:"customer_name"`
+	CustomerEmail      string            `json:"customer_email,omitempty"`
+	CustomerPhone      string            `json:"customer_phone,omitempty"`
+	Notes              string            `json:"notes,omitempty"`
+	CancellationReason string            `json:"cancellation_reason,omitempty"`
+	CreatedBy          *uuid.UUID        `json:"created_by,omitempty"`
+	ConfirmedAt        *time.Time        `json:"confirmed_at,omitempty"`
+	CheckedInAt        *time.Time        `json:"checked_in_at,omitempty"`
+	CompletedAt        *time.Time        `json:"completed_at,omitempty"`
+	CancelledAt        *time.Time        `json:"cancelled_at,omitempty"`
+	NoShowAt           *time.Time        `json:"no_show_at,omitempty"`
+	// LinkVersion is signed into the self-service links of the appointment,
+	// it is bumped whenever the appointment changes so older links stop
+	// working
+	LinkVersion int       `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SetStatus moves the appointment to the status and records when it did. It
+// does not check the transition, see AppointmentStatus.CanTransitionTo.
+func (a *Appointment) SetStatus(status AppointmentStatus, at time.Time) {
+	a.Status = status
+	switch status {
+	case AppointmentStatusConfirmed:
+		a.ConfirmedAt = &at
+	case AppointmentStatusCheckedIn:
+		a.CheckedInAt = &at
+	case AppointmentStatusCompleted:
+		a.CompletedAt = &at
+	case AppointmentStatusCancelled:
+		a.CancelledAt = &at
+	case AppointmentStatusNoShow:
+		a.NoShowAt = &at
+	}
+}
+
+// AppointmentTransitionError is returned when an appointment cannot move from
+// its current status to the requested one
+type AppointmentTransitionError struct {
+	Err  error
+	From AppointmentStatus
+	To   AppointmentStatus
+}
+
+func (e *AppointmentTransitionError) Error() string {
+	return e.Err.Error() + ": " + string(e.From) + " to " + string(e.To)
+}
+
+func (e *AppointmentTransitionError) Unwrap() error {
+	return e.Err
+}
+
+type AppointmentFilter struct {
+	StaffID *uuid.UUID
+	Status  *AppointmentStatus
+	From    *time.Time
+	To      *time.Time
+}
+
+// CreateAppointmentInput books a service. Status may only be requested, the
+// default, or confirmed.
+type CreateAppointmentInput struct {
+	StaffID       uuid.UUID         `json:"staff_id" binding:"required"`
+	ServiceID     uuid.UUID         `json:"service_id" binding:"required"`
+	ResourceID    *uuid.UUID        `json:"resource_id"`
+	StartsAt      time.Time         `json:"starts_at" binding:"required"`
+	Status        AppointmentStatus `json:"status"`
+	CustomerName  string            `json:"customer_name" binding:"required,max=200"`
+	CustomerEmail string            `json:"customer_email" binding:"omitempty,email,max=254"`
+	CustomerPhone string            `json:"customer_phone" binding:"omitempty,e164"`
+	Notes         string            `json:"notes" binding:"max=2000"`
+}
+
+// UpdateAppointmentInput only changes the fields that are set. Moving an
+// appointment to another staff member, service, resource or time is only
+// possible until it is checked in.
+type UpdateAppointmentInput struct {
+	StaffID       *uuid.UUID `json:"staff_id"`
+	ServiceID     *uuid.UUID `json:"service_id"`
+	ResourceID    *uuid.UUID `json:"resource_id"`
+	StartsAt      *time.Time `json:"starts_at"`
+	CustomerName  *string    `json:"customer_name" binding:"omitempty,min=1,max=200"`
+	CustomerEmail *string    `json:"customer_email" binding:"omitempty,email,max=254"`
+	CustomerPhone *string    `json:"customer_phone" binding:"omitempty,e164"`
+	Notes         *string    `json:"notes" binding:"omitempty,max=2000"`
+}
+
+type AppointmentStatusInput struct {
+	Status AppointmentStatus `json:"status" binding:"required"`
+	Reason string            `json:"reason" binding:"max=500"`
+}
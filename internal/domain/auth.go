@@ -9,6 +9,9 @@ type JWTClaims struct {
 	UserID   uuid.UUID `json:"user_id"`
 	Email    string    `json:"email"`
 	SourceID string    `json:"source_id"`
+	// EmailVerified is resolved from the user when the token is validated,
+	// it is not part of the signed token
+	EmailVerified bool `json:"-"`
 	jwt.RegisteredClaims
 }
 
@@ -26,9 +29,14 @@ type AuthenticationUserPayload struct {
 	ID uuid.UUID `json:"id"`
 }
 
+// AuthenticationPayload is returned by the login endpoints. When the user has
+// MFA enabled, the first step only carries MFARequired and MFAToken, which must
+// be exchanged for the session tokens together with a TOTP or recovery code.
 type AuthenticationPayload struct {
-	AccessToken  string                     `json:"access_token"`
-	RefreshToken string                     `json:"refresh_token"`
+	AccessToken  string                     `json:"access_token,omitempty"`
+	RefreshToken string                     `json:"refresh_token,omitempty"`
 	User         *AuthenticationUserPayload `json:"user"`
-	ExpiresIn    int64                      `json:"expires_in"`
+	ExpiresIn    int64                      `json:"expires_in,omitempty"`
+	MFARequired  bool                       `json:"mfa_required,omitempty"`
+	MFAToken     string                     `json:"mfa_token,omitempty"`
 }
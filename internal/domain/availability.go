@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AvailabilityRule is a weekly interval during which a staff member can be
+// booked in a center. A weekday can have several rules, e.g. a morning and an
+// afternoon shift. The rule only applies between its effective dates, both
+// included, when they are set.
+type AvailabilityRule struct {
+	ID             uuid.UUID    `json:"id"`
+	CenterID       uuid.UUID    `json:"center_id"`
+	UserID         uuid.UUID    `json:"user_id"`
+	Weekday        time.Weekday `json:"weekday"`
+	StartTime      TimeOfDay    `json:"start_time"`
+	EndTime        TimeOfDay    `json:"end_time"`
+	EffectiveFrom  *Date        `json:"effective_from,omitempty"`
+	EffectiveUntil *Date        `json:"effective_until,omitempty"`
+	IsActive       bool         `json:"is_active"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// AppliesOn tells whether the rule is active on the date
+func (r *AvailabilityRule) AppliesOn(date Date) bool {
+	if !r.IsActive || date.Weekday() != r.Weekday {
+		return false
+	}
+	if r.EffectiveFrom != nil && date.Before(*r.EffectiveFrom) {
+		return false
+	}
+	if r.EffectiveUntil != nil && date.After(*r.EffectiveUntil) {
+		return false
+	}
+	return true
+}
+
+// Overlaps tells whether both rules could apply at the same time: they are
+// active on the same weekday, their intervals intersect and so do their
+// effective periods
+func (r *AvailabilityRule) Overlaps(other *AvailabilityRule) bool {
+	if !r.IsActive || !other.IsActive || r.Weekday != other.Weekday {
+		return false
+	}
+	if r.StartTime >= other.EndTime || other.StartTime >= r.EndTime {
+		return false
+	}
+	if r.EffectiveUntil != nil && other.EffectiveFrom != nil && r.EffectiveUntil.Before(*other.EffectiveFrom) {
+		return false
+	}
+	if other.EffectiveUntil != nil && r.EffectiveFrom != nil && other.EffectiveUntil.Before(*r.EffectiveFrom) {
+		return false
+	}
+	return true
+}
+
+// AvailabilityRuleInput creates or replaces a rule, IsActive defaults to true
+type AvailabilityRuleInput struct {
+	Weekday        time.Weekday `json:"weekday" binding:"min=0,max=6"`
+	StartTime      TimeOfDay    `json:"start_time"`
+	EndTime        TimeOfDay    `json:"end_time"`
+	EffectiveFrom  *Date        `json:"effective_from"`
+	EffectiveUntil *Date        `json:"effective_until"`
+	IsActive       *bool        `json:"is_active"`
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingConfig struct {
+	// HoldDuration is how long a slot stays held for the customer filling in
+	// their details
+	HoldDuration time.Duration
+	// MaxHoldsPerIP bounds the active holds of a center placed from the same
+	// IP address, so a single client can't hold every slot
+	MaxHoldsPerIP int
+	// WaitlistOfferDuration is how long a freed slot stays offered to a lead
+	// of the waitlist before it goes to the next one
+	WaitlistOfferDuration time.Duration
+	// WaitlistSweepInterval is how often the expired offers are passed on
+	WaitlistSweepInterval time.Duration
+	// ReviewLinkDuration is how long after an appointment is completed its
+	// customer can review it
+	ReviewLinkDuration time.Duration
+}
+
+// SlotHold keeps a slot from being booked by anyone else until it expires,
+// while the customer who placed it confirms the booking. The token placed
+// along with the hold is required to confirm or release it.
+type SlotHold struct {
+	ID        uuid.UUID `json:"id"`
+	CenterID  uuid.UUID `json:"center_id"`
+	StaffID   uuid.UUID `json:"staff_id"`
+	ServiceID uuid.UUID `json:"service_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Token is only set when the hold is placed
+	Token     string    `json:"token,omitempty"`
+	TokenHash string    `json:"-"`
+	IPAddress string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *SlotHold) IsActive(now time.Time) bool {
+	return now.Before(h.ExpiresAt)
+}
+
+// BookingCenter is what customers see of a center that can be booked online
+type BookingCenter struct {
+	Name     string   `json:"name"`
+	Slug     string   `json:"slug"`
+	Address  *Address `json:"address,omitempty"`
+	Phone    string   `json:"phone,omitempty"`
+	Email    string   `json:"email,omitempty"`
+	Timezone string   `json:"timezone"`
+	Locale   string   `json:"locale"`
+	Currency string   `json:"currency"`
+	// CancellationWindowHours is how long before their appointment customers
+	// can still cancel or reschedule it
+	CancellationWindowHours int               `json:"cancellation_window_hours"`
+	Services                []*BookingService `json:"services"`
+}
+
+// BookingService is an active service of a center, the buffers stay internal
+type BookingService struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	DurationMinutes int       `json:"duration_minutes"`
+}
+
+// Booking is the appointment made online, as shown to the customer who made
+// it
+type Booking struct {
+	AppointmentID uuid.UUID         `json:"appointment_id"`
+	LeadID        uuid.UUID         `json:"lead_id"`
+	StaffID       uuid.UUID         `json:"staff_id"`
+	ServiceID     uuid.UUID         `json:"service_id"`
+	StartsAt      time.Time         `json:"starts_at"`
+	EndsAt        time.Time         `json:"ends_at"`
+	Status        AppointmentStatus `json:"status"`
+	// Links let the customer confirm, cancel or reschedule the booking
+	Links *AppointmentLinks `json:"links,omitempty"`
+}
+
+// HoldSlotInput holds a slot of the service, with the staff member or with
+// anyone free at that time when StaffID is nil
+type HoldSlotInput struct {
+	ServiceID uuid.UUID  `json:"service_id" binding:"required"`
+	StaffID   *uuid.UUID `json:"staff_id"`
+	StartsAt  time.Time  `json:"starts_at" binding:"required"`
+}
+
+// ConfirmBookingInput identifies the customer by their email or phone, the
+// lead is created unless one of the center already has them
+type ConfirmBookingInput struct {
+	Name     string          `json:"name" binding:"required,max=200"`
+	Email    string          `json:"email" binding:"omitempty,email,max=254"`
+	Phone    string          `json:"phone" binding:"max=32"`
+	Notes    string          `json:"notes" binding:"max=2000"`
+	Consents []*ConsentInput `json:"consents" binding:"omitempty,max=10,dive"`
+}
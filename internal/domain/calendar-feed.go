@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed is the iCalendar subscription of a staff member to their
+// appointments in a center. The feed is read with a secret token in its URL,
+// of which only the hash is stored. A staff member has at most one active
+// feed per center, rotating it revokes the previous URL.
+type CalendarFeed struct {
+	ID        uuid.UUID  `json:"id"`
+	CenterID  uuid.UUID  `json:"center_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	TokenHash string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CalendarFeedLink is returned once, when the feed is created, as the token
+// can't be recovered afterwards
+type CalendarFeedLink struct {
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CalendarConfig struct {
+	// FeedURL is the public URL the feed tokens are appended to
+	FeedURL string
+	// FeedPast and FeedHorizon bound the appointments served around now
+	FeedPast    time.Duration
+	FeedHorizon time.Duration
+	// ImportHorizon bounds the imported busy times after now, ImportMaxBytes
+	// the size of the imported calendars
+	ImportHorizon  time.Duration
+	ImportMaxBytes int64
+	// FetchTimeout bounds the download of an external calendar
+	FetchTimeout time.Duration
+}
@@ -1,9 +1,174 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultCenterTimezone = "UTC"
+	DefaultCenterLocale   = "en"
+	DefaultCenterCurrency = "EUR"
+)
 
 type Center struct {
-	ID      uuid.UUID `json:"id"`
-	Name    string    `json:"name"`
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Slug names the center in the public booking routes, centers without
+	// one can't be booked online
+	Slug    string    `json:"slug,omitempty"`
 	OwnerID uuid.UUID `json:"owner_id"`
+	Address *Address  `json:"address,omitempty"`
+	Phone   string    `json:"phone,omitempty"`
+	Email   string    `json:"email,omitempty"`
+	// Timezone is an IANA name, e.g. Europe/Madrid. Every schedule of the
+	// center is expressed in it.
+	Timezone string `json:"timezone"`
+	Locale   string `json:"locale"`
+	Currency string `json:"currency"`
+	// CancellationWindowHours is how long before an appointment its customer
+	// can no longer cancel or reschedule it through their links
+	CancellationWindowHours int        `json:"cancellation_window_hours"`
+	CreatedAt               time.Time  `json:"created_at"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+	DeletedAt               *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Location returns the timezone of the center
+func (c *Center) Location() (*time.Location, error) {
+	return time.LoadLocation(c.Timezone)
+}
+
+func (c *Center) CancellationWindow() time.Duration {
+	return time.Duration(c.CancellationWindowHours) * time.Hour
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// IsValidSlug tells whether the slug is 3 to 63 lowercase letters, digits and
+// single hyphens between them, so that it fits in a URL as is
+func IsValidSlug(slug string) bool {
+	return len(slug) >= 3 && len(slug) <= 63 && slugPattern.MatchString(slug)
+}
+
+// Address is the postal address of a center, Country is an ISO 3166-1 alpha-2
+// code
+type Address struct {
+	Line1      string `json:"line1" binding:"required,max=200"`
+	Line2      string `json:"line2,omitempty" binding:"max=200"`
+	City       string `json:"city" binding:"required,max=100"`
+	Region     string `json:"region,omitempty" binding:"max=100"`
+	PostalCode string `json:"postal_code" binding:"required,max=20"`
+	Country    string `json:"country" binding:"required,iso3166_1_alpha2"`
+}
+
+// OpeningHours is a range of a weekday during which a center is open. A day
+// can have several ranges, e.g. to close at lunch time.
+type OpeningHours struct {
+	ID       uuid.UUID    `json:"id"`
+	CenterID uuid.UUID    `json:"center_id"`
+	Weekday  time.Weekday `json:"weekday"`
+	OpensAt  TimeOfDay    `json:"opens_at"`
+	ClosesAt TimeOfDay    `json:"closes_at"`
+}
+
+// CenterService is a service offered by a center, its duration drives the
+// length of the appointments booked for it. The buffers are kept free before
+// and after each appointment, e.g. to clean up a room.
+type CenterService struct {
+	ID                  uuid.UUID `json:"id"`
+	CenterID            uuid.UUID `json:"center_id"`
+	Name                string    `json:"name"`
+	DurationMinutes     int       `json:"duration_minutes"`
+	BufferBeforeMinutes int       `json:"buffer_before_minutes"`
+	BufferAfterMinutes  int       `json:"buffer_after_minutes"`
+	IsActive            bool      `json:"is_active"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// CenterResource is a room or a piece of equipment of a center. An
+// appointment that uses a resource keeps it from being booked by another one
+// at the same time.
+type CenterResource struct {
+	ID        uuid.UUID `json:"id"`
+	CenterID  uuid.UUID `json:"center_id"`
+	Name      string    `json:"name"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CenterSetup is everything created when a business is onboarded
+type CenterSetup struct {
+	Center       *Center          `json:"center"`
+	OpeningHours []*OpeningHours  `json:"opening_hours"`
+	Services     []*CenterService `json:"services"`
+}
+
+// CenterProfileInput holds the profile of a new center. The phone must be in
+// E.164 format, the locale a BCP 47 tag and the currency an ISO 4217 code.
+// Locale and currency default to en and EUR.
+type CenterProfileInput struct {
+	Address  *Address `json:"address" binding:"omitempty"`
+	Phone    string   `json:"phone" binding:"omitempty,e164"`
+	Email    string   `json:"email" binding:"omitempty,email,max=254"`
+	Timezone string   `json:"timezone" binding:"required,timezone"`
+	Locale   string   `json:"locale" binding:"omitempty,bcp47_language_tag"`
+	Currency string   `json:"currency" binding:"omitempty,iso4217"`
+}
+
+type CreateCenterInput struct {
+	Name string `json:"name" binding:"required,max=120"`
+	CenterProfileInput
+}
+
+// UpdateCenterInput only changes the fields that are set, an empty slug
+// closes the online booking of the center
+type UpdateCenterInput struct {
+	Name     *string  `json:"name" binding:"omitempty,min=1,max=120"`
+	Slug     *string  `json:"slug" binding:"omitempty,max=63"`
+	Address  *Address `json:"address" binding:"omitempty"`
+	Phone    *string  `json:"phone" binding:"omitempty,e164"`
+	Email    *string  `json:"email" binding:"omitempty,email,max=254"`
+	Timezone *string  `json:"timezone" binding:"omitempty,timezone"`
+	Locale   *string  `json:"locale" binding:"omitempty,bcp47_language_tag"`
+	Currency *string  `json:"currency" binding:"omitempty,iso4217"`
+	// CancellationWindowHours is at most a month
+	CancellationWindowHours *int `json:"cancellation_window_hours" binding:"omitempty,min=0,max=720"`
+}
+
+type OpeningHoursInput struct {
+	Weekday  time.Weekday `json:"weekday" binding:"min=0,max=6"`
+	OpensAt  TimeOfDay    `json:"opens_at"`
+	ClosesAt TimeOfDay    `json:"closes_at"`
+}
+
+type CenterServiceInput struct {
+	Name                string `json:"name" binding:"required,max=120"`
+	DurationMinutes     int    `json:"duration_minutes" binding:"required,min=5,max=1440"`
+	BufferBeforeMinutes int    `json:"buffer_before_minutes" binding:"min=0,max=240"`
+	BufferAfterMinutes  int    `json:"buffer_after_minutes" binding:"min=0,max=240"`
+}
+
+type CenterResourceInput struct {
+	Name string `json:"name" binding:"required,max=120"`
+}
+
+// UpdateCenterResourceInput only changes the fields that are set, inactive
+// resources can no longer be booked
+type UpdateCenterResourceInput struct {
+	Name     *string `json:"name" binding:"omitempty,min=1,max=120"`
+	IsActive *bool   `json:"is_active"`
+}
+
+// CenterSetupInput onboards a business. Opening hours default to Monday to
+// Friday, 09:00 to 18:00, and the service to a 30 minutes appointment.
+type CenterSetupInput struct {
+	Name string `json:"name" binding:"required,max=120"`
+	CenterProfileInput
+	OpeningHours []OpeningHoursInput `json:"opening_hours" binding:"omitempty,dive"`
+	Service      *CenterServiceInput `json:"service"`
 }
@@ -3,7 +3,48 @@ package domain
 import "time"
 
 type JWTConfig struct {
-	AtkSecret string
-	RtkSecret string
-	Expiry    time.Duration
+	// AtkSecret signs access tokens with HS256 when no KeysFile is configured
+	AtkSecret     string
+	RtkSecret     string
+	Expiry        time.Duration
+	RefreshExpiry time.Duration
+	// KeysFile is the JSON manifest of the asymmetric access token keys
+	KeysFile string
+}
+
+// UnverifiedEmailPolicy decides what users that did not verify their email
+// address yet are allowed to do once authenticated
+type UnverifiedEmailPolicy string
+
+const (
+	// UnverifiedEmailPolicyAllow does not restrict unverified users
+	UnverifiedEmailPolicyAllow UnverifiedEmailPolicy = "allow"
+	// UnverifiedEmailPolicyRestrict only allows read requests
+	UnverifiedEmailPolicyRestrict UnverifiedEmailPolicy = "restrict"
+	// UnverifiedEmailPolicyBlock rejects every authenticated request
+	UnverifiedEmailPolicyBlock UnverifiedEmailPolicy = "block"
+)
+
+type AccountConfig struct {
+	AppURL                  string
+	PasswordResetExpiry     time.Duration
+	EmailVerificationExpiry time.Duration
+	UnverifiedEmailPolicy   UnverifiedEmailPolicy
+	MFAIssuer               string
+	MFAChallengeExpiry      time.Duration
+	LoginThrottle           LoginThrottleConfig
+}
+
+// LoginThrottleConfig drives the brute-force protection of the login. Failed
+// attempts are counted per account and per client IP inside Window; after
+// DelayAfter failures every attempt must wait an exponentially growing delay,
+// and reaching the maximum locks the key for LockoutDuration.
+type LoginThrottleConfig struct {
+	MaxFailedAttempts      int
+	MaxFailedAttemptsPerIP int
+	Window                 time.Duration
+	LockoutDuration        time.Duration
+	DelayAfter             int
+	BaseDelay              time.Duration
+	MaxDelay               time.Duration
 }
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrInvalidDate = errors.New("invalid date, expected YYYY-MM-DD")
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day or a timezone. It is read in
+// the timezone of the center it belongs to.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func ParseDate(value string) (Date, error) {
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return Date{}, ErrInvalidDate
+	}
+	return DateOf(parsed), nil
+}
+
+// DateOf returns the date of t in the location of t
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// In returns the midnight that starts the date in the location
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+func (d Date) AddDays(days int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, days))
+}
+
+func (d Date) Before(other Date) bool {
+	return d.In(time.UTC).Before(other.In(time.UTC))
+}
+
+func (d Date) After(other Date) bool {
+	return other.Before(d)
+}
+
+func (d Date) String() string {
+	return d.In(time.UTC).Format(dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return ErrInvalidDate
+	}
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
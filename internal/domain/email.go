@@ -0,0 +1,7 @@
+package domain
+
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -15,4 +15,7 @@ var (
 	ErrNotFound            Error = errors.New("NOT_FOUND")
 	ErrBadRequest          Error = errors.New("BAD_REQUEST")
 	ErrUnexpectedError     Error = errors.New("UNEXPECTED_ERROR")
+
+	ErrStaffDoubleBooked    Error = errors.New("STAFF_DOUBLE_BOOKED")
+	ErrResourceDoubleBooked Error = errors.New("RESOURCE_DOUBLE_BOOKED")
 )
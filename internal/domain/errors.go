@@ -10,16 +10,28 @@ type HTTPErrorBody struct {
 	Errors any    `json:"errors"`
 }
 
+// DomainError is an error that knows how it is exposed over HTTP, the code of
+// its body is machine-readable
 type DomainError struct {
 	HTTPCode      int
 	HTTPErrorBody HTTPErrorBody
 	OriginalError string
+	err           error
+}
+
+func (e *DomainError) Error() string {
+	return e.OriginalError
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.err
 }
 
 func NewDomainError(httpCode int, errorCode string, errorMsg any, err error) *DomainError {
 	return &DomainError{
 		HTTPCode:      httpCode,
 		OriginalError: err.Error(),
+		err:           err,
 		HTTPErrorBody: HTTPErrorBody{
 			Code:   errorCode,
 			Errors: errorMsg,
@@ -31,6 +43,7 @@ func NewInternalError(err error) *DomainError {
 	return &DomainError{
 		HTTPCode:      http.StatusInternalServerError,
 		OriginalError: err.Error(),
+		err:           err,
 		HTTPErrorBody: HTTPErrorBody{
 			Code:   ErrUnexpectedError.Error(),
 			Errors: "Internal server error",
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExternalCalendar is the iCalendar URL of a staff member's own calendar,
+// e.g. the private address of a Google or Outlook calendar. Its events are
+// imported as time blocks so that nobody books on top of them. A staff member
+// has at most one external calendar per center.
+type ExternalCalendar struct {
+	ID           uuid.UUID  `json:"id"`
+	CenterID     uuid.UUID  `json:"center_id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	URL          string     `json:"url"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type ExternalCalendarInput struct {
+	URL string `json:"url" binding:"required,max=2048"`
+}
+
+// BusyImportResult counts what an import did to the imported blocks. Skipped
+// events are the free, cancelled or unreadable ones.
+type BusyImportResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+	Skipped int `json:"skipped"`
+}
@@ -0,0 +1,6 @@
+package domain
+
+type GeoLocation struct {
+	City        string `json:"city"`
+	CountryCode string `json:"country_code"`
+}
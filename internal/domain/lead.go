@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lead is an end customer of a center, the person who books. Email and Phone
+// are stored normalized, and a center has at most one lead with each of them.
+type Lead struct {
+	ID       uuid.UUID `json:"id"`
+	CenterID uuid.UUID `json:"center_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email,omitempty"`
+	Phone    string    `json:"phone,omitempty"`
+	Notes    string    `json:"notes,omitempty"`
+	// Consents holds the latest consent recorded for each purpose
+	Consents  []*LeadConsent `json:"consents,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// HasConsent tells whether the latest consent of the lead for the purpose
+// grants it. Consents must be loaded.
+func (l *Lead) HasConsent(purpose ConsentPurpose) bool {
+	for _, consent := range l.Consents {
+		if consent.Purpose == purpose {
+			return consent.Granted
+		}
+	}
+	return false
+}
+
+type ConsentPurpose string
+
+const (
+	// ConsentPurposeDataProcessing covers keeping the lead's data to manage
+	// their appointments
+	ConsentPurposeDataProcessing ConsentPurpose = "data_processing"
+	// ConsentPurposeCommunications covers the messages about their bookings,
+	// e.g. reminders or waitlist offers
+	ConsentPurposeCommunications ConsentPurpose = "communications"
+	ConsentPurposeMarketing      ConsentPurpose = "marketing"
+)
+
+func (p ConsentPurpose) IsValid() bool {
+	switch p {
+	case ConsentPurposeDataProcessing, ConsentPurposeCommunications, ConsentPurposeMarketing:
+		return true
+	}
+	return false
+}
+
+type ConsentSource string
+
+const (
+	// ConsentSourceStaff consents are recorded by a member of the center,
+	// e.g. from a signed paper form
+	ConsentSourceStaff ConsentSource = "staff"
+	// ConsentSourceBooking consents are given by the lead when booking online
+	ConsentSourceBooking ConsentSource = "booking"
+)
+
+// LeadConsent proves that a lead granted, or withdrew, a consent: what they
+// agreed to, under which version of the privacy policy, when and from where.
+// Consents are never changed, a withdrawal is a new consent that isn't
+// granted.
+type LeadConsent struct {
+	ID            uuid.UUID      `json:"id"`
+	LeadID        uuid.UUID      `json:"lead_id"`
+	Purpose       ConsentPurpose `json:"purpose"`
+	Granted       bool           `json:"granted"`
+	PolicyVersion string         `json:"policy_version"`
+	// Statement is the text shown to the lead
+	Statement  string        `json:"statement,omitempty"`
+	Source     ConsentSource `json:"source"`
+	IPAddress  string        `json:"ip_address,omitempty"`
+	UserAgent  string        `json:"user_agent,omitempty"`
+	RecordedBy *uuid.UUID    `json:"recorded_by,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// CurrentConsents returns the latest consent of each purpose, in the order of
+// the purposes
+func CurrentConsents(consents []*LeadConsent) []*LeadConsent {
+	latest := make(map[ConsentPurpose]*LeadConsent)
+	for _, consent := range consents {
+		if current, found := latest[consent.Purpose]; !found || consent.CreatedAt.After(current.CreatedAt) {
+			latest[consent.Purpose] = consent
+		}
+	}
+	current := make([]*LeadConsent, 0, len(latest))
+	for _, consent := range latest {
+		current = append(current, consent)
+	}
+	sort.Slice(current, func(i, j int) bool { return current[i].Purpose < current[j].Purpose })
+	return current
+}
+
+// DuplicateLeadError is returned when the email or the phone of a lead
+// already belongs to another lead of the center
+type DuplicateLeadError struct {
+	Err    error
+	LeadID uuid.UUID
+}
+
+func (e *DuplicateLeadError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DuplicateLeadError) Unwrap() error {
+	return e.Err
+}
+
+// NormalizeEmail lowercases the email, so that it matches however it is typed
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone keeps the digits of a phone number, with a leading + for the
+// international ones, written with + or 00. It returns an empty string when
+// the number has other characters than digits, spaces, dots, dashes and
+// parentheses, or when it is too short or too long to be a phone number.
+func NormalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	international := strings.HasPrefix(phone, "+")
+	if international {
+		phone = phone[1:]
+	}
+
+	var digits strings.Builder
+	for _, c := range phone {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == ' ' || c == '.' || c == '-' || c == '(' || c == ')':
+		default:
+			return ""
+		}
+	}
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		number, international = number[2:], true
+	}
+	if len(number) < 6 || len(number) > 15 {
+		return ""
+	}
+	if international {
+		return "+" + number
+	}
+	return number
+}
+
+type LeadFilter struct {
+	// Query matches the name, the email or the phone of the leads
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// ConsentInput records a consent given by the lead. The IP address and the
+// user agent are the ones of the request recording it.
+type ConsentInput struct {
+	Purpose       ConsentPurpose `json:"purpose" binding:"required"`
+	Granted       bool           `json:"granted"`
+	PolicyVersion string         `json:"policy_version" binding:"required,max=50"`
+	Statement     string         `json:"statement" binding:"max=2000"`
+}
+
+// CreateLeadInput needs an email or a phone, the consents given along are
+// recorded with the lead
+type CreateLeadInput struct {
+	Name     string          `json:"name" binding:"required,max=200"`
+	Email    string          `json:"email" binding:"omitempty,email,max=254"`
+	Phone    string          `json:"phone" binding:"max=32"`
+	Notes    string          `json:"notes" binding:"max=2000"`
+	Consents []*ConsentInput `json:"consents" binding:"omitempty,max=10,dive"`
+}
+
+// UpdateLeadInput only changes the fields that are set, an empty email or
+// phone removes it
+type UpdateLeadInput struct {
+	Name  *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Email *string `json:"email" binding:"omitempty,max=254"`
+	Phone *string `json:"phone" binding:"omitempty,max=32"`
+	Notes *string `json:"notes" binding:"omitempty,max=2000"`
+}
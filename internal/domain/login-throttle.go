@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Login throttle key scopes
+const (
+	LoginThrottleScopeAccount = "account"
+	LoginThrottleScopeIP      = "ip"
+)
+
+// LoginThrottleKey identifies the failed login counter of an account or of a
+// client IP address
+func LoginThrottleKey(scope, value string) string {
+	return fmt.Sprintf("%s:%s", scope, value)
+}
+
+// LoginThrottle tracks the failed login attempts of a key inside the current
+// window, and the lockout they triggered
+type LoginThrottle struct {
+	Key             string
+	FailedCount     int
+	WindowStartedAt time.Time
+	LastFailedAt    time.Time
+	LockedUntil     *time.Time
+}
+
+// LoginThrottledError is returned when a login attempt is rejected before the
+// credentials are checked. RetryAfter tells when the next attempt is accepted.
+type LoginThrottledError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CenterRole is the role of a user inside a center
+type CenterRole string
+
+const (
+	CenterRoleOwner        CenterRole = "owner"
+	CenterRoleAdmin        CenterRole = "admin"
+	CenterRoleStaff        CenterRole = "staff"
+	CenterRoleReceptionist CenterRole = "receptionist"
+)
+
+// Permission is an action on the resources of a center
+type Permission string
+
+const (
+	PermissionCenterRead         Permission = "center:read"
+	PermissionCenterUpdate       Permission = "center:update"
+	PermissionCenterDelete       Permission = "center:delete"
+	PermissionMembersRead        Permission = "members:read"
+	PermissionMembersManage      Permission = "members:manage"
+	PermissionScheduleRead       Permission = "schedule:read"
+	PermissionScheduleManage     Permission = "schedule:manage"
+	PermissionAppointmentsRead   Permission = "appointments:read"
+	PermissionAppointmentsManage Permission = "appointments:manage"
+	PermissionLeadsRead          Permission = "leads:read"
+	PermissionLeadsManage        Permission = "leads:manage"
+	PermissionReviewsRead        Permission = "reviews:read"
+	PermissionReviewsModerate    Permission = "reviews:moderate"
+)
+
+// rolePermissions is the permission matrix. Staff members can always manage
+// their own schedule and appointments, which is checked by the services;
+// schedule:manage and appointments:manage grant it over every member.
+var rolePermissions = map[CenterRole][]Permission{
+	CenterRoleOwner: {
+		PermissionCenterRead, PermissionCenterUpdate, PermissionCenterDelete,
+		PermissionMembersRead, PermissionMembersManage,
+		PermissionScheduleRead, PermissionScheduleManage,
+		PermissionAppointmentsRead, PermissionAppointmentsManage,
+		PermissionLeadsRead, PermissionLeadsManage,
+		PermissionReviewsRead, PermissionReviewsModerate,
+	},
+	CenterRoleAdmin: {
+		PermissionCenterRead, PermissionCenterUpdate,
+		PermissionMembersRead, PermissionMembersManage,
+		PermissionScheduleRead, PermissionScheduleManage,
+		PermissionAppointmentsRead, PermissionAppointmentsManage,
+		PermissionLeadsRead, PermissionLeadsManage,
+		PermissionReviewsRead, PermissionReviewsModerate,
+	},
+	CenterRoleStaff: {
+		PermissionCenterRead,
+		PermissionMembersRead,
+		PermissionScheduleRead,
+		PermissionAppointmentsRead,
+		PermissionLeadsRead,
+		PermissionReviewsRead,
+	},
+	CenterRoleReceptionist: {
+		PermissionCenterRead,
+		PermissionMembersRead,
+		PermissionScheduleRead,
+		PermissionAppointmentsRead, PermissionAppointmentsManage,
+		PermissionLeadsRead, PermissionLeadsManage,
+		PermissionReviewsRead,
+	},
+}
+
+func (r CenterRole) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can tells whether the role grants a permission
+func (r CenterRole) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns the permissions granted by the role
+func (r CenterRole) Permissions() []Permission {
+	return append([]Permission(nil), rolePermissions[r]...)
+}
+
+// CenterMembership links a user to a center with a role
+type CenterMembership struct {
+	ID        uuid.UUID  `json:"id"`
+	CenterID  uuid.UUID  `json:"center_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Role      CenterRole `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CenterMember is a membership with the public profile of its user
+type CenterMember struct {
+	UserID      uuid.UUID    `json:"user_id"`
+	Email       string       `json:"email"`
+	FirstName   string       `json:"first_name"`
+	LastName    string       `json:"last_name"`
+	Role        CenterRole   `json:"role"`
+	Permissions []Permission `json:"permissions"`
+	JoinedAt    time.Time    `json:"joined_at"`
+}
+
+type AddMemberInput struct {
+	Email string     `json:"email" binding:"required,email"`
+	Role  CenterRole `json:"role" binding:"required"`
+}
+
+type UpdateMemberRoleInput struct {
+	Role CenterRole `json:"role" binding:"required"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCode is a single-use MFA fallback code, only its hash is stored
+type RecoveryCode struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type LoginMFAInput struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	// Code is either a TOTP code or a recovery code
+	Code string `json:"code" binding:"required"`
+}
+
+type TOTPCodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TOTPSetupPayload struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesPayload struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MinReviewRating = 1
+	MaxReviewRating = 5
+)
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPublished ReviewStatus = "published"
+	// ReviewStatusFlagged reviews wait for a moderator, they are left out of
+	// the public reviews and ratings meanwhile
+	ReviewStatusFlagged ReviewStatus = "flagged"
+	ReviewStatusHidden  ReviewStatus = "hidden"
+)
+
+func (s ReviewStatus) IsValid() bool {
+	switch s {
+	case ReviewStatusPublished, ReviewStatusFlagged, ReviewStatusHidden:
+		return true
+	}
+	return false
+}
+
+// AppointmentReview is the rating a customer gave to a completed appointment,
+// through the review link of the appointment. An appointment has at most one
+// review.
+type AppointmentReview struct {
+	ID            uuid.UUID  `json:"id"`
+	CenterID      uuid.UUID  `json:"center_id"`
+	AppointmentID uuid.UUID  `json:"appointment_id"`
+	StaffID       uuid.UUID  `json:"staff_id"`
+	ServiceID     uuid.UUID  `json:"service_id"`
+	LeadID        *uuid.UUID `json:"lead_id,omitempty"`
+	// AuthorName is the first name of the customer, as shown publicly
+	AuthorName     string       `json:"author_name"`
+	Rating         int          `json:"rating"`
+	Comment        string       `json:"comment,omitempty"`
+	Status         ReviewStatus `json:"status"`
+	ModerationNote string       `json:"moderation_note,omitempty"`
+	ModeratedBy    *uuid.UUID   `json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time   `json:"moderated_at,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// ReviewAuthorName returns the first name of the customer, the rest of their
+// name is not shown publicly
+func ReviewAuthorName(customerName string) string {
+	fields := strings.Fields(customerName)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// PublicReview is a published review as shown on the booking page
+type PublicReview struct {
+	StaffID    uuid.UUID `json:"staff_id"`
+	ServiceID  uuid.UUID `json:"service_id"`
+	AuthorName string    `json:"author_name"`
+	Rating     int       `json:"rating"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RatingCount is the number of published reviews of a staff member with a
+// rating
+type RatingCount struct {
+	StaffID uuid.UUID
+	Rating  int
+	Count   int64
+}
+
+// RatingStats aggregates published reviews. Distribution holds the number of
+// reviews of each rating, from 1 to 5.
+type RatingStats struct {
+	Count        int64                  `json:"count"`
+	Average      float64                `json:"average"`
+	Distribution [MaxReviewRating]int64 `json:"distribution"`
+}
+
+// Add counts the reviews of a rating in the stats
+func (s *RatingStats) Add(rating int, count int64) {
+	if rating < MinReviewRating || rating > MaxReviewRating {
+		return
+	}
+	total := s.Average*float64(s.Count) + float64(rating)*float64(count)
+	s.Count += count
+	s.Distribution[rating-1] += count
+	s.Average = total / float64(s.Count)
+}
+
+// Rounded returns the stats with the average rounded to two decimals
+func (s RatingStats) Rounded() RatingStats {
+	s.Average = math.Round(s.Average*100) / 100
+	return s
+}
+
+type StaffRatingStats struct {
+	StaffID uuid.UUID `json:"staff_id"`
+	RatingStats
+}
+
+// ReviewStats are the ratings of a center and of each of its staff members
+// with published reviews
+type ReviewStats struct {
+	Center RatingStats         `json:"center"`
+	Staff  []*StaffRatingStats `json:"staff"`
+}
+
+// ReviewableAppointment is the completed appointment shown to the customer
+// opening its review link, along with their review once submitted
+type ReviewableAppointment struct {
+	AppointmentID uuid.UUID          `json:"appointment_id"`
+	CenterName    string             `json:"center_name"`
+	ServiceName   string             `json:"service_name"`
+	StaffID       uuid.UUID          `json:"staff_id"`
+	StartsAt      time.Time          `json:"starts_at"`
+	Review        *AppointmentReview `json:"review,omitempty"`
+}
+
+// ReviewLink is the link a customer reviews their appointment with
+type ReviewLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ReviewFilter struct {
+	Status  ReviewStatus
+	StaffID *uuid.UUID
+	Limit   int
+	Offset  int
+}
+
+type SubmitReviewInput struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"max=2000"`
+}
+
+type ModerateReviewInput struct {
+	Status ReviewStatus `json:"status" binding:"required"`
+	Note   string       `json:"note" binding:"max=500"`
+}
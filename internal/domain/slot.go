@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Slot is a time at which a service can be booked with a staff member
+type Slot struct {
+	StaffID  uuid.UUID `json:"staff_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// SlotQuery looks for the slots of a service starting in [From, To), with a
+// given staff member or with any of them when StaffID is nil
+type SlotQuery struct {
+	ServiceID uuid.UUID
+	StaffID   *uuid.UUID
+	From      time.Time
+	To        time.Time
+}
@@ -9,7 +9,7 @@ import (
 type Source struct {
 	ID                    uuid.UUID `json:"id"`
 	UserID                uuid.UUID `json:"user_id"`
-	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenHash      string    `json:"-"`
 	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 	UserAgent             string    `json:"user_agent"`
 	IPAddress             string    `json:"ip_address"`
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeBlockReason string
+
+const (
+	TimeBlockReasonVacation  TimeBlockReason = "vacation"
+	TimeBlockReasonSickLeave TimeBlockReason = "sick_leave"
+	TimeBlockReasonTraining  TimeBlockReason = "training"
+	TimeBlockReasonPersonal  TimeBlockReason = "personal"
+	TimeBlockReasonHoliday   TimeBlockReason = "holiday"
+	TimeBlockReasonOther     TimeBlockReason = "other"
+)
+
+func (r TimeBlockReason) IsValid() bool {
+	switch r {
+	case TimeBlockReasonVacation, TimeBlockReasonSickLeave, TimeBlockReasonTraining,
+		TimeBlockReasonPersonal, TimeBlockReasonHoliday, TimeBlockReasonOther:
+		return true
+	}
+	return false
+}
+
+// TimeBlockSource tells where a block comes from. Imported blocks mirror the
+// events of an external calendar and are replaced when it is imported again.
+type TimeBlockSource string
+
+const (
+	TimeBlockSourceManual  TimeBlockSource = "manual"
+	TimeBlockSourceICSFile TimeBlockSource = "ics_file"
+	TimeBlockSourceICSURL  TimeBlockSource = "ics_url"
+)
+
+// TimeBlock is a period during which a staff member cannot be booked. Without
+// a UserID it is a closure of the whole center, e.g. a public holiday.
+// All-day blocks start and end at midnight in the timezone of the center.
+// Imported blocks keep the UID of their event, and in ExternalKey the
+// occurrence of the event they stand for.
+type TimeBlock struct {
+	ID          uuid.UUID       `json:"id"`
+	CenterID    uuid.UUID       `json:"center_id"`
+	UserID      *uuid.UUID      `json:"user_id,omitempty"`
+	StartsAt    time.Time       `json:"starts_at"`
+	EndsAt      time.Time       `json:"ends_at"`
+	AllDay      bool            `json:"all_day"`
+	Reason      TimeBlockReason `json:"reason"`
+	Note        string          `json:"note,omitempty"`
+	Source      TimeBlockSource `json:"source"`
+	ExternalUID string          `json:"external_uid,omitempty"`
+	ExternalKey string          `json:"-"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// IsImported tells whether the block mirrors an event of an external calendar
+func (b *TimeBlock) IsImported() bool {
+	return b.Source == TimeBlockSourceICSFile || b.Source == TimeBlockSourceICSURL
+}
+
+// IsClosure tells whether the block closes the whole center
+func (b *TimeBlock) IsClosure() bool {
+	return b.UserID == nil
+}
+
+// TimeBlockInput creates or replaces a block. All-day blocks are given by
+// StartDate and EndDate, both included, EndDate defaulting to StartDate.
+// Partial-day blocks are given by StartsAt and EndsAt.
+type TimeBlockInput struct {
+	AllDay    bool            `json:"all_day"`
+	StartDate *Date           `json:"start_date"`
+	EndDate   *Date           `json:"end_date"`
+	StartsAt  *time.Time      `json:"starts_at"`
+	EndsAt    *time.Time      `json:"ends_at"`
+	Reason    TimeBlockReason `json:"reason" binding:"required"`
+	Note      string          `json:"note" binding:"max=500"`
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidTimeOfDay = errors.New("invalid time of day, expected HH:MM")
+
+// TimeOfDay is a wall clock time stored as minutes since midnight. 24:00 is
+// accepted to close a range at the end of the day.
+type TimeOfDay int
+
+const EndOfDay TimeOfDay = 24 * 60
+
+func ParseTimeOfDay(value string) (TimeOfDay, error) {
+	var hours, minutes int
+	if len(value) != 5 {
+		return 0, ErrInvalidTimeOfDay
+	}
+	if _, err := fmt.Sscanf(value, "%02d:%02d", &hours, &minutes); err != nil {
+		return 0, ErrInvalidTimeOfDay
+	}
+	if hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || (hours == 24 && minutes != 0) {
+		return 0, ErrInvalidTimeOfDay
+	}
+	return TimeOfDay(hours*60 + minutes), nil
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
+
+// Duration returns the time elapsed since midnight
+func (t TimeOfDay) Duration() time.Duration {
+	return time.Duration(t) * time.Minute
+}
+
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return ErrInvalidTimeOfDay
+	}
+	parsed, err := ParseTimeOfDay(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
@@ -7,13 +7,22 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID
-	Email     string
-	FirstName string
-	LastName  string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              uuid.UUID
+	Email           string
+	FirstName       string
+	LastName        string
+	Password        string
+	EmailVerifiedAt *time.Time
+	MFASecret       string `json:"-"`
+	MFAEnabledAt    *time.Time
+	MFALastUsedStep int64 `json:"-"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// MFAEnabled tells whether the user has a confirmed TOTP enrolment
+func (u *User) MFAEnabled() bool {
+	return u.MFAEnabledAt != nil
 }
 
 type UserRegisterInput struct {
@@ -23,6 +32,14 @@ type UserRegisterInput struct {
 	LastName  string `json:"last_name" binding:"required"`
 }
 
+type VerifyEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
 type UserLoginInput struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
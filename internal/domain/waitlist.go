@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WaitlistStatus string
+
+const (
+	// WaitlistStatusWaiting entries are offered the slots freed in their range
+	WaitlistStatusWaiting WaitlistStatus = "waiting"
+	// WaitlistStatusOffered entries have a pending offer, they wait again
+	// when it is declined or expires
+	WaitlistStatusOffered WaitlistStatus = "offered"
+	WaitlistStatusBooked  WaitlistStatus = "booked"
+)
+
+func (s WaitlistStatus) IsValid() bool {
+	switch s {
+	case WaitlistStatusWaiting, WaitlistStatusOffered, WaitlistStatusBooked:
+		return true
+	}
+	return false
+}
+
+// WaitlistEntry is a lead waiting for a slot of the service between FromDate
+// and ToDate, both included, with the staff member or with anyone when
+// StaffID is nil. Entries are offered the freed slots in the order they were
+// created.
+type WaitlistEntry struct {
+	ID        uuid.UUID      `json:"id"`
+	CenterID  uuid.UUID      `json:"center_id"`
+	LeadID    uuid.UUID      `json:"lead_id"`
+	ServiceID uuid.UUID      `json:"service_id"`
+	StaffID   *uuid.UUID     `json:"staff_id,omitempty"`
+	FromDate  Date           `json:"from_date"`
+	ToDate    Date           `json:"to_date"`
+	Status    WaitlistStatus `json:"status"`
+	Notes     string         `json:"notes,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Wants tells whether a slot of the staff member on the date is one the entry
+// waits for, the service aside
+func (e *WaitlistEntry) Wants(date Date, staffID uuid.UUID) bool {
+	if e.StaffID != nil && *e.StaffID != staffID {
+		return false
+	}
+	return !date.Before(e.FromDate) && !date.After(e.ToDate)
+}
+
+type WaitlistOfferStatus string
+
+const (
+	WaitlistOfferStatusPending  WaitlistOfferStatus = "pending"
+	WaitlistOfferStatusClaimed  WaitlistOfferStatus = "claimed"
+	WaitlistOfferStatusDeclined WaitlistOfferStatus = "declined"
+	WaitlistOfferStatusExpired  WaitlistOfferStatus = "expired"
+)
+
+// WaitlistOffer is a freed slot offered to a waitlist entry. The slot is held
+// for the lead until the offer expires, the link sent to them carries the
+// token that claims or declines it.
+type WaitlistOffer struct {
+	ID        uuid.UUID           `json:"id"`
+	CenterID  uuid.UUID           `json:"center_id"`
+	EntryID   uuid.UUID           `json:"entry_id"`
+	HoldID    uuid.UUID           `json:"-"`
+	StaffID   uuid.UUID           `json:"staff_id"`
+	ServiceID uuid.UUID           `json:"service_id"`
+	StartsAt  time.Time           `json:"starts_at"`
+	EndsAt    time.Time           `json:"ends_at"`
+	ExpiresAt time.Time           `json:"expires_at"`
+	Status    WaitlistOfferStatus `json:"status"`
+	// AppointmentID is the appointment booked when the offer is claimed
+	AppointmentID *uuid.UUID `json:"appointment_id,omitempty"`
+	TokenHash     string     `json:"-"`
+	RespondedAt   *time.Time `json:"responded_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// IsOpen tells whether the offer can still be claimed or declined
+func (o *WaitlistOffer) IsOpen(now time.Time) bool {
+	return o.Status == WaitlistOfferStatusPending && now.Before(o.ExpiresAt)
+}
+
+type WaitlistFilter struct {
+	Status    WaitlistStatus
+	ServiceID *uuid.UUID
+}
+
+// WaitlistEntryInput is the slot waited for, the dates are read in the
+// timezone of the center
+type WaitlistEntryInput struct {
+	ServiceID uuid.UUID  `json:"service_id" binding:"required"`
+	StaffID   *uuid.UUID `json:"staff_id"`
+	FromDate  *Date      `json:"from_date" binding:"required"`
+	ToDate    *Date      `json:"to_date" binding:"required"`
+	Notes     string     `json:"notes" binding:"max=2000"`
+}
+
+// CreateWaitlistEntryInput puts a lead of the center on the waitlist
+type CreateWaitlistEntryInput struct {
+	LeadID uuid.UUID `json:"lead_id" binding:"required"`
+	WaitlistEntryInput
+}
+
+// JoinWaitlistInput puts the customer on the waitlist of a center booked
+// online, the lead is matched as when confirming a booking. Offers are sent
+// by email, so the email is required, along with the consent to receive
+// them.
+type JoinWaitlistInput struct {
+	WaitlistEntryInput
+	Name     string          `json:"name" binding:"required,max=200"`
+	Email    string          `json:"email" binding:"required,email,max=254"`
+	Phone    string          `json:"phone" binding:"max=32"`
+	Consents []*ConsentInput `json:"consents" binding:"omitempty,max=10,dive"`
+}
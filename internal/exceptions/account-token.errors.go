@@ -0,0 +1,11 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrAccountTokenInvalid domain.Error = errors.New("invalid or expired token")
+)
@@ -0,0 +1,16 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrAppointmentLinkInvalid      domain.Error = errors.New("invalid appointment link")
+	ErrAppointmentLinkExpired      domain.Error = errors.New("appointment link has expired")
+	ErrAppointmentLinkRevoked      domain.Error = errors.New("appointment link was revoked")
+	ErrAppointmentLinkWrongPurpose domain.Error = errors.New("appointment link does not allow this action")
+	ErrAppointmentLinkUnavailable  domain.Error = errors.New("appointment can no longer be managed by its customer")
+	ErrCancellationWindowClosed    domain.Error = errors.New("appointment is too close to be cancelled or rescheduled online")
+)
@@ -0,0 +1,20 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrAppointmentSeriesNotFound     domain.Error = errors.New("appointment series not found")
+	ErrAppointmentSeriesInvalidRule  domain.Error = errors.New("invalid or unsupported recurrence rule")
+	ErrAppointmentSeriesEmpty        domain.Error = errors.New("appointment series has no occurrence")
+	ErrAppointmentSeriesConflict     domain.Error = errors.New("some occurrences of the series can't be booked")
+	ErrAppointmentSeriesCancelled    domain.Error = errors.New("appointment series is cancelled")
+	ErrAppointmentSeriesInvalidScope domain.Error = errors.New("scope must be this, following or all")
+	ErrAppointmentSeriesRuleScope    domain.Error = errors.New("the rule can only change for the following occurrences or the whole series")
+	ErrAppointmentOccurrenceNotFound domain.Error = errors.New("occurrence not found")
+	ErrAppointmentOccurrenceExists   domain.Error = errors.New("occurrence was edited by another request")
+	ErrAppointmentOccurrenceRange    domain.Error = errors.New("from and to are required and at most 92 days apart")
+)
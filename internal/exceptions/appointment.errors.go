@@ -0,0 +1,30 @@
+package exceptions
+
+import (
+	"errors"
+	"net/http"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrAppointmentNotFound          domain.Error = errors.New("appointment not found")
+	ErrAppointmentInvalidStatus     domain.Error = errors.New("invalid appointment status")
+	ErrAppointmentInvalidTransition domain.Error = errors.New("invalid appointment status transition")
+	ErrAppointmentStatusChanged     domain.Error = errors.New("appointment status was changed by another request")
+	ErrAppointmentNotReschedulable  domain.Error = errors.New("appointment can no longer be moved")
+	ErrAppointmentStaffOverlap      domain.Error = errors.New("staff member already has an appointment at that time")
+	ErrAppointmentResourceOverlap   domain.Error = errors.New("resource is already booked at that time")
+)
+
+// StaffDoubleBooked is the conflict returned when the staff member already has
+// an appointment at that time
+func StaffDoubleBooked() *domain.DomainError {
+	return domain.NewDomainError(http.StatusConflict, domain.ErrStaffDoubleBooked.Error(), ErrAppointmentStaffOverlap.Error(), ErrAppointmentStaffOverlap)
+}
+
+// ResourceDoubleBooked is the conflict returned when the resource is already
+// booked at that time
+func ResourceDoubleBooked() *domain.DomainError {
+	return domain.NewDomainError(http.StatusConflict, domain.ErrResourceDoubleBooked.Error(), ErrAppointmentResourceOverlap.Error(), ErrAppointmentResourceOverlap)
+}
@@ -13,4 +13,11 @@ var (
 	ErrAuthInvalidToken           domain.Error = errors.New("invalid or expired token")
 	ErrAuthSourceExpiredOrInvalid domain.Error = errors.New("session expired or invalid")
 	ErrAuthSourceNotFound         domain.Error = errors.New("source not found")
+	ErrAuthInvalidRefreshToken    domain.Error = errors.New("invalid refresh token")
+	ErrAuthRefreshTokenReused     domain.Error = errors.New("refresh token reuse detected")
+	ErrAuthInvalidResetToken      domain.Error = errors.New("invalid or expired reset token")
+	ErrAuthInvalidVerifyToken     domain.Error = errors.New("invalid or expired verification token")
+	ErrAuthEmailNotVerified       domain.Error = errors.New("email address not verified")
+	ErrAuthTooManyAttempts        domain.Error = errors.New("too many login attempts, try again later")
+	ErrAuthAccountLocked          domain.Error = errors.New("account temporarily locked, try again later or reset your password")
 )
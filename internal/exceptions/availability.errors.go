@@ -0,0 +1,13 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrAvailabilityRuleNotFound    domain.Error = errors.New("availability rule not found")
+	ErrAvailabilityInvalidInterval domain.Error = errors.New("availability must end after it starts and be effective until a date after it is effective from")
+	ErrAvailabilityOverlap         domain.Error = errors.New("availability overlaps another rule of the staff member")
+)
@@ -0,0 +1,14 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrSlotUnavailable  domain.Error = errors.New("slot is no longer available")
+	ErrSlotHoldNotFound domain.Error = errors.New("slot hold not found")
+	ErrSlotHoldExpired  domain.Error = errors.New("slot hold expired")
+	ErrSlotHoldLimit    domain.Error = errors.New("too many slots held, confirm or release one first")
+)
@@ -0,0 +1,11 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrCalendarFeedNotFound domain.Error = errors.New("calendar feed not found")
+)
@@ -0,0 +1,18 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrCenterNotFound            domain.Error = errors.New("center not found")
+	ErrCenterNotDeleted          domain.Error = errors.New("center is not deleted")
+	ErrCenterInvalidOpeningHours domain.Error = errors.New("opening hours must close after they open and must not overlap")
+	ErrCenterServiceNotFound     domain.Error = errors.New("service not found")
+	ErrCenterResourceNotFound    domain.Error = errors.New("resource not found")
+	ErrCenterInvalidSlug         domain.Error = errors.New("slug must be 3 to 63 lowercase letters, digits and hyphens")
+	ErrCenterSlugTaken           domain.Error = errors.New("slug is taken by another center")
+	ErrCenterInvalidTimezone     domain.Error = errors.New("timezone must be an IANA time zone name")
+)
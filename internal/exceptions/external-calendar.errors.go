@@ -0,0 +1,15 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrExternalCalendarNotFound    domain.Error = errors.New("external calendar not found")
+	ErrExternalCalendarInvalidURL  domain.Error = errors.New("the calendar address must be a public http, https or webcal URL")
+	ErrExternalCalendarInvalidData domain.Error = errors.New("the calendar is not valid iCalendar data")
+	ErrExternalCalendarTooLarge    domain.Error = errors.New("the calendar is too large to be imported")
+	ErrExternalCalendarUnreachable domain.Error = errors.New("the calendar could not be fetched")
+)
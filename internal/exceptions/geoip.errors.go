@@ -0,0 +1,11 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrGeoIPInvalidAddress domain.Error = errors.New("invalid ip address")
+)
@@ -0,0 +1,16 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrLeadNotFound          domain.Error = errors.New("lead not found")
+	ErrLeadDuplicate         domain.Error = errors.New("a lead with this email or phone already exists")
+	ErrLeadMissingContact    domain.Error = errors.New("a lead needs an email or a phone")
+	ErrLeadInvalidEmail      domain.Error = errors.New("invalid email")
+	ErrLeadInvalidPhone      domain.Error = errors.New("invalid phone number")
+	ErrConsentInvalidPurpose domain.Error = errors.New("consent purpose must be data_processing, communications or marketing")
+)
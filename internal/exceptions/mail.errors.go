@@ -0,0 +1,11 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrMailSendFailed domain.Error = errors.New("failed to send email")
+)
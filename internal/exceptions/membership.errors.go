@@ -0,0 +1,15 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrMembershipNotFound      domain.Error = errors.New("membership not found")
+	ErrMembershipAlreadyExists domain.Error = errors.New("user is already a member of this center")
+	ErrMembershipInvalidRole   domain.Error = errors.New("invalid center role")
+	ErrMembershipOwnerLocked   domain.Error = errors.New("the owner membership cannot be changed")
+	ErrCenterPermissionDenied  domain.Error = errors.New("insufficient permissions for this center")
+)
@@ -0,0 +1,15 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrMFAInvalidChallenge domain.Error = errors.New("invalid or expired mfa token")
+	ErrMFAInvalidCode      domain.Error = errors.New("invalid mfa code")
+	ErrMFAAlreadyEnabled   domain.Error = errors.New("mfa already enabled")
+	ErrMFANotEnabled       domain.Error = errors.New("mfa not enabled")
+	ErrMFASetupNotStarted  domain.Error = errors.New("mfa setup not started")
+)
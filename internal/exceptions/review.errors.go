@@ -0,0 +1,15 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrReviewNotFound         domain.Error = errors.New("review not found")
+	ErrReviewAlreadySubmitted domain.Error = errors.New("appointment was already reviewed")
+	ErrReviewNotReviewable    domain.Error = errors.New("only completed appointments can be reviewed, until their review link expires")
+	ErrReviewInvalidRating    domain.Error = errors.New("rating must be between 1 and 5")
+	ErrReviewInvalidStatus    domain.Error = errors.New("invalid review status")
+)
@@ -0,0 +1,12 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrSlotInvalidRange  domain.Error = errors.New("slot range must end after it starts")
+	ErrSlotRangeTooLarge domain.Error = errors.New("slot range must not exceed 31 days")
+)
@@ -0,0 +1,14 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrTimeBlockNotFound      domain.Error = errors.New("time block not found")
+	ErrTimeBlockInvalidRange  domain.Error = errors.New("time block must end after it starts, all-day blocks need a start date and others a start and end time")
+	ErrTimeBlockInvalidReason domain.Error = errors.New("invalid time block reason")
+	ErrTimeBlockImported      domain.Error = errors.New("imported time blocks change with their calendar and can't be edited")
+)
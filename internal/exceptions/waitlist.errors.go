@@ -0,0 +1,17 @@
+package exceptions
+
+import (
+	"errors"
+
+	"bifur.app/core/internal/domain"
+)
+
+var (
+	ErrWaitlistEntryNotFound   domain.Error = errors.New("waitlist entry not found")
+	ErrWaitlistInvalidRange    domain.Error = errors.New("waitlist dates must be a range of up to 90 days, not in the past")
+	ErrWaitlistInvalidStatus   domain.Error = errors.New("invalid waitlist status")
+	ErrWaitlistEmailRequired   domain.Error = errors.New("an email is required to receive waitlist offers")
+	ErrWaitlistConsentRequired domain.Error = errors.New("the consent to communications is required to receive waitlist offers")
+	ErrWaitlistOfferNotFound   domain.Error = errors.New("waitlist offer not found")
+	ErrWaitlistOfferClosed     domain.Error = errors.New("waitlist offer is no longer open")
+)
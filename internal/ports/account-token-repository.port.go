@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AccountTokenRepository interface {
+	Create(ctx context.Context, token *domain.AccountToken) error
+	// GetValid returns an unused and unexpired token without consuming it
+	GetValid(ctx context.Context, purpose domain.AccountTokenPurpose, tokenHash string) (*domain.AccountToken, error)
+	// Consume marks a valid token as used and returns it, it fails if the token
+	// does not exist, is expired or was already used
+	Consume(ctx context.Context, purpose domain.AccountTokenPurpose, tokenHash string) (*domain.AccountToken, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose domain.AccountTokenPurpose) error
+}
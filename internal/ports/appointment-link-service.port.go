@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AppointmentLinkService lets the customers of appointments manage them
+// through signed links, without an account. The links are issued by the
+// members of the center, or along with the bookings made online.
+type AppointmentLinkService interface {
+	// Issue signs the links of the appointment, they expire when it starts
+	Issue(ctx context.Context, actor *domain.CenterMembership, appointmentID uuid.UUID) (*domain.AppointmentLinks, error)
+	// Revoke stops every link issued so far for the appointment
+	Revoke(ctx context.Context, actor *domain.CenterMembership, appointmentID uuid.UUID) error
+	// Get returns the appointment of a link, whatever its purpose
+	Get(ctx context.Context, linkToken string) (*domain.ManagedAppointment, error)
+	Confirm(ctx context.Context, linkToken string) (*domain.ManagedAppointment, error)
+	// Cancel and Reschedule are refused once the cancellation window of the
+	// center has started
+	Cancel(ctx context.Context, linkToken string, input *domain.CancelAppointmentLinkInput) (*domain.ManagedAppointment, error)
+	// FindSlots returns the slots the appointment of a reschedule link can
+	// move to
+	FindSlots(ctx context.Context, linkToken string, from time.Time, to time.Time) ([]*domain.Slot, error)
+	Reschedule(ctx context.Context, linkToken string, input *domain.RescheduleAppointmentLinkInput) (*domain.ManagedAppointment, error)
+}
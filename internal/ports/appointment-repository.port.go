@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AppointmentRepository interface {
+	Create(ctx context.Context, appointment *domain.Appointment) error
+	GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Appointment, error)
+	List(ctx context.Context, centerID uuid.UUID, filter *domain.AppointmentFilter) ([]*domain.Appointment, error)
+	// Update stores every field of the appointment but its status, and bumps
+	// its link version
+	Update(ctx context.Context, appointment *domain.Appointment) error
+	// RevokeLinks bumps the link version of the appointment, the links signed
+	// so far stop working
+	RevokeLinks(ctx context.Context, appointment *domain.Appointment) error
+	// UpdateStatus stores the status of the appointment and its timestamps. It
+	// fails with ErrAppointmentStatusChanged when the stored status is no
+	// longer from.
+	UpdateStatus(ctx context.Context, appointment *domain.Appointment, from domain.AppointmentStatus) error
+	// ListBusyInRange returns the appointments that take the time of their
+	// staff member and intersect [from, to)
+	ListBusyInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.Appointment, error)
+}
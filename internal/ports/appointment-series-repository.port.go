@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AppointmentSeriesRepository interface {
+	Create(ctx context.Context, series *domain.AppointmentSeries) error
+	GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AppointmentSeries, error)
+	Update(ctx context.Context, series *domain.AppointmentSeries) error
+	// Move stores the series and moves the dates of its stored occurrences by
+	// the number of days in a single transaction
+	Move(ctx context.Context, series *domain.AppointmentSeries, days int) error
+	// Split stores the truncated series and creates the one that continues it
+	// in a single transaction. The occurrences stored from the date on move to
+	// the new series.
+	Split(ctx context.Context, series *domain.AppointmentSeries, next *domain.AppointmentSeries, from domain.Date) error
+	// ListActive returns the series not cancelled that may have occurrences
+	// intersecting [from, to)
+	ListActive(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.AppointmentSeries, error)
+	// ListOccurrences returns the appointments stored for the occurrences of
+	// the series dated between from and to, both included
+	ListOccurrences(ctx context.Context, centerID uuid.UUID, seriesIDs []uuid.UUID, from domain.Date, to domain.Date) ([]*domain.Appointment, error)
+}
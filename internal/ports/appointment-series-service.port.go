@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AppointmentSeriesService acts on behalf of a member of the center, with the
+// same permissions as AppointmentService
+type AppointmentSeriesService interface {
+	Get(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AppointmentSeries, error)
+	// Create checks the occurrences of the series against the availability of
+	// the staff member and the time already taken, see SeriesConflictError
+	Create(ctx context.Context, actor *domain.CenterMembership, input *domain.CreateAppointmentSeriesInput) (*domain.AppointmentSeries, error)
+	// ListOccurrences expands the active series of the center, edited
+	// occurrences carry their appointment
+	ListOccurrences(ctx context.Context, centerID uuid.UUID, filter *domain.OccurrenceFilter) ([]*domain.AppointmentOccurrence, error)
+	// UpdateOccurrence edits the occurrence of the date, the following ones or
+	// the whole series and returns the occurrence edited
+	UpdateOccurrence(ctx context.Context, actor *domain.CenterMembership, seriesID uuid.UUID, date domain.Date, input *domain.UpdateOccurrenceInput) (*domain.AppointmentOccurrence, error)
+	CancelOccurrence(ctx context.Context, actor *domain.CenterMembership, seriesID uuid.UUID, date domain.Date, input *domain.CancelOccurrenceInput) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AppointmentService acts on behalf of a member of the center. Members without
+// the appointments:manage permission can only manage their own appointments.
+type AppointmentService interface {
+	List(ctx context.Context, centerID uuid.UUID, filter *domain.AppointmentFilter) ([]*domain.Appointment, error)
+	Get(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Appointment, error)
+	Create(ctx context.Context, actor *domain.CenterMembership, input *domain.CreateAppointmentInput) (*domain.Appointment, error)
+	Update(ctx context.Context, actor *domain.CenterMembership, id uuid.UUID, input *domain.UpdateAppointmentInput) (*domain.Appointment, error)
+	ChangeStatus(ctx context.Context, actor *domain.CenterMembership, id uuid.UUID, input *domain.AppointmentStatusInput) (*domain.Appointment, error)
+}
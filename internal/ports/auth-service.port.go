@@ -10,9 +10,19 @@ import (
 type AuthService interface {
 	Register(ctx context.Context, registration *domain.UserRegisterInput, userAgent, ipAddress string) (*domain.RefreshTokenPayload, error)
 	Login(ctx context.Context, login *domain.UserLoginInput, userAgent, ipAddress string) (*domain.AuthenticationPayload, error)
-	RefreshToken(ctx context.Context, refreshToken string) (*domain.RefreshTokenPayload, error)
+	LoginMFA(ctx context.Context, input *domain.LoginMFAInput, userAgent, ipAddress string) (*domain.AuthenticationPayload, error)
+	RefreshToken(ctx context.Context, refreshToken, userAgent, ipAddress string) (*domain.RefreshTokenPayload, error)
 	Logout(ctx context.Context, refreshToken string) error
 	LogoutAll(ctx context.Context, userID string) error
 	ValidateToken(ctx context.Context, token string) (*domain.JWTClaims, error)
+	JWKS(ctx context.Context) domain.JWKS
 	GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, resetToken, newPassword string) error
+	VerifyEmail(ctx context.Context, verificationToken string) error
+	ResendVerification(ctx context.Context, email string) error
+	SetupTOTP(ctx context.Context, userID uuid.UUID) (*domain.TOTPSetupPayload, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) (*domain.RecoveryCodesPayload, error)
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*domain.RecoveryCodesPayload, error)
 }
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AvailabilityRepository interface {
+	Create(ctx context.Context, rule *domain.AvailabilityRule) error
+	GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AvailabilityRule, error)
+	// ListByStaff returns the rules of a staff member in a center, inactive
+	// ones included
+	ListByStaff(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) ([]*domain.AvailabilityRule, error)
+	// ListActiveByCenter returns the active rules of every current member of
+	// the center
+	ListActiveByCenter(ctx context.Context, centerID uuid.UUID) ([]*domain.AvailabilityRule, error)
+	Update(ctx context.Context, rule *domain.AvailabilityRule) error
+	Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error
+}
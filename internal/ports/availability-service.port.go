@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AvailabilityService interface {
+	List(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID) ([]*domain.AvailabilityRule, error)
+	Create(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, input *domain.AvailabilityRuleInput) (*domain.AvailabilityRule, error)
+	Update(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, ruleID uuid.UUID, input *domain.AvailabilityRuleInput) (*domain.AvailabilityRule, error)
+	Delete(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, ruleID uuid.UUID) error
+}
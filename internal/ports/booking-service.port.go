@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// BookingService lets the customers of a center book it online, without an
+// account. Centers are found by their slug, those without one can't be booked.
+type BookingService interface {
+	// GetCenter returns the profile and the active services of the center
+	GetCenter(ctx context.Context, slug string) (*domain.BookingCenter, error)
+	FindSlots(ctx context.Context, slug string, query *domain.SlotQuery) ([]*domain.Slot, error)
+	// Hold keeps the slot for the customer until the hold expires, the hold
+	// is returned along with the token that confirms or releases it
+	Hold(ctx context.Context, slug string, input *domain.HoldSlotInput, ipAddress string) (*domain.SlotHold, error)
+	Release(ctx context.Context, slug string, holdID uuid.UUID, holdToken string) error
+	// Confirm books the held slot for the lead matching the email or the
+	// phone of the customer, creating it when there is none
+	Confirm(ctx context.Context, slug string, holdID uuid.UUID, holdToken string, input *domain.ConfirmBookingInput, userAgent, ipAddress string) (*domain.Booking, error)
+}
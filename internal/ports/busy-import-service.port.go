@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// BusyImportService imports the events of a staff member's own calendar as
+// time blocks, from an uploaded file or from the URL of their external
+// calendar. Importing the same events again updates their blocks.
+type BusyImportService interface {
+	// ImportFile reads no more of the file than the import size limit
+	ImportFile(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, file io.Reader) (*domain.BusyImportResult, error)
+	GetCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID) (*domain.ExternalCalendar, error)
+	SetCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, input *domain.ExternalCalendarInput) (*domain.ExternalCalendar, error)
+	// DeleteCalendar also removes the upcoming blocks imported from it
+	DeleteCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID) error
+	SyncCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID) (*domain.BusyImportResult, error)
+}
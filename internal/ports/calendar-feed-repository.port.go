@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type CalendarFeedRepository interface {
+	// Rotate revokes the active feed of the staff member, if any, and creates
+	// the new one in a single transaction
+	Rotate(ctx context.Context, feed *domain.CalendarFeed) error
+	// Revoke fails with ErrCalendarFeedNotFound when there is no active feed
+	Revoke(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) error
+	GetActive(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CalendarFeed, error)
+	GetActiveByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error)
+}
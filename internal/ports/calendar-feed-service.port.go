@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+)
+
+// CalendarFeedService manages the iCalendar feed of the member acting and
+// serves the feeds to calendar apps, which only know the feed token
+type CalendarFeedService interface {
+	// Get returns the active feed of the member, its URL can't be recovered
+	Get(ctx context.Context, actor *domain.CenterMembership) (*domain.CalendarFeed, error)
+	// Rotate creates a new feed URL for the member, revoking the previous one
+	Rotate(ctx context.Context, actor *domain.CenterMembership) (*domain.CalendarFeedLink, error)
+	Revoke(ctx context.Context, actor *domain.CenterMembership) error
+	// Render returns the feed of the token as an iCalendar document, it fails
+	// with ErrCalendarFeedNotFound for unknown or revoked tokens
+	Render(ctx context.Context, feedToken string) ([]byte, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// CalendarFetcher downloads the iCalendar data of an external calendar. It
+// fails with ErrExternalCalendarInvalidURL for addresses it must not reach,
+// ErrExternalCalendarTooLarge and ErrExternalCalendarUnreachable.
+type CalendarFetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
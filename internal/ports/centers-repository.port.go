@@ -8,5 +8,27 @@ import (
 )
 
 type CentersRepository interface {
+	// GetAll returns the centers the user is a member of
 	GetAll(ctx context.Context, userID uuid.UUID) ([]*domain.Center, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Center, error)
+	// GetBySlug returns the center that can be booked online under the slug
+	GetBySlug(ctx context.Context, slug string) (*domain.Center, error)
+	// GetDeletedByID only returns soft deleted centers
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Center, error)
+	// Create stores the center and the membership of its owner in a single
+	// transaction
+	Create(ctx context.Context, center *domain.Center) error
+	// CreateWithSetup stores the center, the membership of its owner, its
+	// opening hours and its services in a single transaction
+	CreateWithSetup(ctx context.Context, setup *domain.CenterSetup) error
+	Update(ctx context.Context, center *domain.Center) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	ListOpeningHours(ctx context.Context, centerID uuid.UUID) ([]*domain.OpeningHours, error)
+	ListServices(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterService, error)
+	GetService(ctx context.Context, centerID uuid.UUID, serviceID uuid.UUID) (*domain.CenterService, error)
+	ListResources(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterResource, error)
+	GetResource(ctx context.Context, centerID uuid.UUID, resourceID uuid.UUID) (*domain.CenterResource, error)
+	CreateResource(ctx context.Context, resource *domain.CenterResource) error
+	UpdateResource(ctx context.Context, resource *domain.CenterResource) error
 }
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type CentersService interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Center, error)
+	Get(ctx context.Context, centerID uuid.UUID) (*domain.Center, error)
+	Create(ctx context.Context, ownerID uuid.UUID, input *domain.CreateCenterInput) (*domain.Center, error)
+	// Setup onboards a business: the center, its owner membership, opening
+	// hours and a first service are created atomically
+	Setup(ctx context.Context, ownerID uuid.UUID, input *domain.CenterSetupInput) (*domain.CenterSetup, error)
+	Update(ctx context.Context, centerID uuid.UUID, input *domain.UpdateCenterInput) (*domain.Center, error)
+	Delete(ctx context.Context, centerID uuid.UUID) error
+	// Restore undeletes a center, only its owner can restore it
+	Restore(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.Center, error)
+	ListOpeningHours(ctx context.Context, centerID uuid.UUID) ([]*domain.OpeningHours, error)
+	ListServices(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterService, error)
+	ListResources(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterResource, error)
+	CreateResource(ctx context.Context, centerID uuid.UUID, input *domain.CenterResourceInput) (*domain.CenterResource, error)
+	UpdateResource(ctx context.Context, centerID uuid.UUID, resourceID uuid.UUID, input *domain.UpdateCenterResourceInput) (*domain.CenterResource, error)
+}
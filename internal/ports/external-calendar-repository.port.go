@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type ExternalCalendarRepository interface {
+	// Save creates the external calendar of the staff member or replaces its
+	// URL, which is then waiting for its first sync
+	Save(ctx context.Context, calendar *domain.ExternalCalendar) error
+	Get(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.ExternalCalendar, error)
+	MarkSynced(ctx context.Context, id uuid.UUID, syncedAt time.Time) error
+	// Delete removes the external calendar along with its imported blocks
+	// that end after from, in a single transaction
+	Delete(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, from time.Time) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+)
+
+type GeoIPResolver interface {
+	Resolve(ctx context.Context, ipAddress string) (*domain.GeoLocation, error)
+}
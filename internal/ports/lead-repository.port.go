@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type LeadRepository interface {
+	// Create stores the lead along with its consents in a single transaction,
+	// it fails with ErrLeadDuplicate when its email or phone is taken
+	Create(ctx context.Context, lead *domain.Lead) error
+	GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Lead, error)
+	// FindByContact returns the leads of the center with the email or the
+	// phone, an empty one matches nothing
+	FindByContact(ctx context.Context, centerID uuid.UUID, email string, phone string) ([]*domain.Lead, error)
+	List(ctx context.Context, centerID uuid.UUID, filter *domain.LeadFilter) ([]*domain.Lead, error)
+	// Update fails with ErrLeadDuplicate when the new email or phone is taken
+	Update(ctx context.Context, lead *domain.Lead) error
+	// Delete erases the lead and its consents
+	Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error
+	CreateConsent(ctx context.Context, consent *domain.LeadConsent) error
+	// ListConsents returns the consents of the lead, the latest first
+	ListConsents(ctx context.Context, leadID uuid.UUID) ([]*domain.LeadConsent, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// LeadService manages the leads of a center. The leads it returns one by one
+// come with their current consents.
+type LeadService interface {
+	List(ctx context.Context, centerID uuid.UUID, filter *domain.LeadFilter) ([]*domain.Lead, error)
+	Get(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Lead, error)
+	Create(ctx context.Context, actor *domain.CenterMembership, input *domain.CreateLeadInput, userAgent, ipAddress string) (*domain.Lead, error)
+	Update(ctx context.Context, centerID uuid.UUID, id uuid.UUID, input *domain.UpdateLeadInput) (*domain.Lead, error)
+	Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error
+	ListConsents(ctx context.Context, centerID uuid.UUID, leadID uuid.UUID) ([]*domain.LeadConsent, error)
+	RecordConsent(ctx context.Context, actor *domain.CenterMembership, leadID uuid.UUID, input *domain.ConsentInput, userAgent, ipAddress string) (*domain.LeadConsent, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+)
+
+type LoginThrottleRepository interface {
+	// Get returns the throttle of a key, or nil when it has no failed attempt
+	Get(ctx context.Context, key string) (*domain.LoginThrottle, error)
+	// RecordFailure atomically counts a failed attempt, starting a new window
+	// when the current one started before windowStart
+	RecordFailure(ctx context.Context, key string, at time.Time, windowStart time.Time) (*domain.LoginThrottle, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
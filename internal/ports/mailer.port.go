@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+)
+
+type Mailer interface {
+	Send(ctx context.Context, message *domain.EmailMessage) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type MembershipRepository interface {
+	Create(ctx context.Context, membership *domain.CenterMembership) error
+	// GetByCenterAndUser fails with ErrMembershipNotFound when the user is not
+	// a member, or the center does not exist or was deleted
+	GetByCenterAndUser(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CenterMembership, error)
+	ListMembers(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterMember, error)
+	UpdateRole(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, role domain.CenterRole) error
+	Delete(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) error
+}
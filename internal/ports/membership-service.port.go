@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type MembershipService interface {
+	// Authorize returns the membership of the user when its role grants the
+	// permission on the center
+	Authorize(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, permission domain.Permission) (*domain.CenterMembership, error)
+	// AuthorizeStaff is Authorize for actions on the schedule of a staff
+	// member, members are always allowed to act on their own schedule
+	AuthorizeStaff(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, staffID uuid.UUID, permission domain.Permission) (*domain.CenterMembership, error)
+	ListMembers(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterMember, error)
+	AddMember(ctx context.Context, centerID uuid.UUID, input *domain.AddMemberInput) (*domain.CenterMember, error)
+	UpdateMemberRole(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, role domain.CenterRole) error
+	RemoveMember(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) error
+}
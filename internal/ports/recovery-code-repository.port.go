@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type RecoveryCodeRepository interface {
+	// ReplaceForUser drops every recovery code of the user and stores the new ones
+	ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []*domain.RecoveryCode) error
+	// Consume marks an unused code as used, it fails if there is no such code
+	Consume(ctx context.Context, userID uuid.UUID, codeHash string) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type ReviewRepository interface {
+	// Create fails with ErrReviewAlreadySubmitted when the appointment has a
+	// review
+	Create(ctx context.Context, review *domain.AppointmentReview) error
+	GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AppointmentReview, error)
+	// GetByAppointment fails with ErrReviewNotFound when the appointment has
+	// no review
+	GetByAppointment(ctx context.Context, centerID uuid.UUID, appointmentID uuid.UUID) (*domain.AppointmentReview, error)
+	// List returns the reviews of the center, the latest first
+	List(ctx context.Context, centerID uuid.UUID, filter *domain.ReviewFilter) ([]*domain.AppointmentReview, error)
+	// Moderate stores the status of the review and its moderation
+	Moderate(ctx context.Context, review *domain.AppointmentReview) error
+	// CountRatings returns the number of published reviews of the center by
+	// staff member and rating
+	CountRatings(ctx context.Context, centerID uuid.UUID) ([]*domain.RatingCount, error)
+}
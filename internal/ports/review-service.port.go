@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ReviewService collects the reviews of completed appointments through their
+// review links, lets the members of the center moderate them and shows the
+// published ones on the booking page
+type ReviewService interface {
+	List(ctx context.Context, centerID uuid.UUID, filter *domain.ReviewFilter) ([]*domain.AppointmentReview, error)
+	Moderate(ctx context.Context, actor *domain.CenterMembership, id uuid.UUID, input *domain.ModerateReviewInput) (*domain.AppointmentReview, error)
+	// IssueLink signs the review link of a completed appointment
+	IssueLink(ctx context.Context, actor *domain.CenterMembership, appointmentID uuid.UUID) (*domain.ReviewLink, error)
+	// RequestReview emails the review link of a completed appointment to its
+	// lead, when they consent to communications
+	RequestReview(ctx context.Context, appointment *domain.Appointment) error
+	GetReviewable(ctx context.Context, linkToken string) (*domain.ReviewableAppointment, error)
+	Submit(ctx context.Context, linkToken string, input *domain.SubmitReviewInput) (*domain.AppointmentReview, error)
+	// ListPublished and GetStats only account for the published reviews of a
+	// center booked online
+	ListPublished(ctx context.Context, slug string, filter *domain.ReviewFilter) ([]*domain.PublicReview, error)
+	GetStats(ctx context.Context, slug string) (*domain.ReviewStats, error)
+}
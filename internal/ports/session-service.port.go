@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type SessionService interface {
+	List(ctx context.Context, userID uuid.UUID, currentSourceID string) ([]*domain.Session, error)
+	Revoke(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+}
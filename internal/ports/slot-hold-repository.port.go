@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type SlotHoldRepository interface {
+	// Create deletes the expired holds of the staff member and stores the
+	// hold, it fails with ErrSlotUnavailable when an active hold of the staff
+	// member overlaps it
+	Create(ctx context.Context, hold *domain.SlotHold) error
+	// GetByID returns the hold, expired or not
+	GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.SlotHold, error)
+	// ListActiveInRange returns the holds active at now that intersect
+	// [from, to)
+	ListActiveInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time, now time.Time) ([]*domain.SlotHold, error)
+	CountActiveByIP(ctx context.Context, centerID uuid.UUID, ipAddress string, now time.Time) (int64, error)
+	Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type SlotService interface {
+	// FindSlots returns the bookable slots of the query sorted by start, slots
+	// in the past are never returned
+	FindSlots(ctx context.Context, centerID uuid.UUID, query *domain.SlotQuery) ([]*domain.Slot, error)
+}
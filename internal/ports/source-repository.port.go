@@ -10,6 +10,9 @@ type SourceRepository interface {
 	Create(ctx context.Context, source *domain.Source) error
 	GetByUserID(ctx context.Context, userID string) ([]*domain.Source, error)
 	GetByID(ctx context.Context, id string) (*domain.Source, error)
+	GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Source, error)
+	GetByRotatedRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Source, error)
+	Rotate(ctx context.Context, source *domain.Source, previousRefreshTokenHash string) error
 	Update(ctx context.Context, source *domain.Source) error
 	Delete(ctx context.Context, id string) error
 	DeleteByUserID(ctx context.Context, userID string) error
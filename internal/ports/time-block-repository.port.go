@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type TimeBlockRepository interface {
+	Create(ctx context.Context, block *domain.TimeBlock) error
+	GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.TimeBlock, error)
+	// List returns the blocks of a staff member, or the closures of the center
+	// when userID is nil, that intersect [from, to). Nil bounds are open.
+	List(ctx context.Context, centerID uuid.UUID, userID *uuid.UUID, from *time.Time, to *time.Time) ([]*domain.TimeBlock, error)
+	// ListInRange returns the closures and the blocks of every staff member
+	// that intersect [from, to)
+	ListInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.TimeBlock, error)
+	Update(ctx context.Context, block *domain.TimeBlock) error
+	Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error
+	// SyncImported replaces the imported blocks of the staff member and source
+	// that end after from with blocks, matching them by ExternalKey. With
+	// uids, only the blocks of those events are replaced and the others kept.
+	SyncImported(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, source domain.TimeBlockSource, from time.Time, uids []string, blocks []*domain.TimeBlock) (*domain.BusyImportResult, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// TimeBlockService manages the blocks of a staff member, or the closures of
+// the center when staffID is nil
+type TimeBlockService interface {
+	List(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, from *time.Time, to *time.Time) ([]*domain.TimeBlock, error)
+	Create(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, input *domain.TimeBlockInput) (*domain.TimeBlock, error)
+	Update(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, blockID uuid.UUID, input *domain.TimeBlockInput) (*domain.TimeBlock, error)
+	Delete(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, blockID uuid.UUID) error
+}
@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+type WaitlistRepository interface {
+	CreateEntry(ctx context.Context, entry *domain.WaitlistEntry) error
+	GetEntry(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.WaitlistEntry, error)
+	// ListEntries returns the entries of the filter, the oldest first
+	ListEntries(ctx context.Context, centerID uuid.UUID, filter *domain.WaitlistFilter) ([]*domain.WaitlistEntry, error)
+	// ListWaiting returns the waiting entries of the center whose range
+	// includes the date, the oldest first
+	ListWaiting(ctx context.Context, centerID uuid.UUID, date domain.Date) ([]*domain.WaitlistEntry, error)
+	// DeleteEntry deletes the entry along with its offers
+	DeleteEntry(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error
+	// CreateOffer stores the offer and marks its entry offered in a single
+	// transaction. It fails with ErrWaitlistEntryNotFound when the entry is
+	// no longer waiting, and with ErrSlotUnavailable when the entry was
+	// already offered the slot.
+	CreateOffer(ctx context.Context, offer *domain.WaitlistOffer) error
+	GetOffer(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.WaitlistOffer, error)
+	// ListSlotOffers returns the offers, answered or not, of the slot of the
+	// staff member starting at startsAt
+	ListSlotOffers(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, startsAt time.Time) ([]*domain.WaitlistOffer, error)
+	// ListExpiredOffers returns up to limit offers of any center still
+	// pending at now but expired
+	ListExpiredOffers(ctx context.Context, now time.Time, limit int) ([]*domain.WaitlistOffer, error)
+	// CloseOffer saves the answer of a pending offer and moves its entry to
+	// entryStatus in a single transaction, it fails with
+	// ErrWaitlistOfferClosed when the offer is no longer pending
+	CloseOffer(ctx context.Context, offer *domain.WaitlistOffer, entryStatus domain.WaitlistStatus) error
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/google/uuid"
+)
+
+// WaitlistService offers the slots freed by cancellations to the leads
+// waiting for them. Customers join it and answer its offers from the online
+// booking of the center, members manage it from the center.
+type WaitlistService interface {
+	List(ctx context.Context, centerID uuid.UUID, filter *domain.WaitlistFilter) ([]*domain.WaitlistEntry, error)
+	Create(ctx context.Context, centerID uuid.UUID, input *domain.CreateWaitlistEntryInput) (*domain.WaitlistEntry, error)
+	Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error
+	// Join puts the customer on the waitlist of the center, as the lead
+	// matching their email or phone
+	Join(ctx context.Context, slug string, input *domain.JoinWaitlistInput, userAgent, ipAddress string) (*domain.WaitlistEntry, error)
+	GetOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) (*domain.WaitlistOffer, error)
+	// ClaimOffer books the offered slot for the lead of the entry
+	ClaimOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) (*domain.Booking, error)
+	// DeclineOffer puts the entry back on the waitlist and offers the slot to
+	// the next one
+	DeclineOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) error
+	// OfferFreedSlot offers the start of the cancelled appointment to the
+	// first entry waiting for it, if any
+	OfferFreedSlot(ctx context.Context, appointment *domain.Appointment) error
+	// ExpireOffers passes the offers expired at now on to the next entries
+	ExpireOffers(ctx context.Context, now time.Time) error
+}
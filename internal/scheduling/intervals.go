@@ -0,0 +1,86 @@
+package scheduling
+
+import (
+	"sort"
+	"time"
+)
+
+// Interval is the half-open range of time [Start, End)
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start.Before(other.End) && other.Start.Before(i.End)
+}
+
+// Merge sorts the intervals and merges the ones that overlap or touch. Empty
+// intervals are dropped.
+func Merge(intervals []Interval) []Interval {
+	sorted := make([]Interval, 0, len(intervals))
+	for _, interval := range intervals {
+		if interval.Start.Before(interval.End) {
+			sorted = append(sorted, interval)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	merged := sorted[:0]
+	for _, interval := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && !interval.Start.After(merged[last].End) {
+			if interval.End.After(merged[last].End) {
+				merged[last].End = interval.End
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// Covers tells whether one of the merged intervals contains the interval
+func Covers(merged []Interval, interval Interval) bool {
+	i := sort.Search(len(merged), func(i int) bool { return merged[i].End.After(interval.Start) })
+	return i < len(merged) && !merged[i].Start.After(interval.Start) && !merged[i].End.Before(interval.End)
+}
+
+// OverlapsAny tells whether one of the merged intervals overlaps the interval
+func OverlapsAny(merged []Interval, interval Interval) bool {
+	i := sort.Search(len(merged), func(i int) bool { return merged[i].End.After(interval.Start) })
+	return i < len(merged) && merged[i].Overlaps(interval)
+}
+
+// Intersect returns the parts covered by both lists, which must be merged
+func Intersect(a []Interval, b []Interval) []Interval {
+	var result []Interval
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		start := maxTime(a[i].Start, b[j].Start)
+		end := minTime(a[i].End, b[j].End)
+		if start.Before(end) {
+			result = append(result, Interval{Start: start, End: end})
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
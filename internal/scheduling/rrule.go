@@ -0,0 +1,504 @@
+package scheduling
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+)
+
+var ErrInvalidRRule = errors.New("scheduling: invalid or unsupported RRULE")
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// maxEmptyPeriods stops the expansion of rules that can never occur again,
+// e.g. the 30th of February. Every valid rule occurs at least once in this
+// many periods, a daily rule for the 29th of February included.
+const maxEmptyPeriods = 3000
+
+const (
+	rruleDateLayout     = "20060102"
+	rruleDateTimeLayout = "20060102T150405Z"
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum is a BYDAY entry. N is the position of the weekday within the
+// month, or the year for a yearly rule without BYMONTH, counted from the end
+// when negative. Zero means every occurrence of the weekday.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Weekday]
+}
+
+// RRule is a recurrence rule of RFC 5545 with a daily or coarser frequency.
+// BYSETPOS, BYWEEKNO, BYYEARDAY and the parts below a day are not supported.
+// Dates are read in the location of the start of the series.
+type RRule struct {
+	Freq     Frequency
+	Interval int
+	// Count and Until are exclusive, a rule with neither repeats forever
+	Count int
+	// Until is set when UNTIL is a UTC date-time, UntilDate when it is a date
+	Until      *time.Time
+	UntilDate  *domain.Date
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+}
+
+// ParseRRule parses the value of an RRULE property, with or without the
+// RRULE: prefix
+func ParseRRule(value string) (*RRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, ErrInvalidRRule
+	}
+
+	rule := &RRule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		name, partValue, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || partValue == "" || seen[name] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRRule, part)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(partValue))
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(partValue)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(partValue)
+		case "UNTIL":
+			err = rule.parseUntil(partValue)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(partValue)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseInts(partValue, 1, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(partValue, 1, 12)
+			for _, month := range months {
+				if month < 0 {
+					err = ErrInvalidRRule
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(month))
+			}
+		case "WKST":
+			weekday, found := weekdayCodes[strings.ToUpper(partValue)]
+			if !found {
+				err = ErrInvalidRRule
+			}
+			rule.WeekStart = weekday
+		default:
+			err = ErrInvalidRRule
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRRule, part)
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *RRule) parseUntil(value string) error {
+	if until, err := time.Parse(rruleDateTimeLayout, value); err == nil {
+		r.Until = &until
+		return nil
+	}
+	until, err := time.Parse(rruleDateLayout, value)
+	if err != nil {
+		return err
+	}
+	date := domain.DateOf(until)
+	r.UntilDate = &date
+	return nil
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToUpper(item)
+		if len(item) < 2 {
+			return nil, ErrInvalidRRule
+		}
+		weekday, found := weekdayCodes[item[len(item)-2:]]
+		if !found {
+			return nil, ErrInvalidRRule
+		}
+		day := WeekdayNum{Weekday: weekday}
+		if ordinal := item[:len(item)-2]; ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, ErrInvalidRRule
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseInts parses a list of numbers between -max and max, zero excluded,
+// negative numbers count from the end. Min is the smallest positive value.
+func parseInts(value string, min int, max int) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || (n > 0 && n < min) || n > max || n < -max {
+			return nil, ErrInvalidRRule
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+func (r *RRule) validate() error {
+	switch r.Freq {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRRule, r.Freq)
+	}
+	if r.Interval < 1 || r.Count < 0 {
+		return fmt.Errorf("%w: INTERVAL and COUNT must be positive", ErrInvalidRRule)
+	}
+	if r.Count > 0 && (r.Until != nil || r.UntilDate != nil) {
+		return fmt.Errorf("%w: COUNT and UNTIL are exclusive", ErrInvalidRRule)
+	}
+	if r.Freq == FrequencyWeekly && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("%w: BYMONTHDAY is not allowed in a weekly rule", ErrInvalidRRule)
+	}
+	for _, day := range r.ByDay {
+		if day.N == 0 {
+			continue
+		}
+		switch {
+		case r.Freq == FrequencyMonthly && day.N >= -5 && day.N <= 5:
+		case r.Freq == FrequencyYearly && len(r.ByMonth) > 0 && day.N >= -5 && day.N <= 5:
+		case r.Freq == FrequencyYearly && len(r.ByMonth) == 0:
+		default:
+			return fmt.Errorf("%w: BYDAY %s is not allowed here", ErrInvalidRRule, day)
+		}
+	}
+	return nil
+}
+
+// String formats the rule as the value of an RRULE property
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(rruleDateTimeLayout))
+	}
+	if r.UntilDate != nil {
+		parts = append(parts, "UNTIL="+r.UntilDate.In(time.UTC).Format(rruleDateLayout))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, month := range r.ByMonth {
+			months[i] = strconv.Itoa(int(month))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Iterator expands a rule lazily, one occurrence at a time
+type Iterator struct {
+	rule    *RRule
+	start   time.Time
+	period  int
+	pending []domain.Date
+	emitted int
+	done    bool
+}
+
+// Iterator returns the occurrences of the rule for a series starting at
+// start. As RFC 5545 requires, the start is always the first occurrence. The
+// others keep the wall clock time of the start in its location.
+func (r *RRule) Iterator(start time.Time) *Iterator {
+	return &Iterator{rule: r, start: start}
+}
+
+// Next returns the next occurrence, false once the rule is exhausted
+func (it *Iterator) Next() (time.Time, bool) {
+	if it.done {
+		return time.Time{}, false
+	}
+
+	var date domain.Date
+	if it.emitted == 0 {
+		date = domain.DateOf(it.start)
+	} else {
+		next, ok := it.nextDate()
+		if !ok {
+			it.done = true
+			return time.Time{}, false
+		}
+		date = next
+	}
+
+	occurrence := time.Date(date.Year, date.Month, date.Day, it.start.Hour(), it.start.Minute(), it.start.Second(), 0, it.start.Location())
+	if (it.rule.Until != nil && occurrence.After(*it.rule.Until)) || (it.rule.UntilDate != nil && date.After(*it.rule.UntilDate)) {
+		it.done = true
+		return time.Time{}, false
+	}
+
+	it.emitted++
+	if it.rule.Count > 0 && it.emitted >= it.rule.Count {
+		it.done = true
+	}
+	return occurrence, true
+}
+
+// nextDate returns the next date produced by the rule after the start
+func (it *Iterator) nextDate() (domain.Date, bool) {
+	startDate := domain.DateOf(it.start)
+	for empty := 0; len(it.pending) == 0; empty++ {
+		if empty >= maxEmptyPeriods {
+			return domain.Date{}, false
+		}
+		for _, date := range it.rule.candidates(startDate, it.period) {
+			if date.After(startDate) {
+				it.pending = append(it.pending, date)
+			}
+		}
+		it.period++
+	}
+
+	date := it.pending[0]
+	it.pending = it.pending[1:]
+	return date, true
+}
+
+// candidates returns the sorted dates the rule produces in the nth period
+// after the one of the start
+func (r *RRule) candidates(start domain.Date, n int) []domain.Date {
+	var dates []domain.Date
+	switch r.Freq {
+	case FrequencyDaily:
+		date := start.AddDays(n * r.Interval)
+		if r.matchesMonth(date.Month) && r.matchesMonthDay(date) && r.matchesWeekday(date) {
+			dates = append(dates, date)
+		}
+	case FrequencyWeekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := start.AddDays(-offset + 7*n*r.Interval)
+		for i := 0; i < 7; i++ {
+			date := weekStart.AddDays(i)
+			if !r.matchesMonth(date.Month) {
+				continue
+			}
+			if (len(r.ByDay) == 0 && date.Weekday() == start.Weekday()) || (len(r.ByDay) > 0 && r.matchesWeekday(date)) {
+				dates = append(dates, date)
+			}
+		}
+	case FrequencyMonthly:
+		first := time.Date(start.Year, start.Month+time.Month(n*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		if r.matchesMonth(first.Month()) {
+			dates = r.monthDates(first.Year(), first.Month(), start.Day)
+		}
+	case FrequencyYearly:
+		year := start.Year + n*r.Interval
+		months := r.ByMonth
+		if len(months) == 0 && (len(r.ByMonthDay) > 0 || len(r.ByDay) > 0) {
+			months = []time.Month{time.January, time.February, time.March, time.April, time.May, time.June, time.July, time.August, time.September, time.October, time.November, time.December}
+		}
+		if len(months) == 0 {
+			months = []time.Month{start.Month}
+		}
+		for _, month := range months {
+			dates = append(dates, r.monthDates(year, month, start.Day)...)
+		}
+		if len(r.ByMonth) == 0 {
+			dates = r.filterYearlyOrdinals(dates, year)
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dedupDates(dates)
+}
+
+// monthDates returns the dates of a month selected by BYMONTHDAY and BYDAY,
+// or the day of the start when neither is set. Days the month does not have
+// are skipped, as RFC 5545 requires.
+func (r *RRule) monthDates(year int, month time.Month, startDay int) []domain.Date {
+	days := daysIn(year, month)
+	var dates []domain.Date
+	for day := 1; day <= days; day++ {
+		date := domain.Date{Year: year, Month: month, Day: day}
+		switch {
+		case len(r.ByMonthDay) == 0 && len(r.ByDay) == 0:
+			if day != startDay {
+				continue
+			}
+		case len(r.ByMonthDay) > 0 && !r.matchesMonthDay(date):
+			continue
+		case len(r.ByDay) > 0 && !r.matchesMonthWeekday(date, days, len(r.ByMonth) > 0 || r.Freq == FrequencyMonthly):
+			continue
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+func (r *RRule) matchesMonth(month time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesMonthDay(date domain.Date) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	days := daysIn(date.Year, date.Month)
+	for _, day := range r.ByMonthDay {
+		if day == date.Day || (day < 0 && days+day+1 == date.Day) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesWeekday(date domain.Date) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthWeekday matches BYDAY within a month. Ordinals are only read
+// within the month when withOrdinals is set, yearly ones are checked by
+// filterYearlyOrdinals.
+func (r *RRule) matchesMonthWeekday(date domain.Date, days int, withOrdinals bool) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday != date.Weekday() {
+			continue
+		}
+		if day.N == 0 || !withOrdinals {
+			return true
+		}
+		if day.N > 0 && (date.Day-1)/7+1 == day.N {
+			return true
+		}
+		if day.N < 0 && (days-date.Day)/7+1 == -day.N {
+			return true
+		}
+	}
+	return false
+}
+
+// filterYearlyOrdinals keeps the dates selected by the ordinals of BYDAY
+// within the year, e.g. 20MO is the 20th Monday of the year
+func (r *RRule) filterYearlyOrdinals(dates []domain.Date, year int) []domain.Date {
+	hasOrdinals := false
+	for _, day := range r.ByDay {
+		hasOrdinals = hasOrdinals || day.N != 0
+	}
+	if !hasOrdinals {
+		return dates
+	}
+
+	daysInYear := 365
+	if daysIn(year, time.February) == 29 {
+		daysInYear = 366
+	}
+	filtered := dates[:0]
+	for _, date := range dates {
+		yearDay := date.In(time.UTC).YearDay()
+		for _, day := range r.ByDay {
+			if day.Weekday != date.Weekday() {
+				continue
+			}
+			if day.N == 0 || (day.N > 0 && (yearDay-1)/7+1 == day.N) || (day.N < 0 && (daysInYear-yearDay)/7+1 == -day.N) {
+				filtered = append(filtered, date)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func dedupDates(dates []domain.Date) []domain.Date {
+	unique := dates[:0]
+	for i, date := range dates {
+		if i == 0 || date != dates[i-1] {
+			unique = append(unique, date)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,159 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expand returns the dates of the first occurrences of the rule
+func expand(t *testing.T, value string, start time.Time, limit int) []string {
+	rule, err := ParseRRule(value)
+	require.NoError(t, err)
+
+	var dates []string
+	it := rule.Iterator(start)
+	for len(dates) < limit {
+		occurrence, ok := it.Next()
+		if !ok {
+			break
+		}
+		dates = append(dates, occurrence.Format("2006-01-02"))
+	}
+	return dates
+}
+
+func TestRRuleExpansion(t *testing.T) {
+	// Monday 2025-05-05 10:00 in Madrid
+	start := time.Date(2025, 5, 5, 10, 0, 0, 0, madrid)
+
+	tests := []struct {
+		name     string
+		rule     string
+		start    time.Time
+		expected []string
+	}{
+		{
+			name:     "daily with count",
+			rule:     "FREQ=DAILY;COUNT=3",
+			expected: []string{"2025-05-05", "2025-05-06", "2025-05-07"},
+		},
+		{
+			name:     "weekly",
+			rule:     "RRULE:FREQ=WEEKLY;COUNT=4",
+			expected: []string{"2025-05-05", "2025-05-12", "2025-05-19", "2025-05-26"},
+		},
+		{
+			name:     "every other week on several days",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=4",
+			expected: []string{"2025-05-05", "2025-05-08", "2025-05-19", "2025-05-22"},
+		},
+		{
+			name:     "weekly until a date",
+			rule:     "FREQ=WEEKLY;UNTIL=20250519",
+			expected: []string{"2025-05-05", "2025-05-12", "2025-05-19"},
+		},
+		{
+			name:     "weekly until a date-time before the time of the last day",
+			rule:     "FREQ=WEEKLY;UNTIL=20250519T070000Z",
+			expected: []string{"2025-05-05", "2025-05-12"},
+		},
+		{
+			name:     "monthly skips the months without the day",
+			rule:     "FREQ=MONTHLY;COUNT=4",
+			start:    time.Date(2025, 1, 31, 10, 0, 0, 0, madrid),
+			expected: []string{"2025-01-31", "2025-03-31", "2025-05-31", "2025-07-31"},
+		},
+		{
+			name:     "monthly on the last day",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=4",
+			start:    time.Date(2025, 1, 31, 10, 0, 0, 0, madrid),
+			expected: []string{"2025-01-31", "2025-02-28", "2025-03-31", "2025-04-30"},
+		},
+		{
+			name:     "monthly on the first monday",
+			rule:     "FREQ=MONTHLY;BYDAY=1MO;COUNT=4",
+			expected: []string{"2025-05-05", "2025-06-02", "2025-07-07", "2025-08-04"},
+		},
+		{
+			name:     "monthly on the last friday",
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR;COUNT=4",
+			expected: []string{"2025-05-05", "2025-05-30", "2025-06-27", "2025-07-25"},
+		},
+		{
+			name:     "yearly on a leap day",
+			rule:     "FREQ=YEARLY;COUNT=3",
+			start:    time.Date(2024, 2, 29, 10, 0, 0, 0, madrid),
+			expected: []string{"2024-02-29", "2028-02-29", "2032-02-29"},
+		},
+		{
+			name:     "yearly on the fourth thursday of november",
+			rule:     "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=4",
+			start:    time.Date(2025, 11, 27, 10, 0, 0, 0, madrid),
+			expected: []string{"2025-11-27", "2026-11-26", "2027-11-25", "2028-11-23"},
+		},
+		{
+			name:     "never occurring again",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=30;BYMONTH=2",
+			expected: []string{"2025-05-05"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleStart := tt.start
+			if ruleStart.IsZero() {
+				ruleStart = start
+			}
+			assert.Equal(t, tt.expected, expand(t, tt.rule, ruleStart, len(tt.expected)+1))
+		})
+	}
+}
+
+func TestRRuleKeepsWallClockAcrossDST(t *testing.T) {
+	// Spain moves to summer time on 2025-03-30
+	rule, err := ParseRRule("FREQ=WEEKLY;COUNT=2")
+	require.NoError(t, err)
+	it := rule.Iterator(time.Date(2025, 3, 24, 10, 0, 0, 0, madrid))
+
+	first, _ := it.Next()
+	second, _ := it.Next()
+
+	assert.Equal(t, "09:00", first.UTC().Format("15:04"))
+	assert.Equal(t, "08:00", second.UTC().Format("15:04"))
+	assert.Equal(t, "10:00", second.Format("15:04"))
+}
+
+func TestParseRRule(t *testing.T) {
+	valid := []string{
+		"FREQ=WEEKLY;BYDAY=MO,WE,FR;WKST=SU",
+		"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1,15,-1",
+		"FREQ=YEARLY;BYDAY=20MO",
+		"FREQ=DAILY;UNTIL=20251231T230000Z",
+	}
+	for _, value := range valid {
+		rule, err := ParseRRule(value)
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, value, rule.String())
+		}
+	}
+
+	invalid := []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=WEEKLY;COUNT=2;UNTIL=20251231",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=3",
+		"FREQ=MONTHLY;BYSETPOS=-1;BYDAY=MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"INTERVAL=2",
+	}
+	for _, value := range invalid {
+		_, err := ParseRRule(value)
+		assert.ErrorIs(t, err, ErrInvalidRRule, value)
+	}
+}
@@ -0,0 +1,118 @@
+// Package scheduling computes the bookable slots of a staff member. It is pure:
+// callers load the rules and the busy time and the package does no I/O.
+package scheduling
+
+import (
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/domain"
+)
+
+var ErrInvalidRequest = errors.New("scheduling: duration and granularity must be positive and the range must not be inverted")
+
+// Request describes the slots to compute for a single staff member
+type Request struct {
+	// Location is the timezone of the center, weekly rules and opening hours
+	// are wall clock times in it
+	Location *time.Location
+	// From and To bound the start of the slots, [From, To)
+	From time.Time
+	To   time.Time
+	// Duration is the length of the booked service
+	Duration time.Duration
+	// BufferBefore and BufferAfter must be free around a slot, they may fall
+	// outside the availability of the staff member
+	BufferBefore time.Duration
+	BufferAfter  time.Duration
+	// Granularity is the step between two slot starts, counted from the start
+	// of each availability window
+	Granularity time.Duration
+	Rules       []*domain.AvailabilityRule
+	// OpeningHours, when set, restrict the availability to the hours the
+	// center is open
+	OpeningHours []*domain.OpeningHours
+	// Busy is the time already taken: time blocks, closures and appointments
+	Busy []Interval
+}
+
+// Slots returns the bookable slots of the request sorted by start
+func Slots(req *Request) ([]Interval, error) {
+	if req.Duration <= 0 || req.Granularity <= 0 || req.To.Before(req.From) {
+		return nil, ErrInvalidRequest
+	}
+
+	windows := Windows(req.Rules, req.OpeningHours, req.From, req.To.Add(req.Duration), req.Location)
+	busy := Merge(req.Busy)
+
+	var slots []Interval
+	next := 0
+	for _, window := range windows {
+		for start := window.Start; !start.Add(req.Duration).After(window.End); start = start.Add(req.Granularity) {
+			if start.Before(req.From) {
+				continue
+			}
+			if !start.Before(req.To) {
+				return slots, nil
+			}
+
+			slot := Interval{Start: start, End: start.Add(req.Duration)}
+			guarded := Interval{Start: slot.Start.Add(-req.BufferBefore), End: slot.End.Add(req.BufferAfter)}
+			// Busy intervals are merged and slots come in order, the ones
+			// ending before this slot cannot collide with the next ones
+			for next < len(busy) && !busy[next].End.After(guarded.Start) {
+				next++
+			}
+			if next < len(busy) && busy[next].Overlaps(guarded) {
+				continue
+			}
+			slots = append(slots, slot)
+		}
+	}
+	return slots, nil
+}
+
+// Windows returns the merged intervals during which the rules, restricted to
+// the opening hours when there are any, make a staff member available on the
+// days between from and to. Windows are not cut at from and to, so slots stay
+// aligned on the start of the window whatever the range asked for.
+func Windows(rules []*domain.AvailabilityRule, openingHours []*domain.OpeningHours, from time.Time, to time.Time, loc *time.Location) []Interval {
+	first := domain.DateOf(from.In(loc))
+	last := domain.DateOf(to.In(loc))
+
+	var available, open []Interval
+	for date := first; !date.After(last); date = date.AddDays(1) {
+		for _, rule := range rules {
+			if rule.AppliesOn(date) {
+				available = append(available, wallClock(date, rule.StartTime, rule.EndTime, loc))
+			}
+		}
+		for _, hours := range openingHours {
+			if hours.Weekday == date.Weekday() {
+				open = append(open, wallClock(date, hours.OpensAt, hours.ClosesAt, loc))
+			}
+		}
+	}
+
+	windows := Merge(available)
+	if len(openingHours) > 0 {
+		windows = Intersect(windows, Merge(open))
+	}
+	return windows
+}
+
+// wallClock resolves a wall clock range of a date. Across a DST change the
+// range lasts the real time elapsed between both wall clock times. A time
+// skipped by the change is moved forward by the length of the gap, and a time
+// repeated by it resolves to its second occurrence.
+func wallClock(date domain.Date, start domain.TimeOfDay, end domain.TimeOfDay, loc *time.Location) Interval {
+	midnight := date.In(time.UTC)
+	return Interval{
+		Start: at(midnight, start, loc),
+		End:   at(midnight, end, loc),
+	}
+}
+
+func at(midnight time.Time, t domain.TimeOfDay, loc *time.Location) time.Time {
+	return time.Date(midnight.Year(), midnight.Month(), midnight.Day(), int(t)/60, int(t)%60, 0, 0, loc)
+}
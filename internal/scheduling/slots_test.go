@@ -0,0 +1,254 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var madrid, _ = time.LoadLocation("Europe/Madrid")
+
+func clock(value string) domain.TimeOfDay {
+	t, err := domain.ParseTimeOfDay(value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func rule(weekday time.Weekday, start, end string) *domain.AvailabilityRule {
+	return &domain.AvailabilityRule{Weekday: weekday, StartTime: clock(start), EndTime: clock(end), IsActive: true}
+}
+
+func opening(weekday time.Weekday, opens, closes string) *domain.OpeningHours {
+	return &domain.OpeningHours{Weekday: weekday, OpensAt: clock(opens), ClosesAt: clock(closes)}
+}
+
+// local returns a wall clock time of Monday 2025-05-05 in Madrid
+func local(value string) time.Time {
+	t := clock(value)
+	return time.Date(2025, 5, 5, int(t)/60, int(t)%60, 0, 0, madrid)
+}
+
+func utc(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestSlots(t *testing.T) {
+	effectiveFrom, _ := domain.ParseDate("2025-05-06")
+	futureRule := rule(time.Monday, "09:00", "11:00")
+	futureRule.EffectiveFrom = &effectiveFrom
+
+	tests := []struct {
+		name     string
+		req      Request
+		expected []time.Time
+	}{
+		{
+			name:     "whole window",
+			req:      Request{Duration: 30 * time.Minute, Granularity: 30 * time.Minute, Rules: []*domain.AvailabilityRule{rule(time.Monday, "09:00", "11:00")}},
+			expected: []time.Time{local("09:00"), local("09:30"), local("10:00"), local("10:30")},
+		},
+		{
+			name:     "granularity shorter than duration",
+			req:      Request{Duration: 30 * time.Minute, Granularity: 15 * time.Minute, Rules: []*domain.AvailabilityRule{rule(time.Monday, "09:00", "10:00")}},
+			expected: []time.Time{local("09:00"), local("09:15"), local("09:30")},
+		},
+		{
+			name: "busy time",
+			req: Request{Duration: 30 * time.Minute, Granularity: 30 * time.Minute, Rules: []*domain.AvailabilityRule{rule(time.Monday, "09:00", "11:00")},
+				Busy: []Interval{{Start: local("09:30"), End: local("10:15")}}},
+			expected: []time.Time{local("09:00"), local("10:30")},
+		},
+		{
+			name: "overlapping busy time",
+			req: Request{Duration: 30 * time.Minute, Granularity: 30 * time.Minute, Rules: []*domain.AvailabilityRule{rule(time.Monday, "09:00", "11:00")},
+				Busy: []Interval{{Start: local("09:30"), End: local("10:00")}, {Start: local("08:00"), End: local("10:30")}}},
+			expected: []time.Time{local("10:30")},
+		},
+		{
+			name: "buffers",
+			req: Request{Duration: 30 * time.Minute, Granularity: 30 * time.Minute, BufferBefore: 15 * time.Minute, BufferAfter: 15 * time.Minute,
+				Rules: []*domain.AvailabilityRule{rule(time.Monday, "09:00", "11:00")},
+				Busy:  []Interval{{Start: local("10:00"), End: local("10:30")}}},
+			expected: []time.Time{local("09:00")},
+		},
+		{
+			name:     "adjacent rules",
+			req:      Request{Duration: time.Hour, Granularity: 30 * time.Minute, Rules: []*domain.AvailabilityRule{rule(time.Monday, "10:00", "11:00"), rule(time.Monday, "09:00", "10:00")}},
+			expected: []time.Time{local("09:00"), local("09:30"), local("10:00")},
+		},
+		{
+			name: "split day",
+			req: Request{Duration: time.Hour, Granularity: time.Hour,
+				Rules: []*domain.AvailabilityRule{rule(time.Monday, "09:00", "11:00"), rule(time.Monday, "15:00", "16:30")}},
+			expected: []time.Time{local("09:00"), local("10:00"), local("15:00")},
+		},
+		{
+			name: "opening hours",
+			req: Request{Duration: 30 * time.Minute, Granularity: 30 * time.Minute, Rules: []*domain.AvailabilityRule{rule(time.Monday, "08:00", "20:00")},
+				OpeningHours: []*domain.OpeningHours{opening(time.Monday, "09:00", "10:00")}},
+			expected: []time.Time{local("09:00"), local("09:30")},
+		},
+		{
+			name: "center closed",
+			req: Request{Duration: 30 * time.Minute, Granularity: 30 * time.Minute, Rules: []*domain.AvailabilityRule{rule(time.Monday, "08:00", "20:00")},
+				OpeningHours: []*domain.OpeningHours{opening(time.Tuesday, "09:00", "10:00")}},
+			expected: nil,
+		},
+		{
+			name:     "rule not yet effective",
+			req:      Request{Duration: 30 * time.Minute, Granularity: 30 * time.Minute, Rules: []*domain.AvailabilityRule{futureRule}},
+			expected: nil,
+		},
+		{
+			name: "range inside a window",
+			req: Request{From: local("09:45"), To: local("10:30"), Duration: 30 * time.Minute, Granularity: 30 * time.Minute,
+				Rules: []*domain.AvailabilityRule{rule(time.Monday, "09:00", "11:00")}},
+			expected: []time.Time{local("10:00")},
+		},
+		{
+			name: "clocks go forward",
+			req: Request{From: utc("2025-03-29T23:00:00Z"), To: utc("2025-03-30T22:00:00Z"), Duration: time.Hour, Granularity: time.Hour,
+				Rules: []*domain.AvailabilityRule{rule(time.Sunday, "01:00", "04:00")}},
+			// 01:00 CET then 03:00 CEST, 02:00 does not exist
+			expected: []time.Time{utc("2025-03-30T00:00:00Z"), utc("2025-03-30T01:00:00Z")},
+		},
+		{
+			name: "clocks go back",
+			req: Request{From: utc("2025-10-25T22:00:00Z"), To: utc("2025-10-26T23:00:00Z"), Duration: time.Hour, Granularity: time.Hour,
+				Rules: []*domain.AvailabilityRule{rule(time.Sunday, "01:00", "04:00")}},
+			// 02:00 happens twice
+			expected: []time.Time{utc("2025-10-25T23:00:00Z"), utc("2025-10-26T00:00:00Z"), utc("2025-10-26T01:00:00Z"), utc("2025-10-26T02:00:00Z")},
+		},
+		{
+			name: "day of the center is not the day in UTC",
+			req: Request{Location: time.FixedZone("UTC+10", 10*60*60), From: utc("2025-05-04T12:00:00Z"), To: utc("2025-05-05T12:00:00Z"),
+				Duration: time.Hour, Granularity: time.Hour, Rules: []*domain.AvailabilityRule{rule(time.Monday, "08:00", "09:00")}},
+			expected: []time.Time{utc("2025-05-04T22:00:00Z")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if req.Location == nil {
+				req.Location = madrid
+			}
+			if req.From.IsZero() {
+				req.From = local("00:00")
+				req.To = local("24:00")
+			}
+
+			slots, err := Slots(&req)
+
+			require.NoError(t, err)
+			starts := make([]time.Time, len(slots))
+			for i, slot := range slots {
+				starts[i] = slot.Start
+				assert.Equal(t, req.Duration, slot.End.Sub(slot.Start))
+			}
+			require.Len(t, starts, len(tt.expected))
+			for i := range tt.expected {
+				assert.True(t, tt.expected[i].Equal(starts[i]), "slot %d: expected %s, got %s", i, tt.expected[i], starts[i])
+			}
+		})
+	}
+}
+
+func TestSlots_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+	}{
+		{"no duration", Request{Location: madrid, From: local("00:00"), To: local("24:00"), Granularity: time.Minute}},
+		{"no granularity", Request{Location: madrid, From: local("00:00"), To: local("24:00"), Duration: time.Minute}},
+		{"inverted range", Request{Location: madrid, From: local("24:00"), To: local("00:00"), Duration: time.Minute, Granularity: time.Minute}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Slots(&tt.req)
+
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge([]Interval{
+		{Start: local("12:00"), End: local("13:00")},
+		{Start: local("09:00"), End: local("10:00")},
+		{Start: local("10:00"), End: local("11:00")},
+		{Start: local("09:30"), End: local("09:45")},
+		{Start: local("15:00"), End: local("15:00")},
+	})
+
+	assert.Equal(t, []Interval{
+		{Start: local("09:00"), End: local("11:00")},
+		{Start: local("12:00"), End: local("13:00")},
+	}, merged)
+}
+
+// benchmarkRequest is a month of a staff member working two shifts six days a
+// week, with a few appointments every day
+func benchmarkRequest() *Request {
+	var rules []*domain.AvailabilityRule
+	var openingHours []*domain.OpeningHours
+	for weekday := time.Monday; weekday <= time.Saturday; weekday++ {
+		rules = append(rules, rule(weekday, "09:00", "13:00"), rule(weekday, "15:00", "20:00"))
+		openingHours = append(openingHours, opening(weekday, "08:00", "21:00"))
+	}
+
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, madrid)
+	to := from.AddDate(0, 1, 0)
+	var busy []Interval
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, hour := range []int{9, 11, 16, 18} {
+			start := time.Date(day.Year(), day.Month(), day.Day(), hour, 30, 0, 0, madrid)
+			busy = append(busy, Interval{Start: start, End: start.Add(45 * time.Minute)})
+		}
+	}
+
+	return &Request{
+		Location:     madrid,
+		From:         from,
+		To:           to,
+		Duration:     30 * time.Minute,
+		BufferAfter:  5 * time.Minute,
+		Granularity:  15 * time.Minute,
+		Rules:        rules,
+		OpeningHours: openingHours,
+		Busy:         busy,
+	}
+}
+
+func BenchmarkSlots(b *testing.B) {
+	req := benchmarkRequest()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Slots(req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSlotsWithoutOpeningHours(b *testing.B) {
+	req := benchmarkRequest()
+	req.OpeningHours = nil
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Slots(req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/utils/token"
+	"github.com/google/uuid"
+)
+
+// appointmentLinkKind keeps the signed links of appointments apart from any
+// other value signed with the same secret
+const appointmentLinkKind = "appointment"
+
+// appointmentLink is what a self-service link signs. The version must match
+// the one of the appointment, so the links stop working once it changes.
+type appointmentLink struct {
+	purpose       domain.AppointmentLinkPurpose
+	centerID      uuid.UUID
+	appointmentID uuid.UUID
+	version       int
+	expiresAt     time.Time
+}
+
+// appointmentLinkSigner signs the links of appointments with the link
+// secret, it is shared with the services that book appointments
+type appointmentLinkSigner struct {
+	appURL string
+	secret []byte
+}
+
+func (s *appointmentLinkSigner) sign(link *appointmentLink) string {
+	payload := strings.Join([]string{
+		appointmentLinkKind,
+		string(link.purpose),
+		link.centerID.String(),
+		link.appointmentID.String(),
+		strconv.Itoa(link.version),
+		strconv.FormatInt(link.expiresAt.Unix(), 10),
+	}, ":")
+	return token.Sign(payload, s.secret)
+}
+
+// verify returns the link signed in the token, it does not check that the
+// link is still valid
+func (s *appointmentLinkSigner) verify(linkToken string) (*appointmentLink, error) {
+	payload, err := token.Verify(linkToken, s.secret)
+	if err != nil {
+		return nil, exceptions.ErrAppointmentLinkInvalid
+	}
+	fields := strings.Split(payload, ":")
+	if len(fields) != 6 || fields[0] != appointmentLinkKind {
+		return nil, exceptions.ErrAppointmentLinkInvalid
+	}
+
+	link := &appointmentLink{purpose: domain.AppointmentLinkPurpose(fields[1])}
+	if !link.purpose.IsValid() {
+		return nil, exceptions.ErrAppointmentLinkInvalid
+	}
+	link.centerID, err = uuid.Parse(fields[2])
+	if err != nil {
+		return nil, exceptions.ErrAppointmentLinkInvalid
+	}
+	link.appointmentID, err = uuid.Parse(fields[3])
+	if err != nil {
+		return nil, exceptions.ErrAppointmentLinkInvalid
+	}
+	link.version, err = strconv.Atoi(fields[4])
+	if err != nil {
+		return nil, exceptions.ErrAppointmentLinkInvalid
+	}
+	expiresAt, err := strconv.ParseInt(fields[5], 10, 64)
+	if err != nil {
+		return nil, exceptions.ErrAppointmentLinkInvalid
+	}
+	link.expiresAt = time.Unix(expiresAt, 0)
+	return link, nil
+}
+
+// url signs a link of the appointment for the purpose, and returns the page
+// that opens it
+func (s *appointmentLinkSigner) url(appointment *domain.Appointment, purpose domain.AppointmentLinkPurpose, expiresAt time.Time) string {
+	linkToken := s.sign(&appointmentLink{
+		purpose:       purpose,
+		centerID:      appointment.CenterID,
+		appointmentID: appointment.ID,
+		version:       appointment.LinkVersion,
+		expiresAt:     expiresAt,
+	})
+	return fmt.Sprintf("%s/appointments/%s?token=%s", s.appURL, purpose, url.QueryEscape(linkToken))
+}
+
+// links signs a link of each purpose for the appointment, expiring when it
+// starts
+func (s *appointmentLinkSigner) links(appointment *domain.Appointment) *domain.AppointmentLinks {
+	return &domain.AppointmentLinks{
+		ConfirmURL:    s.url(appointment, domain.AppointmentLinkPurposeConfirm, appointment.StartsAt),
+		CancelURL:     s.url(appointment, domain.AppointmentLinkPurposeCancel, appointment.StartsAt),
+		RescheduleURL: s.url(appointment, domain.AppointmentLinkPurposeReschedule, appointment.StartsAt),
+		ExpiresAt:     appointment.StartsAt,
+	}
+}
+
+// reviewLink signs the review link of a completed appointment, it expires
+// the duration after the appointment was completed
+func (s *appointmentLinkSigner) reviewLink(appointment *domain.Appointment, duration time.Duration) *domain.ReviewLink {
+	expiresAt := appointment.CompletedAt.Add(duration)
+	return &domain.ReviewLink{
+		URL:       s.url(appointment, domain.AppointmentLinkPurposeReview, expiresAt),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// isManageable tells whether the customer of the appointment can still act
+// on it through their links
+func isManageable(appointment *domain.Appointment, now time.Time) bool {
+	return (appointment.Status == domain.AppointmentStatusRequested || appointment.Status == domain.AppointmentStatusConfirmed) &&
+		now.Before(appointment.StartsAt)
+}
+
+type AppointmentLinkServiceImplementation struct {
+	appointmentRepo ports.AppointmentRepository
+	centersRepo     ports.CentersRepository
+	slotService     ports.SlotService
+	waitlistService ports.WaitlistService
+	scheduler       *appointmentScheduler
+	signer          *appointmentLinkSigner
+	logger          ports.Logger
+}
+
+func NewAppointmentLinkService(appointmentRepo ports.AppointmentRepository, seriesRepo ports.AppointmentSeriesRepository, holdRepo ports.SlotHoldRepository, centersRepo ports.CentersRepository, membershipRepo ports.MembershipRepository, slotService ports.SlotService, waitlistService ports.WaitlistService, appURL string, secret []byte, logger ports.Logger) ports.AppointmentLinkService {
+	return &AppointmentLinkServiceImplementation{
+		appointmentRepo: appointmentRepo,
+		centersRepo:     centersRepo,
+		slotService:     slotService,
+		waitlistService: waitlistService,
+		scheduler: &appointmentScheduler{
+			centersRepo:    centersRepo,
+			membershipRepo: membershipRepo,
+			seriesRepo:     seriesRepo,
+			holdRepo:       holdRepo,
+		},
+		signer: &appointmentLinkSigner{appURL: appURL, secret: secret},
+		logger: logger,
+	}
+}
+
+func (s *AppointmentLinkServiceImplementation) Issue(ctx context.Context, actor *domain.CenterMembership, appointmentID uuid.UUID) (*domain.AppointmentLinks, error) {
+	appointment, err := s.appointmentRepo.GetByID(ctx, actor.CenterID, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	err = authorizeAppointment(actor, appointment.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	if !isManageable(appointment, time.Now()) {
+		return nil, exceptions.ErrAppointmentLinkUnavailable
+	}
+	return s.signer.links(appointment), nil
+}
+
+func (s *AppointmentLinkServiceImplementation) Revoke(ctx context.Context, actor *domain.CenterMembership, appointmentID uuid.UUID) error {
+	appointment, err := s.appointmentRepo.GetByID(ctx, actor.CenterID, appointmentID)
+	if err != nil {
+		return err
+	}
+	err = authorizeAppointment(actor, appointment.StaffID)
+	if err != nil {
+		return err
+	}
+	return s.appointmentRepo.RevokeLinks(ctx, appointment)
+}
+
+func (s *AppointmentLinkServiceImplementation) Get(ctx context.Context, linkToken string) (*domain.ManagedAppointment, error) {
+	link, center, appointment, err := s.resolve(ctx, linkToken, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.managed(ctx, link, center, appointment)
+}
+
+// Confirm leaves a confirmed appointment as it is, so the link can be opened
+// more than once
+func (s *AppointmentLinkServiceImplementation) Confirm(ctx context.Context, linkToken string) (*domain.ManagedAppointment, error) {
+	link, center, appointment, err := s.resolve(ctx, linkToken, domain.AppointmentLinkPurposeConfirm)
+	if err != nil {
+		return nil, err
+	}
+
+	if appointment.Status != domain.AppointmentStatusConfirmed {
+		from := appointment.Status
+		if !from.CanTransitionTo(domain.AppointmentStatusConfirmed) {
+			return nil, &domain.AppointmentTransitionError{Err: exceptions.ErrAppointmentInvalidTransition, From: from, To: domain.AppointmentStatusConfirmed}
+		}
+		appointment.SetStatus(domain.AppointmentStatusConfirmed, time.Now())
+		err = s.appointmentRepo.UpdateStatus(ctx, appointment, from)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.managed(ctx, link, center, appointment)
+}
+
+func (s *AppointmentLinkServiceImplementation) Cancel(ctx context.Context, linkToken string, input *domain.CancelAppointmentLinkInput) (*domain.ManagedAppointment, error) {
+	link, center, appointment, err := s.resolve(ctx, linkToken, domain.AppointmentLinkPurposeCancel)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !now.Before(changeableUntil(center, appointment)) {
+		return nil, exceptions.ErrCancellationWindowClosed
+	}
+
+	from := appointment.Status
+	if !from.CanTransitionTo(domain.AppointmentStatusCancelled) {
+		return nil, &domain.AppointmentTransitionError{Err: exceptions.ErrAppointmentInvalidTransition, From: from, To: domain.AppointmentStatusCancelled}
+	}
+	appointment.SetStatus(domain.AppointmentStatusCancelled, now)
+	appointment.CancellationReason = input.Reason
+	err = s.appointmentRepo.UpdateStatus(ctx, appointment, from)
+	if err != nil {
+		return nil, err
+	}
+
+	offerFreedSlot(ctx, s.waitlistService, s.logger, appointment)
+	return s.managed(ctx, link, center, appointment)
+}
+
+// FindSlots leaves out the slots starting in the cancellation window, the
+// appointment couldn't be moved out of them afterwards
+func (s *AppointmentLinkServiceImplementation) FindSlots(ctx context.Context, linkToken string, from time.Time, to time.Time) ([]*domain.Slot, error) {
+	_, center, appointment, err := s.resolve(ctx, linkToken, domain.AppointmentLinkPurposeReschedule)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := s.slotService.FindSlots(ctx, center.ID, &domain.SlotQuery{
+		ServiceID: appointment.ServiceID,
+		StaffID:   &appointment.StaffID,
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	earliest := time.Now().Add(center.CancellationWindow())
+	available := make([]*domain.Slot, 0, len(slots))
+	for _, slot := range slots {
+		if !slot.StartsAt.Before(earliest) {
+			available = append(available, slot)
+		}
+	}
+	return available, nil
+}
+
+// Reschedule only moves the appointment to a slot that FindSlots returns, the
+// links of the moved appointment replace the previous ones
+func (s *AppointmentLinkServiceImplementation) Reschedule(ctx context.Context, linkToken string, input *domain.RescheduleAppointmentLinkInput) (*domain.ManagedAppointment, error) {
+	link, center, appointment, err := s.resolve(ctx, linkToken, domain.AppointmentLinkPurposeReschedule)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !now.Before(changeableUntil(center, appointment)) {
+		return nil, exceptions.ErrCancellationWindowClosed
+	}
+	if input.StartsAt.Before(now.Add(center.CancellationWindow())) {
+		return nil, exceptions.ErrSlotUnavailable
+	}
+
+	slots, err := s.slotService.FindSlots(ctx, center.ID, &domain.SlotQuery{
+		ServiceID: appointment.ServiceID,
+		StaffID:   &appointment.StaffID,
+		From:      input.StartsAt,
+		To:        input.StartsAt.Add(slotGranularity),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if slotStartingAt(slots, input.StartsAt) == nil {
+		return nil, exceptions.ErrSlotUnavailable
+	}
+
+	previous := *appointment
+	appointment.StartsAt = input.StartsAt
+	err = s.scheduler.schedule(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+	err = s.appointmentRepo.Update(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+
+	offerFreedSlot(ctx, s.waitlistService, s.logger, &previous)
+	managed, err := s.managed(ctx, link, center, appointment)
+	if err != nil {
+		return nil, err
+	}
+	managed.Links = s.signer.links(appointment)
+	return managed, nil
+}
+
+// resolve returns the appointment of a link that is still valid, for the
+// purpose unless it is empty
+func (s *AppointmentLinkServiceImplementation) resolve(ctx context.Context, linkToken string, purpose domain.AppointmentLinkPurpose) (*appointmentLink, *domain.Center, *domain.Appointment, error) {
+	link, err := s.signer.verify(linkToken)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if purpose != "" && link.purpose != purpose {
+		return nil, nil, nil, exceptions.ErrAppointmentLinkWrongPurpose
+	}
+	now := time.Now()
+	if !now.Before(link.expiresAt) {
+		return nil, nil, nil, exceptions.ErrAppointmentLinkExpired
+	}
+
+	appointment, err := s.appointmentRepo.GetByID(ctx, link.centerID, link.appointmentID)
+	if errors.Is(err, exceptions.ErrAppointmentNotFound) {
+		return nil, nil, nil, exceptions.ErrAppointmentLinkInvalid
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if appointment.LinkVersion != link.version {
+		return nil, nil, nil, exceptions.ErrAppointmentLinkRevoked
+	}
+	if purpose != "" && !isManageable(appointment, now) {
+		return nil, nil, nil, exceptions.ErrAppointmentLinkUnavailable
+	}
+
+	center, err := s.centersRepo.GetByID(ctx, link.centerID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return link, center, appointment, nil
+}
+
+func (s *AppointmentLinkServiceImplementation) managed(ctx context.Context, link *appointmentLink, center *domain.Center, appointment *domain.Appointment) (*domain.ManagedAppointment, error) {
+	service, err := s.centersRepo.GetService(ctx, center.ID, appointment.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ManagedAppointment{
+		AppointmentID:   appointment.ID,
+		Purpose:         link.purpose,
+		CenterName:      center.Name,
+		Timezone:        center.Timezone,
+		ServiceID:       appointment.ServiceID,
+		ServiceName:     service.Name,
+		StaffID:         appointment.StaffID,
+		StartsAt:        appointment.StartsAt,
+		EndsAt:          appointment.EndsAt,
+		Status:          appointment.Status,
+		CustomerName:    appointment.CustomerName,
+		ChangeableUntil: changeableUntil(center, appointment),
+	}, nil
+}
+
+// changeableUntil is when the cancellation window of the center starts for
+// the appointment
+func changeableUntil(center *domain.Center, appointment *domain.Appointment) time.Time {
+	return appointment.StartsAt.Add(-center.CancellationWindow())
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testLinkSecret = []byte("link-secret")
+
+// linkedAppointment returns a requested appointment of the center starting
+// in the given time
+func linkedAppointment(centerID uuid.UUID, startsIn time.Duration) *domain.Appointment {
+	startsAt := time.Now().Add(startsIn).Truncate(time.Minute)
+	return &domain.Appointment{
+		ID:           uuid.New(),
+		CenterID:     centerID,
+		StaffID:      uuid.New(),
+		ServiceID:    uuid.New(),
+		StartsAt:     startsAt,
+		EndsAt:       startsAt.Add(30 * time.Minute),
+		Status:       domain.AppointmentStatusRequested,
+		CustomerName: "Ana García",
+		LinkVersion:  3,
+	}
+}
+
+// linkToken returns the token carried by a link URL
+func linkToken(t *testing.T, link string) string {
+	parsed, err := url.Parse(link)
+	assert.NoError(t, err)
+	return parsed.Query().Get("token")
+}
+
+func TestAppointmentLinkService_IssueAndGet(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewAppointmentLinkService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, new(MockMembershipRepository), new(MockSlotService), newOfferingWaitlist(), "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*AppointmentLinkServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid", CancellationWindowHours: 24}
+	appointment := linkedAppointment(center.ID, 72*time.Hour)
+	actor := &domain.CenterMembership{CenterID: center.ID, UserID: uuid.New(), Role: domain.CenterRoleReceptionist}
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, appointment.ID).Return(appointment, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, appointment.ServiceID).Return(&domain.CenterService{ID: appointment.ServiceID, Name: "Limpieza", DurationMinutes: 30, IsActive: true}, nil)
+
+	// Execute
+	links, err := service.Issue(context.Background(), actor, appointment.ID)
+	assert.NoError(t, err)
+	managed, err := service.Get(context.Background(), linkToken(t, links.RescheduleURL))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, links.CancelURL, "https://app.example.com/appointments/cancel?token=")
+	assert.True(t, appointment.StartsAt.Equal(links.ExpiresAt))
+	assert.Equal(t, appointment.ID, managed.AppointmentID)
+	assert.Equal(t, domain.AppointmentLinkPurposeReschedule, managed.Purpose)
+	assert.Equal(t, "Limpieza", managed.ServiceName)
+	assert.True(t, appointment.StartsAt.Add(-24*time.Hour).Equal(managed.ChangeableUntil))
+}
+
+func TestAppointmentLinkService_RejectsLinks(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewAppointmentLinkService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, new(MockMembershipRepository), new(MockSlotService), newOfferingWaitlist(), "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*AppointmentLinkServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid", CancellationWindowHours: 24}
+	appointment := linkedAppointment(center.ID, 72*time.Hour)
+	links := service.signer.links(appointment)
+	expired := service.signer.sign(&appointmentLink{
+		purpose:       domain.AppointmentLinkPurposeCancel,
+		centerID:      center.ID,
+		appointmentID: appointment.ID,
+		version:       appointment.LinkVersion,
+		expiresAt:     time.Now().Add(-time.Minute),
+	})
+	revoked := service.signer.sign(&appointmentLink{
+		purpose:       domain.AppointmentLinkPurposeCancel,
+		centerID:      center.ID,
+		appointmentID: appointment.ID,
+		version:       appointment.LinkVersion - 1,
+		expiresAt:     appointment.StartsAt,
+	})
+	forged := (&appointmentLinkSigner{secret: []byte("another-secret")}).links(appointment)
+
+	// Expectations
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, appointment.ID).Return(appointment, nil)
+
+	// Execute
+	_, tamperedErr := service.Cancel(context.Background(), linkToken(t, links.CancelURL)+"0", &domain.CancelAppointmentLinkInput{})
+	_, forgedErr := service.Cancel(context.Background(), linkToken(t, forged.CancelURL), &domain.CancelAppointmentLinkInput{})
+	_, purposeErr := service.Cancel(context.Background(), linkToken(t, links.ConfirmURL), &domain.CancelAppointmentLinkInput{})
+	_, expiredErr := service.Cancel(context.Background(), expired, &domain.CancelAppointmentLinkInput{})
+	_, revokedErr := service.Cancel(context.Background(), revoked, &domain.CancelAppointmentLinkInput{})
+
+	// Assert
+	assert.ErrorIs(t, tamperedErr, exceptions.ErrAppointmentLinkInvalid)
+	assert.ErrorIs(t, forgedErr, exceptions.ErrAppointmentLinkInvalid)
+	assert.ErrorIs(t, purposeErr, exceptions.ErrAppointmentLinkWrongPurpose)
+	assert.ErrorIs(t, expiredErr, exceptions.ErrAppointmentLinkExpired)
+	assert.ErrorIs(t, revokedErr, exceptions.ErrAppointmentLinkRevoked)
+	mockAppointmentRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAppointmentLinkService_CancelEnforcesWindow(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockWaitlistService := newOfferingWaitlist()
+	service := NewAppointmentLinkService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, new(MockMembershipRepository), new(MockSlotService), mockWaitlistService, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*AppointmentLinkServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid", CancellationWindowHours: 24}
+	tooClose := linkedAppointment(center.ID, 12*time.Hour)
+	farEnough := linkedAppointment(center.ID, 48*time.Hour)
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, tooClose.ID).Return(tooClose, nil)
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, farEnough.ID).Return(farEnough, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, farEnough.ServiceID).Return(&domain.CenterService{ID: farEnough.ServiceID, Name: "Limpieza", DurationMinutes: 30, IsActive: true}, nil)
+	mockAppointmentRepo.On("UpdateStatus", mock.Anything, farEnough, domain.AppointmentStatusRequested).Return(nil)
+
+	// Execute
+	_, closedErr := service.Cancel(context.Background(), linkToken(t, service.signer.links(tooClose).CancelURL), &domain.CancelAppointmentLinkInput{})
+	managed, err := service.Cancel(context.Background(), linkToken(t, service.signer.links(farEnough).CancelURL), &domain.CancelAppointmentLinkInput{Reason: "Can't make it"})
+
+	// Assert
+	assert.ErrorIs(t, closedErr, exceptions.ErrCancellationWindowClosed)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AppointmentStatusCancelled, managed.Status)
+	assert.Equal(t, "Can't make it", farEnough.CancellationReason)
+	assert.NotNil(t, farEnough.CancelledAt)
+	mockWaitlistService.AssertCalled(t, "OfferFreedSlot", mock.Anything, farEnough)
+	mockAppointmentRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, tooClose, mock.Anything)
+}
+
+func TestAppointmentLinkService_Reschedule(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	mockSlotService := new(MockSlotService)
+	mockWaitlistService := newOfferingWaitlist()
+	service := NewAppointmentLinkService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, mockMembershipRepo, mockSlotService, mockWaitlistService, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*AppointmentLinkServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid", CancellationWindowHours: 24}
+	appointment := linkedAppointment(center.ID, 48*time.Hour)
+	previousStart := appointment.StartsAt
+	rescheduleToken := linkToken(t, service.signer.links(appointment).RescheduleURL)
+	newStart := previousStart.Add(24 * time.Hour)
+	slot := &domain.Slot{StaffID: appointment.StaffID, StartsAt: newStart, EndsAt: newStart.Add(30 * time.Minute)}
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, appointment.ID).Return(appointment, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, appointment.ServiceID).Return(&domain.CenterService{ID: appointment.ServiceID, Name: "Limpieza", DurationMinutes: 30, IsActive: true}, nil)
+	mockSlotService.On("FindSlots", mock.Anything, center.ID, &domain.SlotQuery{ServiceID: appointment.ServiceID, StaffID: &appointment.StaffID, From: newStart, To: newStart.Add(slotGranularity)}).Return([]*domain.Slot{slot}, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, center.ID, appointment.StaffID).Return(&domain.CenterMembership{CenterID: center.ID, UserID: appointment.StaffID, Role: domain.CenterRoleStaff}, nil)
+	mockAppointmentRepo.On("Update", mock.Anything, appointment).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Appointment).LinkVersion++
+	}).Return(nil)
+
+	// Execute
+	managed, err := service.Reschedule(context.Background(), rescheduleToken, &domain.RescheduleAppointmentLinkInput{StartsAt: newStart})
+	_, staleErr := service.Get(context.Background(), rescheduleToken)
+	_, freshErr := service.Get(context.Background(), linkToken(t, managed.Links.RescheduleURL))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, newStart.Equal(managed.StartsAt))
+	assert.True(t, newStart.Add(30*time.Minute).Equal(appointment.EndsAt))
+	assert.ErrorIs(t, staleErr, exceptions.ErrAppointmentLinkRevoked)
+	assert.NoError(t, freshErr)
+	freed := mockWaitlistService.Calls[0].Arguments.Get(1).(*domain.Appointment)
+	assert.True(t, previousStart.Equal(freed.StartsAt))
+}
+
+func TestAppointmentLinkService_RescheduleToUnavailableSlot(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockSlotService := new(MockSlotService)
+	service := NewAppointmentLinkService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, new(MockMembershipRepository), mockSlotService, newOfferingWaitlist(), "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*AppointmentLinkServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid", CancellationWindowHours: 24}
+	appointment := linkedAppointment(center.ID, 48*time.Hour)
+	rescheduleToken := linkToken(t, service.signer.links(appointment).RescheduleURL)
+	taken := appointment.StartsAt.Add(24 * time.Hour)
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, appointment.ID).Return(appointment, nil)
+	mockSlotService.On("FindSlots", mock.Anything, center.ID, mock.AnythingOfType("*domain.SlotQuery")).Return([]*domain.Slot{}, nil)
+
+	// Execute
+	_, takenErr := service.Reschedule(context.Background(), rescheduleToken, &domain.RescheduleAppointmentLinkInput{StartsAt: taken})
+	_, windowErr := service.Reschedule(context.Background(), rescheduleToken, &domain.RescheduleAppointmentLinkInput{StartsAt: time.Now().Add(time.Hour)})
+
+	// Assert
+	assert.ErrorIs(t, takenErr, exceptions.ErrSlotUnavailable)
+	assert.ErrorIs(t, windowErr, exceptions.ErrSlotUnavailable)
+	mockSlotService.AssertNumberOfCalls(t, "FindSlots", 1)
+	mockAppointmentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,996 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/scheduling"
+	"github.com/google/uuid"
+)
+
+const (
+	// seriesCheckHorizon bounds the occurrences checked against the
+	// availability and the bookings when a series is created or edited
+	seriesCheckHorizon = 366 * 24 * time.Hour
+	// maxSeriesOccurrences bounds the expansion of a rule looking for its last
+	// occurrence, longer series are stored as repeating forever
+	maxSeriesOccurrences = 5000
+	maxOccurrenceRange   = 92 * 24 * time.Hour
+)
+
+// lastDate is used as an open upper bound of occurrence dates
+var lastDate = domain.Date{Year: 9999, Month: time.December, Day: 31}
+
+type AppointmentSeriesServiceImplementation struct {
+	seriesRepo       ports.AppointmentSeriesRepository
+	appointmentRepo  ports.AppointmentRepository
+	centersRepo      ports.CentersRepository
+	availabilityRepo ports.AvailabilityRepository
+	timeBlockRepo    ports.TimeBlockRepository
+	holdRepo         ports.SlotHoldRepository
+	waitlistService  ports.WaitlistService
+	scheduler        *appointmentScheduler
+	logger           ports.Logger
+}
+
+func NewAppointmentSeriesService(seriesRepo ports.AppointmentSeriesRepository, appointmentRepo ports.AppointmentRepository, holdRepo ports.SlotHoldRepository, centersRepo ports.CentersRepository, availabilityRepo ports.AvailabilityRepository, timeBlockRepo ports.TimeBlockRepository, membershipRepo ports.MembershipRepository, waitlistService ports.WaitlistService, logger ports.Logger) ports.AppointmentSeriesService {
+	return &AppointmentSeriesServiceImplementation{
+		seriesRepo:       seriesRepo,
+		appointmentRepo:  appointmentRepo,
+		centersRepo:      centersRepo,
+		availabilityRepo: availabilityRepo,
+		timeBlockRepo:    timeBlockRepo,
+		holdRepo:         holdRepo,
+		waitlistService:  waitlistService,
+		scheduler: &appointmentScheduler{
+			centersRepo:    centersRepo,
+			membershipRepo: membershipRepo,
+			seriesRepo:     seriesRepo,
+			holdRepo:       holdRepo,
+		},
+		logger: logger,
+	}
+}
+
+func (s *AppointmentSeriesServiceImplementation) Get(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AppointmentSeries, error) {
+	return s.seriesRepo.GetByID(ctx, centerID, id)
+}
+
+func (s *AppointmentSeriesServiceImplementation) Create(ctx context.Context, actor *domain.CenterMembership, input *domain.CreateAppointmentSeriesInput) (*domain.AppointmentSeries, error) {
+	status := input.Status
+	if status == "" {
+		status = domain.AppointmentStatusRequested
+	}
+	if status != domain.AppointmentStatusRequested && status != domain.AppointmentStatusConfirmed {
+		return nil, exceptions.ErrAppointmentInvalidStatus
+	}
+
+	err := authorizeAppointment(actor, input.StaffID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := scheduling.ParseRRule(input.RRule)
+	if err != nil {
+		return nil, exceptions.ErrAppointmentSeriesInvalidRule
+	}
+
+	center, err := s.centersRepo.GetByID(ctx, actor.CenterID)
+	if err != nil {
+		return nil, err
+	}
+
+	series := &domain.AppointmentSeries{
+		CenterID:      actor.CenterID,
+		StaffID:       input.StaffID,
+		ServiceID:     input.ServiceID,
+		ResourceID:    input.ResourceID,
+		RRule:         rule.String(),
+		StartsAt:      input.StartsAt,
+		Timezone:      center.Timezone,
+		ExDates:       append([]domain.Date{}, input.ExDates...),
+		Status:        status,
+		CustomerName:  input.CustomerName,
+		CustomerEmail: strings.ToLower(input.CustomerEmail),
+		CustomerPhone: input.CustomerPhone,
+		Notes:         input.Notes,
+		CreatedBy:     &actor.UserID,
+	}
+	service, err := s.schedule(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.findConflicts(ctx, series, service, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		if !input.SkipConflicts {
+			return nil, &domain.SeriesConflictError{Err: exceptions.ErrAppointmentSeriesConflict, Conflicts: conflicts}
+		}
+		for _, conflict := range conflicts {
+			series.ExDates = append(series.ExDates, conflict.Date)
+		}
+	}
+
+	err = setSeriesEnd(series)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.seriesRepo.Create(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (s *AppointmentSeriesServiceImplementation) ListOccurrences(ctx context.Context, centerID uuid.UUID, filter *domain.OccurrenceFilter) ([]*domain.AppointmentOccurrence, error) {
+	if !filter.To.After(filter.From) || filter.To.Sub(filter.From) > maxOccurrenceRange {
+		return nil, exceptions.ErrAppointmentOccurrenceRange
+	}
+
+	var seriesList []*domain.AppointmentSeries
+	if filter.SeriesID != nil {
+		series, err := s.seriesRepo.GetByID(ctx, centerID, *filter.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+		if series.CancelledAt == nil {
+			seriesList = append(seriesList, series)
+		}
+	} else {
+		var err error
+		seriesList, err = s.seriesRepo.ListActive(ctx, centerID, filter.From, filter.To)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	occurrences := []*domain.AppointmentOccurrence{}
+	for _, series := range seriesList {
+		seriesOccurrences, err := occurrencesOf(series, filter.From, filter.To)
+		if err != nil {
+			return nil, err
+		}
+		// Only the occurrences starting in the range are listed, the rule
+		// start of an edited occurrence is its date at the time of the series
+		for _, occurrence := range seriesOccurrences {
+			if !occurrence.StartsAt.Before(filter.From) {
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+	}
+
+	err := s.attachStored(ctx, centerID, occurrences)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := occurrences[:0]
+	for _, occurrence := range occurrences {
+		if filter.StaffID == nil || occurrence.StaffID == *filter.StaffID {
+			filtered = append(filtered, occurrence)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].StartsAt.Before(filtered[j].StartsAt) })
+	return filtered, nil
+}
+
+func (s *AppointmentSeriesServiceImplementation) UpdateOccurrence(ctx context.Context, actor *domain.CenterMembership, seriesID uuid.UUID, date domain.Date, input *domain.UpdateOccurrenceInput) (*domain.AppointmentOccurrence, error) {
+	if !input.Scope.IsValid() {
+		return nil, exceptions.ErrAppointmentSeriesInvalidScope
+	}
+	if input.Scope == domain.SeriesScopeThis && input.RRule != nil {
+		return nil, exceptions.ErrAppointmentSeriesRuleScope
+	}
+
+	series, occurrence, err := s.occurrence(ctx, actor, seriesID, date)
+	if err != nil {
+		return nil, err
+	}
+	if input.StaffID != nil {
+		err = authorizeAppointment(actor, *input.StaffID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case input.Scope == domain.SeriesScopeThis:
+		return s.updateThis(ctx, actor, series, occurrence, input)
+	case input.Scope == domain.SeriesScopeFollowing && occurrence.Date != series.FirstDate():
+		return s.updateFollowing(ctx, series, occurrence, input)
+	default:
+		return s.updateAll(ctx, series, occurrence, input)
+	}
+}
+
+// updateThis edits a single occurrence, which is stored as an appointment of
+// the series the first time
+func (s *AppointmentSeriesServiceImplementation) updateThis(ctx context.Context, actor *domain.CenterMembership, series *domain.AppointmentSeries, occurrence *domain.AppointmentOccurrence, input *domain.UpdateOccurrenceInput) (*domain.AppointmentOccurrence, error) {
+	edit := &domain.UpdateAppointmentInput{
+		StaffID:       input.StaffID,
+		ServiceID:     input.ServiceID,
+		ResourceID:    input.ResourceID,
+		StartsAt:      input.StartsAt,
+		CustomerName:  input.CustomerName,
+		CustomerEmail: input.CustomerEmail,
+		CustomerPhone: input.CustomerPhone,
+		Notes:         input.Notes,
+	}
+
+	if occurrence.Appointment != nil {
+		previous := *occurrence.Appointment
+		err := s.scheduler.edit(ctx, actor, occurrence.Appointment, edit)
+		if err != nil {
+			return nil, err
+		}
+		err = s.appointmentRepo.Update(ctx, occurrence.Appointment)
+		if err != nil {
+			return nil, err
+		}
+		if rescheduled(&previous, occurrence.Appointment) {
+			offerFreedSlot(ctx, s.waitlistService, s.logger, &previous)
+		}
+		occurrence.Attach(occurrence.Appointment)
+		return occurrence, nil
+	}
+
+	appointment := &domain.Appointment{
+		CenterID:       series.CenterID,
+		StaffID:        series.StaffID,
+		ServiceID:      series.ServiceID,
+		ResourceID:     series.ResourceID,
+		SeriesID:       &series.ID,
+		OccurrenceDate: &occurrence.Date,
+		StartsAt:       occurrence.StartsAt,
+		Status:         domain.AppointmentStatusRequested,
+		CustomerName:   series.CustomerName,
+		CustomerEmail:  series.CustomerEmail,
+		CustomerPhone:  series.CustomerPhone,
+		Notes:          series.Notes,
+		CreatedBy:      series.CreatedBy,
+	}
+	if series.Status == domain.AppointmentStatusConfirmed {
+		appointment.SetStatus(series.Status, time.Now())
+	}
+
+	err := s.scheduler.schedule(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+	err = s.scheduler.edit(ctx, actor, appointment, edit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.appointmentRepo.Create(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+	occurrence.Attach(appointment)
+	return occurrence, nil
+}
+
+// updateFollowing ends the series before the occurrence and continues it with
+// a new series holding the changes
+func (s *AppointmentSeriesServiceImplementation) updateFollowing(ctx context.Context, series *domain.AppointmentSeries, occurrence *domain.AppointmentOccurrence, input *domain.UpdateOccurrenceInput) (*domain.AppointmentOccurrence, error) {
+	next := *series
+	next.ID = uuid.Nil
+	next.StartsAt = occurrence.RuleStartsAt
+	if input.StartsAt != nil {
+		next.StartsAt = *input.StartsAt
+	}
+
+	err := truncateSeries(series, &next, occurrence)
+	if err != nil {
+		return nil, err
+	}
+	if input.RRule != nil {
+		rule, err := scheduling.ParseRRule(*input.RRule)
+		if err != nil {
+			return nil, exceptions.ErrAppointmentSeriesInvalidRule
+		}
+		next.RRule = rule.String()
+	}
+	applySeriesEdit(&next, input)
+
+	service, err := s.schedule(ctx, &next)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stored occurrences from the date on move to the new series
+	stored, err := s.storedDates(ctx, series, occurrence.Date)
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := s.findConflicts(ctx, &next, service, stored, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, &domain.SeriesConflictError{Err: exceptions.ErrAppointmentSeriesConflict, Conflicts: conflicts}
+	}
+
+	err = setSeriesEnd(series)
+	if err != nil {
+		return nil, err
+	}
+	err = setSeriesEnd(&next)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.seriesRepo.Split(ctx, series, &next, occurrence.Date)
+	if err != nil {
+		return nil, err
+	}
+	return s.firstOccurrence(ctx, &next)
+}
+
+// updateAll edits the series. Moving the occurrence moves every occurrence by
+// the same number of days to the new time. Occurrences edited on their own
+// keep their changes, and move to the date their occurrence moves to.
+func (s *AppointmentSeriesServiceImplementation) updateAll(ctx context.Context, series *domain.AppointmentSeries, occurrence *domain.AppointmentOccurrence, input *domain.UpdateOccurrenceInput) (*domain.AppointmentOccurrence, error) {
+	moved := input.StaffID != nil || input.ServiceID != nil || input.ResourceID != nil || input.StartsAt != nil || input.RRule != nil
+	date := occurrence.Date
+	days := 0
+
+	if input.RRule != nil {
+		rule, err := scheduling.ParseRRule(*input.RRule)
+		if err != nil {
+			return nil, exceptions.ErrAppointmentSeriesInvalidRule
+		}
+		series.RRule = rule.String()
+	}
+	if input.StartsAt != nil {
+		loc, err := series.Location()
+		if err != nil {
+			return nil, err
+		}
+		newStart := input.StartsAt.In(loc)
+		days = daysBetween(occurrence.Date, domain.DateOf(newStart))
+		first := domain.DateOf(series.StartsAt.In(loc)).AddDays(days)
+		series.StartsAt = time.Date(first.Year, first.Month, first.Day, newStart.Hour(), newStart.Minute(), newStart.Second(), 0, loc)
+		for i := range series.ExDates {
+			series.ExDates[i] = series.ExDates[i].AddDays(days)
+		}
+		date = date.AddDays(days)
+	}
+	applySeriesEdit(series, input)
+
+	if moved {
+		service, err := s.schedule(ctx, series)
+		if err != nil {
+			return nil, err
+		}
+		stored, err := s.storedDates(ctx, series, series.FirstDate().AddDays(-days))
+		if err != nil {
+			return nil, err
+		}
+		movedDates := make(map[domain.Date]bool, len(stored))
+		for storedDate := range stored {
+			movedDates[storedDate.AddDays(days)] = true
+		}
+		conflicts, err := s.findConflicts(ctx, series, service, movedDates, series.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(conflicts) > 0 {
+			return nil, &domain.SeriesConflictError{Err: exceptions.ErrAppointmentSeriesConflict, Conflicts: conflicts}
+		}
+	}
+
+	err := setSeriesEnd(series)
+	if err != nil {
+		return nil, err
+	}
+	err = s.seriesRepo.Move(ctx, series, days)
+	if err != nil {
+		return nil, err
+	}
+
+	_, edited, err := s.findOccurrence(ctx, series, date)
+	if err != nil {
+		// The new rule may not have an occurrence on the date anymore
+		return s.firstOccurrence(ctx, series)
+	}
+	return edited, nil
+}
+
+func (s *AppointmentSeriesServiceImplementation) CancelOccurrence(ctx context.Context, actor *domain.CenterMembership, seriesID uuid.UUID, date domain.Date, input *domain.CancelOccurrenceInput) error {
+	if !input.Scope.IsValid() {
+		return exceptions.ErrAppointmentSeriesInvalidScope
+	}
+
+	series, occurrence, err := s.occurrence(ctx, actor, seriesID, date)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	from := occurrence.Date
+	if input.Scope == domain.SeriesScopeAll {
+		from = series.FirstDate()
+	}
+	// The occurrences being cut are expanded before the rule changes, to
+	// offer their slots once cancelled
+	var freed []*domain.AppointmentOccurrence
+	if input.Scope != domain.SeriesScopeThis {
+		freed, err = upcomingOccurrences(series, from, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case input.Scope == domain.SeriesScopeThis:
+		if occurrence.Appointment != nil {
+			return s.cancelStored(ctx, []*domain.Appointment{occurrence.Appointment}, input.Reason, now, true)
+		}
+		series.ExDates = append(series.ExDates, occurrence.Date)
+		err = setSeriesEnd(series)
+		if err != nil {
+			return err
+		}
+		err = s.seriesRepo.Update(ctx, series)
+		if err != nil {
+			return err
+		}
+		offerFreedSlot(ctx, s.waitlistService, s.logger, &domain.Appointment{
+			CenterID: series.CenterID,
+			StaffID:  occurrence.StaffID,
+			StartsAt: occurrence.StartsAt,
+		})
+		return nil
+	case input.Scope == domain.SeriesScopeFollowing && occurrence.Date != series.FirstDate():
+		err = truncateSeries(series, nil, occurrence)
+		if err != nil {
+			return err
+		}
+		err = setSeriesEnd(series)
+		if err != nil {
+			return err
+		}
+		err = s.seriesRepo.Update(ctx, series)
+		if err != nil {
+			return err
+		}
+	default:
+		series.CancelledAt = &now
+		err = s.seriesRepo.Update(ctx, series)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stored occurrences that did not happen yet are cancelled with the
+	// series, the past ones keep their status
+	stored, err := s.seriesRepo.ListOccurrences(ctx, series.CenterID, []uuid.UUID{series.ID}, from, lastDate)
+	if err != nil {
+		return err
+	}
+	storedDates := make(map[domain.Date]bool, len(stored))
+	upcoming := make([]*domain.Appointment, 0, len(stored))
+	for _, appointment := range stored {
+		storedDates[*appointment.OccurrenceDate] = true
+		if appointment.StartsAt.After(now) {
+			upcoming = append(upcoming, appointment)
+		}
+	}
+	err = s.cancelStored(ctx, upcoming, input.Reason, now, false)
+	if err != nil {
+		return err
+	}
+
+	// The stored occurrences were offered as they were cancelled
+	for _, pending := range freed {
+		if storedDates[pending.Date] {
+			continue
+		}
+		offerFreedSlot(ctx, s.waitlistService, s.logger, &domain.Appointment{
+			CenterID: series.CenterID,
+			StaffID:  pending.StaffID,
+			StartsAt: pending.StartsAt,
+		})
+	}
+	return nil
+}
+
+// upcomingOccurrences expands the occurrences of the series that start after
+// now, from the date and within the horizon the series is checked in
+func upcomingOccurrences(series *domain.AppointmentSeries, from domain.Date, now time.Time) ([]*domain.AppointmentOccurrence, error) {
+	loc, err := series.Location()
+	if err != nil {
+		return nil, err
+	}
+	start := from.In(loc)
+	if start.Before(now) {
+		start = now
+	}
+	occurrences, err := occurrencesOf(series, start, start.Add(seriesCheckHorizon))
+	if err != nil {
+		return nil, err
+	}
+	upcoming := occurrences[:0]
+	for _, occurrence := range occurrences {
+		if occurrence.StartsAt.After(now) {
+			upcoming = append(upcoming, occurrence)
+		}
+	}
+	return upcoming, nil
+}
+
+// cancelStored cancels the appointments of stored occurrences. Unless strict,
+// the ones that can no longer be cancelled are left as they are.
+func (s *AppointmentSeriesServiceImplementation) cancelStored(ctx context.Context, appointments []*domain.Appointment, reason string, now time.Time, strict bool) error {
+	for _, appointment := range appointments {
+		from := appointment.Status
+		if !from.CanTransitionTo(domain.AppointmentStatusCancelled) {
+			if strict {
+				return &domain.AppointmentTransitionError{Err: exceptions.ErrAppointmentInvalidTransition, From: from, To: domain.AppointmentStatusCancelled}
+			}
+			continue
+		}
+		appointment.SetStatus(domain.AppointmentStatusCancelled, now)
+		appointment.CancellationReason = reason
+		err := s.appointmentRepo.UpdateStatus(ctx, appointment, from)
+		if err != nil {
+			return err
+		}
+		offerFreedSlot(ctx, s.waitlistService, s.logger, appointment)
+	}
+	return nil
+}
+
+// occurrence loads the series and its occurrence of the date, after checking
+// the actor can manage them
+func (s *AppointmentSeriesServiceImplementation) occurrence(ctx context.Context, actor *domain.CenterMembership, seriesID uuid.UUID, date domain.Date) (*domain.AppointmentSeries, *domain.AppointmentOccurrence, error) {
+	series, err := s.seriesRepo.GetByID(ctx, actor.CenterID, seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if series.CancelledAt != nil {
+		return nil, nil, exceptions.ErrAppointmentSeriesCancelled
+	}
+	err = authorizeAppointment(actor, series.StaffID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.findOccurrence(ctx, series, date)
+}
+
+// findOccurrence expands the occurrence of the series on the date
+func (s *AppointmentSeriesServiceImplementation) findOccurrence(ctx context.Context, series *domain.AppointmentSeries, date domain.Date) (*domain.AppointmentSeries, *domain.AppointmentOccurrence, error) {
+	loc, err := series.Location()
+	if err != nil {
+		return nil, nil, err
+	}
+	occurrences, err := occurrencesOf(series, date.In(loc), date.AddDays(1).In(loc))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, occurrence := range occurrences {
+		if occurrence.Date == date {
+			err = s.attachStored(ctx, series.CenterID, []*domain.AppointmentOccurrence{occurrence})
+			if err != nil {
+				return nil, nil, err
+			}
+			return series, occurrence, nil
+		}
+	}
+	return nil, nil, exceptions.ErrAppointmentOccurrenceNotFound
+}
+
+func (s *AppointmentSeriesServiceImplementation) firstOccurrence(ctx context.Context, series *domain.AppointmentSeries) (*domain.AppointmentOccurrence, error) {
+	_, occurrence, err := s.findOccurrence(ctx, series, series.FirstDate())
+	return occurrence, err
+}
+
+// attachStored replaces the occurrences that are stored by their appointment
+func (s *AppointmentSeriesServiceImplementation) attachStored(ctx context.Context, centerID uuid.UUID, occurrences []*domain.AppointmentOccurrence) error {
+	if len(occurrences) == 0 {
+		return nil
+	}
+
+	seriesIDs, from, to := occurrenceBounds(occurrences)
+	stored, err := s.seriesRepo.ListOccurrences(ctx, centerID, seriesIDs, from, to)
+	if err != nil {
+		return err
+	}
+
+	byOccurrence := make(map[occurrenceKey]*domain.Appointment, len(stored))
+	for _, appointment := range stored {
+		byOccurrence[occurrenceKey{*appointment.SeriesID, *appointment.OccurrenceDate}] = appointment
+	}
+	for _, occurrence := range occurrences {
+		if appointment, found := byOccurrence[occurrenceKey{occurrence.SeriesID, occurrence.Date}]; found {
+			occurrence.Attach(appointment)
+		}
+	}
+	return nil
+}
+
+// storedDates returns the dates of the stored occurrences of the series from
+// the date on
+func (s *AppointmentSeriesServiceImplementation) storedDates(ctx context.Context, series *domain.AppointmentSeries, from domain.Date) (map[domain.Date]bool, error) {
+	stored, err := s.seriesRepo.ListOccurrences(ctx, series.CenterID, []uuid.UUID{series.ID}, from, lastDate)
+	if err != nil {
+		return nil, err
+	}
+	dates := make(map[domain.Date]bool, len(stored))
+	for _, appointment := range stored {
+		dates[*appointment.OccurrenceDate] = true
+	}
+	return dates, nil
+}
+
+// schedule checks the staff member, the service and the resource of the
+// series, and makes its occurrences last as long as the service
+func (s *AppointmentSeriesServiceImplementation) schedule(ctx context.Context, series *domain.AppointmentSeries) (*domain.CenterService, error) {
+	_, err := s.scheduler.membershipRepo.GetByCenterAndUser(ctx, series.CenterID, series.StaffID)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := s.centersRepo.GetService(ctx, series.CenterID, series.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if !service.IsActive {
+		return nil, exceptions.ErrCenterServiceNotFound
+	}
+
+	if series.ResourceID != nil {
+		resource, err := s.centersRepo.GetResource(ctx, series.CenterID, *series.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		if !resource.IsActive {
+			return nil, exceptions.ErrCenterResourceNotFound
+		}
+	}
+
+	series.DurationMinutes = service.DurationMinutes
+	return service, nil
+}
+
+// findConflicts checks the upcoming occurrences of the series, within
+// seriesCheckHorizon, against the availability of the staff member, time
+// blocks and closures, and the time already taken by appointments, other
+// series and the active holds of the staff member. Stored occurrences and the skipped series are left out.
+func (s *AppointmentSeriesServiceImplementation) findConflicts(ctx context.Context, series *domain.AppointmentSeries, service *domain.CenterService, stored map[domain.Date]bool, skip ...uuid.UUID) ([]domain.SeriesConflict, error) {
+	loc, err := series.Location()
+	if err != nil {
+		return nil, err
+	}
+	from := series.StartsAt
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+
+	all, err := occurrencesOf(series, from, from.Add(seriesCheckHorizon))
+	if err != nil {
+		return nil, err
+	}
+	var occurrences []*domain.AppointmentOccurrence
+	for _, occurrence := range all {
+		if !stored[occurrence.Date] {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	bufferBefore := time.Duration(service.BufferBeforeMinutes) * time.Minute
+	bufferAfter := time.Duration(service.BufferAfterMinutes) * time.Minute
+	rangeFrom := occurrences[0].StartsAt.Add(-bufferBefore)
+	rangeTo := occurrences[len(occurrences)-1].EndsAt.Add(bufferAfter)
+
+	rules, err := s.availabilityRepo.ListByStaff(ctx, series.CenterID, series.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	openingHours, err := s.centersRepo.ListOpeningHours(ctx, series.CenterID)
+	if err != nil {
+		return nil, err
+	}
+	windows := scheduling.Windows(rules, openingHours, rangeFrom, rangeTo, loc)
+
+	blocks, err := s.timeBlockRepo.ListInRange(ctx, series.CenterID, rangeFrom, rangeTo)
+	if err != nil {
+		return nil, err
+	}
+	var unavailable []scheduling.Interval
+	for _, block := range blocks {
+		if block.IsClosure() || *block.UserID == series.StaffID {
+			unavailable = append(unavailable, scheduling.Interval{Start: block.StartsAt, End: block.EndsAt})
+		}
+	}
+	unavailable = scheduling.Merge(unavailable)
+
+	skipped := map[uuid.UUID]bool{series.ID: true}
+	for _, id := range skip {
+		skipped[id] = true
+	}
+	var booked []scheduling.Interval
+	appointments, err := s.appointmentRepo.ListBusyInRange(ctx, series.CenterID, rangeFrom, rangeTo)
+	if err != nil {
+		return nil, err
+	}
+	for _, appointment := range appointments {
+		if appointment.SeriesID != nil && skipped[*appointment.SeriesID] {
+			continue
+		}
+		if appointment.StaffID == series.StaffID || sameResource(appointment.ResourceID, series.ResourceID) {
+			booked = append(booked, scheduling.Interval{Start: appointment.StartsAt, End: appointment.EndsAt})
+		}
+	}
+	pending, err := pendingOccurrences(ctx, s.seriesRepo, series.CenterID, rangeFrom, rangeTo)
+	if err != nil {
+		return nil, err
+	}
+	for _, occurrence := range pending {
+		if skipped[occurrence.SeriesID] {
+			continue
+		}
+		if occurrence.StaffID == series.StaffID || sameResource(occurrence.ResourceID, series.ResourceID) {
+			booked = append(booked, scheduling.Interval{Start: occurrence.StartsAt, End: occurrence.EndsAt})
+		}
+	}
+	holds, err := s.holdRepo.ListActiveInRange(ctx, series.CenterID, rangeFrom, rangeTo, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, hold := range holds {
+		if hold.StaffID == series.StaffID {
+			booked = append(booked, scheduling.Interval{Start: hold.StartsAt, End: hold.EndsAt})
+		}
+	}
+	booked = scheduling.Merge(booked)
+
+	var conflicts []domain.SeriesConflict
+	for _, occurrence := range occurrences {
+		slot := scheduling.Interval{Start: occurrence.StartsAt, End: occurrence.EndsAt}
+		guarded := scheduling.Interval{Start: slot.Start.Add(-bufferBefore), End: slot.End.Add(bufferAfter)}
+		conflict := domain.SeriesConflict{Date: occurrence.Date, StartsAt: occurrence.StartsAt, EndsAt: occurrence.EndsAt}
+		switch {
+		case !scheduling.Covers(windows, slot) || scheduling.OverlapsAny(unavailable, guarded):
+			conflict.Reason = domain.SeriesConflictUnavailable
+		case scheduling.OverlapsAny(booked, guarded):
+			conflict.Reason = domain.SeriesConflictBooked
+		default:
+			continue
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, nil
+}
+
+type occurrenceKey struct {
+	seriesID uuid.UUID
+	date     domain.Date
+}
+
+// occurrencesOf expands the occurrences of the series that intersect
+// [from, to), the excluded dates are skipped
+func occurrencesOf(series *domain.AppointmentSeries, from time.Time, to time.Time) ([]*domain.AppointmentOccurrence, error) {
+	rule, err := scheduling.ParseRRule(series.RRule)
+	if err != nil {
+		return nil, exceptions.ErrAppointmentSeriesInvalidRule
+	}
+	loc, err := series.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []*domain.AppointmentOccurrence
+	it := rule.Iterator(series.StartsAt.In(loc))
+	for {
+		start, ok := it.Next()
+		if !ok || !start.Before(to) {
+			return occurrences, nil
+		}
+		end := start.Add(series.Duration())
+		date := domain.DateOf(start)
+		if !end.After(from) || series.IsExcluded(date) {
+			continue
+		}
+		occurrences = append(occurrences, &domain.AppointmentOccurrence{
+			SeriesID:     series.ID,
+			Date:         date,
+			StaffID:      series.StaffID,
+			ResourceID:   series.ResourceID,
+			StartsAt:     start,
+			EndsAt:       end,
+			RuleStartsAt: start,
+			Status:       series.Status,
+		})
+	}
+}
+
+// pendingOccurrences returns the occurrences of the active series of the
+// center that intersect [from, to) and are not stored. The stored ones are
+// appointments and are found as such.
+func pendingOccurrences(ctx context.Context, seriesRepo ports.AppointmentSeriesRepository, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.AppointmentOccurrence, error) {
+	seriesList, err := seriesRepo.ListActive(ctx, centerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []*domain.AppointmentOccurrence
+	for _, series := range seriesList {
+		seriesOccurrences, err := occurrencesOf(series, from, to)
+		if err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, seriesOccurrences...)
+	}
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	seriesIDs, firstDate, lastDate := occurrenceBounds(occurrences)
+	stored, err := seriesRepo.ListOccurrences(ctx, centerID, seriesIDs, firstDate, lastDate)
+	if err != nil {
+		return nil, err
+	}
+	isStored := make(map[occurrenceKey]bool, len(stored))
+	for _, appointment := range stored {
+		isStored[occurrenceKey{*appointment.SeriesID, *appointment.OccurrenceDate}] = true
+	}
+
+	pending := occurrences[:0]
+	for _, occurrence := range occurrences {
+		if !isStored[occurrenceKey{occurrence.SeriesID, occurrence.Date}] {
+			pending = append(pending, occurrence)
+		}
+	}
+	return pending, nil
+}
+
+func occurrenceBounds(occurrences []*domain.AppointmentOccurrence) ([]uuid.UUID, domain.Date, domain.Date) {
+	var seriesIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	from, to := occurrences[0].Date, occurrences[0].Date
+	for _, occurrence := range occurrences {
+		if !seen[occurrence.SeriesID] {
+			seen[occurrence.SeriesID] = true
+			seriesIDs = append(seriesIDs, occurrence.SeriesID)
+		}
+		if occurrence.Date.Before(from) {
+			from = occurrence.Date
+		}
+		if occurrence.Date.After(to) {
+			to = occurrence.Date
+		}
+	}
+	return seriesIDs, from, to
+}
+
+// truncateSeries ends the series before the occurrence. The rest of the rule
+// and the excluded dates from the occurrence on go to next, when set. As
+// RFC 5545 counts excluded dates in COUNT, so does the split.
+func truncateSeries(series *domain.AppointmentSeries, next *domain.AppointmentSeries, occurrence *domain.AppointmentOccurrence) error {
+	rule, err := scheduling.ParseRRule(series.RRule)
+	if err != nil {
+		return exceptions.ErrAppointmentSeriesInvalidRule
+	}
+	loc, err := series.Location()
+	if err != nil {
+		return err
+	}
+
+	before := 0
+	it := rule.Iterator(series.StartsAt.In(loc))
+	for start, ok := it.Next(); ok && domain.DateOf(start).Before(occurrence.Date); start, ok = it.Next() {
+		before++
+	}
+
+	if next != nil {
+		nextRule := *rule
+		if rule.Count > 0 {
+			nextRule.Count = rule.Count - before
+		}
+		next.RRule = nextRule.String()
+		next.ExDates = nil
+		for _, date := range series.ExDates {
+			if !date.Before(occurrence.Date) {
+				next.ExDates = append(next.ExDates, date)
+			}
+		}
+	}
+
+	if rule.Count > 0 {
+		rule.Count = before
+	} else {
+		until := occurrence.RuleStartsAt.Add(-time.Second).UTC()
+		rule.Until, rule.UntilDate = &until, nil
+	}
+	series.RRule = rule.String()
+	kept := []domain.Date{}
+	for _, date := range series.ExDates {
+		if date.Before(occurrence.Date) {
+			kept = append(kept, date)
+		}
+	}
+	series.ExDates = kept
+	return nil
+}
+
+// setSeriesEnd stores when the last occurrence of the series ends, or nothing
+// when it repeats forever or for more than maxSeriesOccurrences
+func setSeriesEnd(series *domain.AppointmentSeries) error {
+	rule, err := scheduling.ParseRRule(series.RRule)
+	if err != nil {
+		return exceptions.ErrAppointmentSeriesInvalidRule
+	}
+	loc, err := series.Location()
+	if err != nil {
+		return err
+	}
+
+	series.EndsAt = nil
+	if rule.Count == 0 && rule.Until == nil && rule.UntilDate == nil {
+		return nil
+	}
+
+	var last *time.Time
+	it := rule.Iterator(series.StartsAt.In(loc))
+	for i := 0; ; i++ {
+		start, ok := it.Next()
+		if !ok {
+			break
+		}
+		if i >= maxSeriesOccurrences {
+			return nil
+		}
+		if !series.IsExcluded(domain.DateOf(start)) {
+			end := start.Add(series.Duration())
+			last = &end
+		}
+	}
+	if last == nil {
+		return exceptions.ErrAppointmentSeriesEmpty
+	}
+	series.EndsAt = last
+	return nil
+}
+
+func applySeriesEdit(series *domain.AppointmentSeries, input *domain.UpdateOccurrenceInput) {
+	if input.StaffID != nil {
+		series.StaffID = *input.StaffID
+	}
+	if input.ServiceID != nil {
+		series.ServiceID = *input.ServiceID
+	}
+	if input.ResourceID != nil {
+		series.ResourceID = input.ResourceID
+	}
+	if input.CustomerName != nil {
+		series.CustomerName = *input.CustomerName
+	}
+	if input.CustomerEmail != nil {
+		series.CustomerEmail = strings.ToLower(*input.CustomerEmail)
+	}
+	if input.CustomerPhone != nil {
+		series.CustomerPhone = *input.CustomerPhone
+	}
+	if input.Notes != nil {
+		series.Notes = *input.Notes
+	}
+}
+
+func daysBetween(from domain.Date, to domain.Date) int {
+	return int(to.In(time.UTC).Sub(from.In(time.UTC)).Hours() / 24)
+}
@@ -0,0 +1,449 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAppointmentSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockAppointmentSeriesRepository) Create(ctx context.Context, series *domain.AppointmentSeries) error {
+	args := m.Called(ctx, series)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentSeriesRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AppointmentSeries, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.AppointmentSeries), args.Error(1)
+}
+
+func (m *MockAppointmentSeriesRepository) Update(ctx context.Context, series *domain.AppointmentSeries) error {
+	args := m.Called(ctx, series)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentSeriesRepository) Move(ctx context.Context, series *domain.AppointmentSeries, days int) error {
+	args := m.Called(ctx, series, days)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentSeriesRepository) Split(ctx context.Context, series *domain.AppointmentSeries, next *domain.AppointmentSeries, from domain.Date) error {
+	args := m.Called(ctx, series, next, from)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentSeriesRepository) ListActive(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.AppointmentSeries, error) {
+	args := m.Called(ctx, centerID, from, to)
+	return args.Get(0).([]*domain.AppointmentSeries), args.Error(1)
+}
+
+func (m *MockAppointmentSeriesRepository) ListOccurrences(ctx context.Context, centerID uuid.UUID, seriesIDs []uuid.UUID, from domain.Date, to domain.Date) ([]*domain.Appointment, error) {
+	args := m.Called(ctx, centerID, seriesIDs, from, to)
+	return args.Get(0).([]*domain.Appointment), args.Error(1)
+}
+
+// newEmptySeriesRepository returns a repository of a center without series
+func newEmptySeriesRepository() *MockAppointmentSeriesRepository {
+	repo := new(MockAppointmentSeriesRepository)
+	repo.On("ListActive", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.AppointmentSeries{}, nil).Maybe()
+	return repo
+}
+
+func TestAppointmentSeriesService_CreateReportsConflicts(t *testing.T) {
+	// Setup
+	mockSeriesRepo := new(MockAppointmentSeriesRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewAppointmentSeriesService(mockSeriesRepo, mockAppointmentRepo, mockHoldRepo, mockCentersRepo, mockAvailabilityRepo, mockTimeBlockRepo, mockMembershipRepo, newOfferingWaitlist(), new(mocks.LoggerMock)).(*AppointmentSeriesServiceImplementation)
+	centerID, staffID, serviceID := uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	// Mondays far enough in the future
+	at := func(day, hour, minute int) time.Time { return time.Date(2035, 1, day, hour, minute, 0, 0, madrid) }
+	blocks := []*domain.TimeBlock{
+		{StartsAt: at(8, 0, 0), EndsAt: at(9, 0, 0), Reason: domain.TimeBlockReasonHoliday},
+	}
+	appointments := []*domain.Appointment{
+		{StaffID: staffID, StartsAt: at(15, 10, 15), EndsAt: at(15, 10, 45), Status: domain.AppointmentStatusConfirmed},
+	}
+	holds := []*domain.SlotHold{
+		{StaffID: uuid.New(), StartsAt: at(22, 10, 0), EndsAt: at(22, 10, 30)},
+		{StaffID: staffID, StartsAt: at(29, 10, 0), EndsAt: at(29, 10, 30)},
+	}
+	input := &domain.CreateAppointmentSeriesInput{
+		StaffID:      staffID,
+		ServiceID:    serviceID,
+		StartsAt:     at(1, 10, 0),
+		RRule:        "FREQ=WEEKLY;COUNT=5",
+		CustomerName: "Jane Doe",
+	}
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Timezone: "Europe/Madrid"}, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockCentersRepo.On("ListOpeningHours", mock.Anything, centerID).Return([]*domain.OpeningHours{}, nil)
+	mockAvailabilityRepo.On("ListByStaff", mock.Anything, centerID, staffID).Return([]*domain.AvailabilityRule{
+		{UserID: staffID, Weekday: time.Monday, StartTime: 9 * 60, EndTime: 12 * 60, IsActive: true},
+	}, nil)
+	mockTimeBlockRepo.On("ListInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return(blocks, nil)
+	mockAppointmentRepo.On("ListBusyInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return(appointments, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, centerID, mock.Anything, mock.Anything, mock.Anything).Return(holds, nil)
+	mockSeriesRepo.On("ListActive", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.AppointmentSeries{}, nil)
+
+	// Execute
+	_, err := service.Create(context.Background(), actor, input)
+
+	// Assert
+	var conflictErr *domain.SeriesConflictError
+	assert.True(t, errors.As(err, &conflictErr))
+	assert.ErrorIs(t, err, exceptions.ErrAppointmentSeriesConflict)
+	assert.Equal(t, []domain.SeriesConflict{
+		{Date: domain.DateOf(at(8, 0, 0)), StartsAt: at(8, 10, 0), EndsAt: at(8, 10, 30), Reason: domain.SeriesConflictUnavailable},
+		{Date: domain.DateOf(at(15, 0, 0)), StartsAt: at(15, 10, 0), EndsAt: at(15, 10, 30), Reason: domain.SeriesConflictBooked},
+		{Date: domain.DateOf(at(29, 0, 0)), StartsAt: at(29, 10, 0), EndsAt: at(29, 10, 30), Reason: domain.SeriesConflictBooked},
+	}, conflictErr.Conflicts)
+	mockSeriesRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAppointmentSeriesService_CreateSkippingConflicts(t *testing.T) {
+	// Setup
+	mockSeriesRepo := new(MockAppointmentSeriesRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewAppointmentSeriesService(mockSeriesRepo, mockAppointmentRepo, mockHoldRepo, mockCentersRepo, mockAvailabilityRepo, mockTimeBlockRepo, mockMembershipRepo, newOfferingWaitlist(), new(mocks.LoggerMock)).(*AppointmentSeriesServiceImplementation)
+	centerID, staffID, serviceID := uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	at := func(day, hour, minute int) time.Time { return time.Date(2035, 1, day, hour, minute, 0, 0, madrid) }
+	blocks := []*domain.TimeBlock{
+		{StartsAt: at(22, 0, 0), EndsAt: at(23, 0, 0), Reason: domain.TimeBlockReasonHoliday},
+	}
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Timezone: "Europe/Madrid"}, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockCentersRepo.On("ListOpeningHours", mock.Anything, centerID).Return([]*domain.OpeningHours{}, nil)
+	mockAvailabilityRepo.On("ListByStaff", mock.Anything, centerID, staffID).Return([]*domain.AvailabilityRule{
+		{UserID: staffID, Weekday: time.Monday, StartTime: 9 * 60, EndTime: 12 * 60, IsActive: true},
+	}, nil)
+	mockTimeBlockRepo.On("ListInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return(blocks, nil)
+	mockAppointmentRepo.On("ListBusyInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.Appointment{}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, centerID, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.SlotHold{}, nil)
+	mockSeriesRepo.On("ListActive", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.AppointmentSeries{}, nil)
+	mockSeriesRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AppointmentSeries")).Return(nil)
+
+	// Execute
+	series, err := service.Create(context.Background(), actor, &domain.CreateAppointmentSeriesInput{
+		StaffID:       staffID,
+		ServiceID:     serviceID,
+		StartsAt:      at(1, 10, 0),
+		RRule:         "FREQ=WEEKLY;COUNT=4",
+		CustomerName:  "Jane Doe",
+		SkipConflicts: true,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Date{domain.DateOf(at(22, 0, 0))}, series.ExDates)
+	assert.Equal(t, 30, series.DurationMinutes)
+	// The last occurrence is excluded, the series ends with the one before
+	assert.Equal(t, at(15, 10, 30), *series.EndsAt)
+	assert.Equal(t, domain.AppointmentStatusRequested, series.Status)
+}
+
+func TestAppointmentSeriesService_CancelOccurrence(t *testing.T) {
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	at := func(day, hour, minute int) time.Time { return time.Date(2035, 1, day, hour, minute, 0, 0, madrid) }
+	centerID, staffID, seriesID := uuid.New(), uuid.New(), uuid.New()
+	lastDay := domain.DateOf(at(22, 0, 0))
+	stored := &domain.Appointment{ID: uuid.New(), SeriesID: &seriesID, OccurrenceDate: &lastDay, StaffID: staffID, StartsAt: at(22, 11, 0), EndsAt: at(22, 11, 30), Status: domain.AppointmentStatusConfirmed}
+
+	tests := []struct {
+		name          string
+		scope         domain.SeriesScope
+		rule          string
+		exDates       []domain.Date
+		endsAt        time.Time
+		cancelsSeries bool
+		cancelsLast   bool
+		// offeredDays are the days of the pending occurrences offered
+		offeredDays []int
+	}{
+		{name: "this", scope: domain.SeriesScopeThis, rule: "FREQ=WEEKLY;COUNT=4", exDates: []domain.Date{domain.DateOf(at(15, 0, 0))}, endsAt: at(22, 10, 30), offeredDays: []int{15}},
+		{name: "following", scope: domain.SeriesScopeFollowing, rule: "FREQ=WEEKLY;COUNT=2", exDates: []domain.Date{}, endsAt: at(8, 10, 30), cancelsLast: true, offeredDays: []int{15}},
+		{name: "all", scope: domain.SeriesScopeAll, rule: "FREQ=WEEKLY;COUNT=4", exDates: []domain.Date{}, cancelsSeries: true, cancelsLast: true, offeredDays: []int{1, 8, 15}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockSeriesRepo := new(MockAppointmentSeriesRepository)
+			mockAppointmentRepo := new(MockAppointmentRepository)
+			mockWaitlistService := newOfferingWaitlist()
+			service := NewAppointmentSeriesService(mockSeriesRepo, mockAppointmentRepo, new(MockSlotHoldRepository), new(MockCentersRepository), new(MockAvailabilityRepository), new(MockTimeBlockRepository), new(MockMembershipRepository), mockWaitlistService, new(mocks.LoggerMock)).(*AppointmentSeriesServiceImplementation)
+			actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+			series := &domain.AppointmentSeries{ID: seriesID, CenterID: centerID, StaffID: staffID, RRule: "FREQ=WEEKLY;COUNT=4", StartsAt: at(1, 10, 0), DurationMinutes: 30, Timezone: "Europe/Madrid", ExDates: []domain.Date{}, Status: domain.AppointmentStatusConfirmed}
+			occurrence := *stored
+
+			// Expectations
+			mockSeriesRepo.On("GetByID", mock.Anything, centerID, seriesID).Return(series, nil)
+			mockSeriesRepo.On("ListOccurrences", mock.Anything, centerID, []uuid.UUID{seriesID}, mock.Anything, mock.Anything).Return([]*domain.Appointment{&occurrence}, nil)
+			mockSeriesRepo.On("Update", mock.Anything, series).Return(nil)
+			mockAppointmentRepo.On("UpdateStatus", mock.Anything, &occurrence, domain.AppointmentStatusConfirmed).Return(nil)
+
+			// Execute
+			err := service.CancelOccurrence(context.Background(), actor, seriesID, domain.DateOf(at(15, 0, 0)), &domain.CancelOccurrenceInput{Scope: tt.scope, Reason: "Moving abroad"})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.rule, series.RRule)
+			assert.Equal(t, tt.exDates, series.ExDates)
+			if tt.cancelsSeries {
+				assert.NotNil(t, series.CancelledAt)
+			} else {
+				assert.Equal(t, tt.endsAt, *series.EndsAt)
+				assert.Nil(t, series.CancelledAt)
+			}
+			if tt.cancelsLast {
+				assert.Equal(t, domain.AppointmentStatusCancelled, occurrence.Status)
+				assert.Equal(t, "Moving abroad", occurrence.CancellationReason)
+				mockWaitlistService.AssertCalled(t, "OfferFreedSlot", mock.Anything, &occurrence)
+			} else {
+				mockAppointmentRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+			}
+			for _, day := range tt.offeredDays {
+				mockWaitlistService.AssertCalled(t, "OfferFreedSlot", mock.Anything, mock.MatchedBy(func(freed *domain.Appointment) bool {
+					return freed.StaffID == staffID && freed.StartsAt.Equal(at(day, 10, 0))
+				}))
+			}
+			// The stored occurrence is only offered at the time it was moved to
+			mockWaitlistService.AssertNotCalled(t, "OfferFreedSlot", mock.Anything, mock.MatchedBy(func(freed *domain.Appointment) bool {
+				return freed.StartsAt.Equal(at(22, 10, 0))
+			}))
+		})
+	}
+}
+
+func TestAppointmentSeriesService_UpdateFollowingSplitsSeries(t *testing.T) {
+	// Setup
+	mockSeriesRepo := new(MockAppointmentSeriesRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewAppointmentSeriesService(mockSeriesRepo, mockAppointmentRepo, mockHoldRepo, mockCentersRepo, mockAvailabilityRepo, mockTimeBlockRepo, mockMembershipRepo, newOfferingWaitlist(), new(mocks.LoggerMock)).(*AppointmentSeriesServiceImplementation)
+	centerID, staffID, serviceID, seriesID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	at := func(day, hour, minute int) time.Time { return time.Date(2035, 1, day, hour, minute, 0, 0, madrid) }
+	exDates := []domain.Date{domain.DateOf(at(8, 0, 0)), domain.DateOf(at(29, 0, 0))}
+	series := &domain.AppointmentSeries{ID: seriesID, CenterID: centerID, StaffID: staffID, ServiceID: serviceID, RRule: "FREQ=WEEKLY", StartsAt: at(1, 10, 0), DurationMinutes: 30, Timezone: "Europe/Madrid", ExDates: exDates, Status: domain.AppointmentStatusConfirmed}
+	newStart := at(15, 11, 0)
+
+	// Expectations
+	mockSeriesRepo.On("GetByID", mock.Anything, centerID, seriesID).Return(series, nil)
+	mockSeriesRepo.On("ListOccurrences", mock.Anything, centerID, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Appointment{}, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockCentersRepo.On("ListOpeningHours", mock.Anything, centerID).Return([]*domain.OpeningHours{}, nil)
+	mockAvailabilityRepo.On("ListByStaff", mock.Anything, centerID, staffID).Return([]*domain.AvailabilityRule{
+		{UserID: staffID, Weekday: time.Monday, StartTime: 9 * 60, EndTime: 12 * 60, IsActive: true},
+	}, nil)
+	mockTimeBlockRepo.On("ListInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.TimeBlock{}, nil)
+	mockAppointmentRepo.On("ListBusyInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.Appointment{}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, centerID, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.SlotHold{}, nil)
+	mockSeriesRepo.On("ListActive", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.AppointmentSeries{}, nil)
+	mockSeriesRepo.On("Split", mock.Anything, series, mock.AnythingOfType("*domain.AppointmentSeries"), domain.DateOf(at(15, 0, 0))).Return(nil)
+
+	// Execute
+	occurrence, err := service.UpdateOccurrence(context.Background(), actor, seriesID, domain.DateOf(at(15, 0, 0)), &domain.UpdateOccurrenceInput{
+		Scope:    domain.SeriesScopeFollowing,
+		StartsAt: &newStart,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	// 10:00 in Madrid is 9:00 UTC in winter
+	assert.Equal(t, "FREQ=WEEKLY;UNTIL=20350115T085959Z", series.RRule)
+	assert.Equal(t, []domain.Date{domain.DateOf(at(8, 0, 0))}, series.ExDates)
+	// The occurrence of the 8th is excluded
+	assert.Equal(t, at(1, 10, 30), *series.EndsAt)
+	var next *domain.AppointmentSeries
+	for _, call := range mockSeriesRepo.Calls {
+		if call.Method == "Split" {
+			next = call.Arguments.Get(2).(*domain.AppointmentSeries)
+		}
+	}
+	assert.Equal(t, "FREQ=WEEKLY", next.RRule)
+	assert.Equal(t, newStart, next.StartsAt)
+	assert.Equal(t, []domain.Date{domain.DateOf(at(29, 0, 0))}, next.ExDates)
+	assert.Nil(t, next.EndsAt)
+	assert.Equal(t, newStart, occurrence.StartsAt)
+}
+
+func TestAppointmentSeriesService_UpdateThisStoresOccurrence(t *testing.T) {
+	// Setup
+	mockSeriesRepo := new(MockAppointmentSeriesRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewAppointmentSeriesService(mockSeriesRepo, mockAppointmentRepo, mockHoldRepo, mockCentersRepo, mockAvailabilityRepo, mockTimeBlockRepo, mockMembershipRepo, newOfferingWaitlist(), new(mocks.LoggerMock)).(*AppointmentSeriesServiceImplementation)
+	centerID, staffID, serviceID, seriesID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	at := func(day, hour, minute int) time.Time { return time.Date(2035, 1, day, hour, minute, 0, 0, madrid) }
+	series := &domain.AppointmentSeries{ID: seriesID, CenterID: centerID, StaffID: staffID, ServiceID: serviceID, RRule: "FREQ=WEEKLY;COUNT=4", StartsAt: at(1, 10, 0), DurationMinutes: 30, Timezone: "Europe/Madrid", ExDates: []domain.Date{}, Status: domain.AppointmentStatusConfirmed, CustomerName: "Jane Doe"}
+	date := domain.DateOf(at(15, 0, 0))
+	newStart := at(15, 11, 0)
+	notes := "Bring the X-rays"
+
+	// Expectations
+	mockSeriesRepo.On("GetByID", mock.Anything, centerID, seriesID).Return(series, nil)
+	mockSeriesRepo.On("ListOccurrences", mock.Anything, centerID, []uuid.UUID{seriesID}, date, date).Return([]*domain.Appointment{}, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockCentersRepo.On("ListOpeningHours", mock.Anything, centerID).Return([]*domain.OpeningHours{}, nil)
+	mockAvailabilityRepo.On("ListByStaff", mock.Anything, centerID, staffID).Return([]*domain.AvailabilityRule{
+		{UserID: staffID, Weekday: time.Monday, StartTime: 9 * 60, EndTime: 12 * 60, IsActive: true},
+	}, nil)
+	mockTimeBlockRepo.On("ListInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.TimeBlock{}, nil)
+	mockAppointmentRepo.On("ListBusyInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.Appointment{}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, centerID, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.SlotHold{}, nil)
+	mockSeriesRepo.On("ListActive", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.AppointmentSeries{}, nil)
+	mockAppointmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Appointment")).Return(nil)
+
+	// Execute
+	occurrence, err := service.UpdateOccurrence(context.Background(), actor, seriesID, date, &domain.UpdateOccurrenceInput{
+		Scope:    domain.SeriesScopeThis,
+		StartsAt: &newStart,
+		Notes:    &notes,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	appointment := mockAppointmentRepo.Calls[len(mockAppointmentRepo.Calls)-1].Arguments.Get(1).(*domain.Appointment)
+	assert.Equal(t, seriesID, *appointment.SeriesID)
+	assert.Equal(t, date, *appointment.OccurrenceDate)
+	assert.Equal(t, newStart, appointment.StartsAt)
+	assert.Equal(t, at(15, 11, 30), appointment.EndsAt)
+	assert.Equal(t, domain.AppointmentStatusConfirmed, appointment.Status)
+	assert.Equal(t, "Jane Doe", appointment.CustomerName)
+	assert.Equal(t, notes, appointment.Notes)
+	assert.Equal(t, appointment, occurrence.Appointment)
+	assert.Equal(t, newStart, occurrence.StartsAt)
+	mockSeriesRepo.AssertNotCalled(t, "Move", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAppointmentSeriesService_UpdateThisEditsStoredOccurrence(t *testing.T) {
+	// Setup
+	mockSeriesRepo := new(MockAppointmentSeriesRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	service := NewAppointmentSeriesService(mockSeriesRepo, mockAppointmentRepo, new(MockSlotHoldRepository), new(MockCentersRepository), new(MockAvailabilityRepository), new(MockTimeBlockRepository), new(MockMembershipRepository), newOfferingWaitlist(), new(mocks.LoggerMock)).(*AppointmentSeriesServiceImplementation)
+	centerID, staffID, serviceID, seriesID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	at := func(day, hour, minute int) time.Time { return time.Date(2035, 1, day, hour, minute, 0, 0, madrid) }
+	series := &domain.AppointmentSeries{ID: seriesID, CenterID: centerID, StaffID: staffID, ServiceID: serviceID, RRule: "FREQ=WEEKLY;COUNT=4", StartsAt: at(1, 10, 0), DurationMinutes: 30, Timezone: "Europe/Madrid", ExDates: []domain.Date{}, Status: domain.AppointmentStatusConfirmed}
+	date := domain.DateOf(at(15, 0, 0))
+	stored := &domain.Appointment{ID: uuid.New(), CenterID: centerID, SeriesID: &seriesID, OccurrenceDate: &date, StaffID: staffID, ServiceID: serviceID, StartsAt: at(15, 11, 0), EndsAt: at(15, 11, 30), Status: domain.AppointmentStatusConfirmed}
+	name := "Janet Doe"
+
+	// Expectations
+	mockSeriesRepo.On("GetByID", mock.Anything, centerID, seriesID).Return(series, nil)
+	mockSeriesRepo.On("ListOccurrences", mock.Anything, centerID, []uuid.UUID{seriesID}, date, date).Return([]*domain.Appointment{stored}, nil)
+	mockAppointmentRepo.On("Update", mock.Anything, stored).Return(nil)
+
+	// Execute
+	occurrence, err := service.UpdateOccurrence(context.Background(), actor, seriesID, date, &domain.UpdateOccurrenceInput{
+		Scope:        domain.SeriesScopeThis,
+		CustomerName: &name,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, name, stored.CustomerName)
+	// The time the occurrence was moved to is kept
+	assert.Equal(t, at(15, 11, 0), occurrence.StartsAt)
+	mockAppointmentRepo.AssertExpectations(t)
+	mockAppointmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockSeriesRepo.AssertNotCalled(t, "Move", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAppointmentSeriesService_UpdateAllMovesStoredOccurrences(t *testing.T) {
+	// Setup
+	mockSeriesRepo := new(MockAppointmentSeriesRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewAppointmentSeriesService(mockSeriesRepo, mockAppointmentRepo, mockHoldRepo, mockCentersRepo, mockAvailabilityRepo, mockTimeBlockRepo, mockMembershipRepo, newOfferingWaitlist(), new(mocks.LoggerMock)).(*AppointmentSeriesServiceImplementation)
+	centerID, staffID, serviceID, seriesID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	at := func(day, hour, minute int) time.Time { return time.Date(2035, 1, day, hour, minute, 0, 0, madrid) }
+	series := &domain.AppointmentSeries{ID: seriesID, CenterID: centerID, StaffID: staffID, ServiceID: serviceID, RRule: "FREQ=WEEKLY;COUNT=4", StartsAt: at(1, 10, 0), DurationMinutes: 30, Timezone: "Europe/Madrid", ExDates: []domain.Date{domain.DateOf(at(22, 0, 0))}, Status: domain.AppointmentStatusConfirmed}
+	editedDate := domain.DateOf(at(15, 0, 0))
+	stored := &domain.Appointment{ID: uuid.New(), CenterID: centerID, SeriesID: &seriesID, OccurrenceDate: &editedDate, StaffID: staffID, ServiceID: serviceID, StartsAt: at(15, 11, 0), EndsAt: at(15, 11, 30), Status: domain.AppointmentStatusConfirmed}
+	// The Tuesday the edited occurrence moves to is a holiday, it only
+	// conflicts when the occurrence is taken for a pending one
+	blocks := []*domain.TimeBlock{
+		{StartsAt: at(16, 0, 0), EndsAt: at(17, 0, 0), Reason: domain.TimeBlockReasonHoliday},
+	}
+	newStart := at(2, 10, 0)
+
+	// Expectations
+	mockSeriesRepo.On("GetByID", mock.Anything, centerID, seriesID).Return(series, nil)
+	mockSeriesRepo.On("ListOccurrences", mock.Anything, centerID, []uuid.UUID{seriesID}, mock.Anything, mock.Anything).Return([]*domain.Appointment{stored}, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(actor, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockCentersRepo.On("ListOpeningHours", mock.Anything, centerID).Return([]*domain.OpeningHours{}, nil)
+	mockAvailabilityRepo.On("ListByStaff", mock.Anything, centerID, staffID).Return([]*domain.AvailabilityRule{
+		{UserID: staffID, Weekday: time.Tuesday, StartTime: 9 * 60, EndTime: 12 * 60, IsActive: true},
+	}, nil)
+	mockTimeBlockRepo.On("ListInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return(blocks, nil)
+	mockAppointmentRepo.On("ListBusyInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.Appointment{stored}, nil)
+	mockSeriesRepo.On("ListActive", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.AppointmentSeries{}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, centerID, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.SlotHold{}, nil)
+	mockSeriesRepo.On("Move", mock.Anything, series, 1).Return(nil)
+
+	// Execute
+	occurrence, err := service.UpdateOccurrence(context.Background(), actor, seriesID, domain.DateOf(at(1, 0, 0)), &domain.UpdateOccurrenceInput{
+		Scope:    domain.SeriesScopeAll,
+		StartsAt: &newStart,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, newStart, series.StartsAt)
+	assert.Equal(t, []domain.Date{domain.DateOf(at(23, 0, 0))}, series.ExDates)
+	assert.Equal(t, newStart, occurrence.StartsAt)
+	mockSeriesRepo.AssertExpectations(t)
+	mockSeriesRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+)
+
+type AppointmentServiceImplementation struct {
+	appointmentRepo ports.AppointmentRepository
+	scheduler       *appointmentScheduler
+	waitlistService ports.WaitlistService
+	reviewService   ports.ReviewService
+	logger          ports.Logger
+}
+
+func NewAppointmentService(appointmentRepo ports.AppointmentRepository, seriesRepo ports.AppointmentSeriesRepository, holdRepo ports.SlotHoldRepository, centersRepo ports.CentersRepository, membershipRepo ports.MembershipRepository, waitlistService ports.WaitlistService, reviewService ports.ReviewService, logger ports.Logger) ports.AppointmentService {
+	return &AppointmentServiceImplementation{
+		appointmentRepo: appointmentRepo,
+		scheduler: &appointmentScheduler{
+			centersRepo:    centersRepo,
+			membershipRepo: membershipRepo,
+			seriesRepo:     seriesRepo,
+			holdRepo:       holdRepo,
+		},
+		waitlistService: waitlistService,
+		reviewService:   reviewService,
+		logger:          logger,
+	}
+}
+
+// appointmentScheduler checks where appointments can be booked, it is shared
+// with the series service, whose occurrences are appointments once edited
+type appointmentScheduler struct {
+	centersRepo    ports.CentersRepository
+	membershipRepo ports.MembershipRepository
+	seriesRepo     ports.AppointmentSeriesRepository
+	holdRepo       ports.SlotHoldRepository
+}
+
+// authorizeAppointment lets members manage the appointments of the staff
+// member when their role allows it, or when the appointments are their own
+func authorizeAppointment(actor *domain.CenterMembership, staffID uuid.UUID) error {
+	if actor.UserID == staffID || actor.Role.Can(domain.PermissionAppointmentsManage) {
+		return nil
+	}
+	return exceptions.ErrCenterPermissionDenied
+}
+
+// offerFreedSlot offers the time the appointment no longer takes to the
+// waitlist, the change stands whether or not it is offered
+func offerFreedSlot(ctx context.Context, waitlistService ports.WaitlistService, logger ports.Logger, appointment *domain.Appointment) {
+	err := waitlistService.OfferFreedSlot(ctx, appointment)
+	if err != nil {
+		logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"appointment_id": appointment.ID.String(),
+		})
+	}
+}
+
+func (s *AppointmentServiceImplementation) List(ctx context.Context, centerID uuid.UUID, filter *domain.AppointmentFilter) ([]*domain.Appointment, error) {
+	if filter.Status != nil && !filter.Status.IsValid() {
+		return nil, exceptions.ErrAppointmentInvalidStatus
+	}
+	return s.appointmentRepo.List(ctx, centerID, filter)
+}
+
+func (s *AppointmentServiceImplementation) Get(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Appointment, error) {
+	return s.appointmentRepo.GetByID(ctx, centerID, id)
+}
+
+func (s *AppointmentServiceImplementation) Create(ctx context.Context, actor *domain.CenterMembership, input *domain.CreateAppointmentInput) (*domain.Appointment, error) {
+	status := input.Status
+	if status == "" {
+		status = domain.AppointmentStatusRequested
+	}
+	if status != domain.AppointmentStatusRequested && status != domain.AppointmentStatusConfirmed {
+		return nil, exceptions.ErrAppointmentInvalidStatus
+	}
+
+	err := authorizeAppointment(actor, input.StaffID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment := &domain.Appointment{
+		CenterID:      actor.CenterID,
+		StaffID:       input.StaffID,
+		ServiceID:     input.ServiceID,
+		ResourceID:    input.ResourceID,
+		StartsAt:      input.StartsAt,
+		Status:        domain.AppointmentStatusRequested,
+		CustomerName:  input.CustomerName,
+		CustomerEmail: strings.ToLower(input.CustomerEmail),
+		CustomerPhone: input.CustomerPhone,
+		Notes:         input.Notes,
+		CreatedBy:     &actor.UserID,
+	}
+	err = s.scheduler.schedule(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+	if status == domain.AppointmentStatusConfirmed {
+		appointment.SetStatus(status, time.Now())
+	}
+
+	err = s.appointmentRepo.Create(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+func (s *AppointmentServiceImplementation) Update(ctx context.Context, actor *domain.CenterMembership, id uuid.UUID, input *domain.UpdateAppointmentInput) (*domain.Appointment, error) {
+	appointment, err := s.appointmentRepo.GetByID(ctx, actor.CenterID, id)
+	if err != nil {
+		return nil, err
+	}
+	err = authorizeAppointment(actor, appointment.StaffID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *appointment
+	err = s.scheduler.edit(ctx, actor, appointment, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.appointmentRepo.Update(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+	if rescheduled(&previous, appointment) {
+		offerFreedSlot(ctx, s.waitlistService, s.logger, &previous)
+	}
+	return appointment, nil
+}
+
+// rescheduled tells whether the appointment left the time of its staff
+// member it took before
+func rescheduled(previous *domain.Appointment, appointment *domain.Appointment) bool {
+	return previous.StaffID != appointment.StaffID || !previous.StartsAt.Equal(appointment.StartsAt)
+}
+
+func (s *AppointmentServiceImplementation) ChangeStatus(ctx context.Context, actor *domain.CenterMembership, id uuid.UUID, input *domain.AppointmentStatusInput) (*domain.Appointment, error) {
+	if !input.Status.IsValid() {
+		return nil, exceptions.ErrAppointmentInvalidStatus
+	}
+
+	appointment, err := s.appointmentRepo.GetByID(ctx, actor.CenterID, id)
+	if err != nil {
+		return nil, err
+	}
+	err = authorizeAppointment(actor, appointment.StaffID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := appointment.Status
+	if !from.CanTransitionTo(input.Status) {
+		return nil, &domain.AppointmentTransitionError{Err: exceptions.ErrAppointmentInvalidTransition, From: from, To: input.Status}
+	}
+
+	appointment.SetStatus(input.Status, time.Now())
+	if input.Status == domain.AppointmentStatusCancelled {
+		appointment.CancellationReason = input.Reason
+	}
+
+	err = s.appointmentRepo.UpdateStatus(ctx, appointment, from)
+	if err != nil {
+		return nil, err
+	}
+
+	// The status change stands whether or not the freed slot is offered or
+	// the review requested
+	switch input.Status {
+	case domain.AppointmentStatusCancelled:
+		err = s.waitlistService.OfferFreedSlot(ctx, appointment)
+	case domain.AppointmentStatusCompleted:
+		err = s.reviewService.RequestReview(ctx, appointment)
+	}
+	if err != nil {
+		s.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"appointment_id": appointment.ID.String(),
+		})
+	}
+	return appointment, nil
+}
+
+// edit changes the fields of the appointment set in the input, and schedules
+// it again when it moves
+func (s *appointmentScheduler) edit(ctx context.Context, actor *domain.CenterMembership, appointment *domain.Appointment, input *domain.UpdateAppointmentInput) error {
+	if input.StaffID != nil || input.ServiceID != nil || input.ResourceID != nil || input.StartsAt != nil {
+		if appointment.Status != domain.AppointmentStatusRequested && appointment.Status != domain.AppointmentStatusConfirmed {
+			return exceptions.ErrAppointmentNotReschedulable
+		}
+		if input.StaffID != nil {
+			err := authorizeAppointment(actor, *input.StaffID)
+			if err != nil {
+				return err
+			}
+			appointment.StaffID = *input.StaffID
+		}
+		if input.ServiceID != nil {
+			appointment.ServiceID = *input.ServiceID
+		}
+		if input.ResourceID != nil {
+			appointment.ResourceID = input.ResourceID
+		}
+		if input.StartsAt != nil {
+			appointment.StartsAt = *input.StartsAt
+		}
+		err := s.schedule(ctx, appointment)
+		if err != nil {
+			return err
+		}
+	}
+
+	if input.CustomerName != nil {
+		appointment.CustomerName = *input.CustomerName
+	}
+	if input.CustomerEmail != nil {
+		appointment.CustomerEmail = strings.ToLower(*input.CustomerEmail)
+	}
+	if input.CustomerPhone != nil {
+		appointment.CustomerPhone = *input.CustomerPhone
+	}
+	if input.Notes != nil {
+		appointment.Notes = *input.Notes
+	}
+	return nil
+}
+
+// schedule checks the staff member, the service and the resource of the
+// appointment, and makes it last as long as the service. The repository
+// rejects overlaps with stored appointments, the occurrences of series that
+// are not stored and the active holds are checked here.
+func (s *appointmentScheduler) schedule(ctx context.Context, appointment *domain.Appointment) error {
+	return s.scheduleHeld(ctx, appointment, uuid.Nil)
+}
+
+// scheduleHeld schedules the appointment booked from a hold, which does not
+// count as busy for it
+func (s *appointmentScheduler) scheduleHeld(ctx context.Context, appointment *domain.Appointment, holdID uuid.UUID) error {
+	_, err := s.membershipRepo.GetByCenterAndUser(ctx, appointment.CenterID, appointment.StaffID)
+	if err != nil {
+		return err
+	}
+
+	service, err := s.centersRepo.GetService(ctx, appointment.CenterID, appointment.ServiceID)
+	if err != nil {
+		return err
+	}
+	if !service.IsActive {
+		return exceptions.ErrCenterServiceNotFound
+	}
+
+	if appointment.ResourceID != nil {
+		resource, err := s.centersRepo.GetResource(ctx, appointment.CenterID, *appointment.ResourceID)
+		if err != nil {
+			return err
+		}
+		if !resource.IsActive {
+			return exceptions.ErrCenterResourceNotFound
+		}
+	}
+
+	appointment.EndsAt = appointment.StartsAt.Add(time.Duration(service.DurationMinutes) * time.Minute)
+
+	pending, err := pendingOccurrences(ctx, s.seriesRepo, appointment.CenterID, appointment.StartsAt, appointment.EndsAt)
+	if err != nil {
+		return err
+	}
+	for _, occurrence := range pending {
+		if appointment.SeriesID != nil && occurrence.SeriesID == *appointment.SeriesID && occurrence.Date == *appointment.OccurrenceDate {
+			continue
+		}
+		if occurrence.StaffID == appointment.StaffID {
+			return exceptions.StaffDoubleBooked()
+		}
+		if sameResource(occurrence.ResourceID, appointment.ResourceID) {
+			return exceptions.ResourceDoubleBooked()
+		}
+	}
+
+	holds, err := s.holdRepo.ListActiveInRange(ctx, appointment.CenterID, appointment.StartsAt, appointment.EndsAt, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, hold := range holds {
+		if hold.ID != holdID && hold.StaffID == appointment.StaffID {
+			return exceptions.StaffDoubleBooked()
+		}
+	}
+	return nil
+}
+
+func sameResource(a *uuid.UUID, b *uuid.UUID) bool {
+	return a != nil && b != nil && *a == *b
+}
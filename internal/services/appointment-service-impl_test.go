@@ -0,0 +1,439 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAppointmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAppointmentRepository) Create(ctx context.Context, appointment *domain.Appointment) error {
+	args := m.Called(ctx, appointment)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Appointment, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.Appointment), args.Error(1)
+}
+
+func (m *MockAppointmentRepository) List(ctx context.Context, centerID uuid.UUID, filter *domain.AppointmentFilter) ([]*domain.Appointment, error) {
+	args := m.Called(ctx, centerID, filter)
+	return args.Get(0).([]*domain.Appointment), args.Error(1)
+}
+
+func (m *MockAppointmentRepository) Update(ctx context.Context, appointment *domain.Appointment) error {
+	args := m.Called(ctx, appointment)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentRepository) RevokeLinks(ctx context.Context, appointment *domain.Appointment) error {
+	args := m.Called(ctx, appointment)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentRepository) UpdateStatus(ctx context.Context, appointment *domain.Appointment, from domain.AppointmentStatus) error {
+	args := m.Called(ctx, appointment, from)
+	return args.Error(0)
+}
+
+func (m *MockAppointmentRepository) ListBusyInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.Appointment, error) {
+	args := m.Called(ctx, centerID, from, to)
+	return args.Get(0).([]*domain.Appointment), args.Error(1)
+}
+
+type MockWaitlistService struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistService) List(ctx context.Context, centerID uuid.UUID, filter *domain.WaitlistFilter) ([]*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, centerID, filter)
+	return args.Get(0).([]*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistService) Create(ctx context.Context, centerID uuid.UUID, input *domain.CreateWaitlistEntryInput) (*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, centerID, input)
+	return args.Get(0).(*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistService) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	args := m.Called(ctx, centerID, id)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistService) Join(ctx context.Context, slug string, input *domain.JoinWaitlistInput, userAgent, ipAddress string) (*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, slug, input, userAgent, ipAddress)
+	return args.Get(0).(*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistService) GetOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) (*domain.WaitlistOffer, error) {
+	args := m.Called(ctx, slug, offerID, offerToken)
+	return args.Get(0).(*domain.WaitlistOffer), args.Error(1)
+}
+
+func (m *MockWaitlistService) ClaimOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) (*domain.Booking, error) {
+	args := m.Called(ctx, slug, offerID, offerToken)
+	return args.Get(0).(*domain.Booking), args.Error(1)
+}
+
+func (m *MockWaitlistService) DeclineOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) error {
+	args := m.Called(ctx, slug, offerID, offerToken)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistService) OfferFreedSlot(ctx context.Context, appointment *domain.Appointment) error {
+	args := m.Called(ctx, appointment)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistService) ExpireOffers(ctx context.Context, now time.Time) error {
+	args := m.Called(ctx, now)
+	return args.Error(0)
+}
+
+// newOfferingWaitlist returns a waitlist that accepts every freed slot
+func newOfferingWaitlist() *MockWaitlistService {
+	waitlist := new(MockWaitlistService)
+	waitlist.On("OfferFreedSlot", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return waitlist
+}
+
+type MockReviewService struct {
+	mock.Mock
+}
+
+func (m *MockReviewService) List(ctx context.Context, centerID uuid.UUID, filter *domain.ReviewFilter) ([]*domain.AppointmentReview, error) {
+	args := m.Called(ctx, centerID, filter)
+	return args.Get(0).([]*domain.AppointmentReview), args.Error(1)
+}
+
+func (m *MockReviewService) Moderate(ctx context.Context, actor *domain.CenterMembership, id uuid.UUID, input *domain.ModerateReviewInput) (*domain.AppointmentReview, error) {
+	args := m.Called(ctx, actor, id, input)
+	return args.Get(0).(*domain.AppointmentReview), args.Error(1)
+}
+
+func (m *MockReviewService) IssueLink(ctx context.Context, actor *domain.CenterMembership, appointmentID uuid.UUID) (*domain.ReviewLink, error) {
+	args := m.Called(ctx, actor, appointmentID)
+	return args.Get(0).(*domain.ReviewLink), args.Error(1)
+}
+
+func (m *MockReviewService) RequestReview(ctx context.Context, appointment *domain.Appointment) error {
+	args := m.Called(ctx, appointment)
+	return args.Error(0)
+}
+
+func (m *MockReviewService) GetReviewable(ctx context.Context, linkToken string) (*domain.ReviewableAppointment, error) {
+	args := m.Called(ctx, linkToken)
+	return args.Get(0).(*domain.ReviewableAppointment), args.Error(1)
+}
+
+func (m *MockReviewService) Submit(ctx context.Context, linkToken string, input *domain.SubmitReviewInput) (*domain.AppointmentReview, error) {
+	args := m.Called(ctx, linkToken, input)
+	return args.Get(0).(*domain.AppointmentReview), args.Error(1)
+}
+
+func (m *MockReviewService) ListPublished(ctx context.Context, slug string, filter *domain.ReviewFilter) ([]*domain.PublicReview, error) {
+	args := m.Called(ctx, slug, filter)
+	return args.Get(0).([]*domain.PublicReview), args.Error(1)
+}
+
+func (m *MockReviewService) GetStats(ctx context.Context, slug string) (*domain.ReviewStats, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(*domain.ReviewStats), args.Error(1)
+}
+
+// newRequestingReviews returns a review service that requests the review of
+// every completed appointment
+func newRequestingReviews() *MockReviewService {
+	reviews := new(MockReviewService)
+	reviews.On("RequestReview", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return reviews
+}
+
+func TestAppointmentService_Create(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, mockMembershipRepo, newOfferingWaitlist(), newRequestingReviews(), new(mocks.LoggerMock))
+	centerID, staffID, serviceID := uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: uuid.New(), Role: domain.CenterRoleReceptionist}
+	startsAt := time.Date(2035, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// Expectations
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 45, IsActive: true}, nil)
+	mockAppointmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Appointment")).Return(nil)
+
+	// Execute
+	appointment, err := service.Create(context.Background(), actor, &domain.CreateAppointmentInput{
+		StaffID:      staffID,
+		ServiceID:    serviceID,
+		StartsAt:     startsAt,
+		Status:       domain.AppointmentStatusConfirmed,
+		CustomerName: "Jane Doe",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, startsAt.Add(45*time.Minute), appointment.EndsAt)
+	assert.Equal(t, domain.AppointmentStatusConfirmed, appointment.Status)
+	assert.NotNil(t, appointment.ConfirmedAt)
+	assert.Equal(t, actor.UserID, *appointment.CreatedBy)
+	mockAppointmentRepo.AssertExpectations(t)
+}
+
+func TestAppointmentService_CreateDoubleBooked(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, mockMembershipRepo, newOfferingWaitlist(), newRequestingReviews(), new(mocks.LoggerMock))
+	centerID, staffID, serviceID := uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	overlap := exceptions.StaffDoubleBooked()
+
+	// Expectations
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(actor, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockAppointmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Appointment")).Return(overlap)
+
+	// Execute
+	_, err := service.Create(context.Background(), actor, &domain.CreateAppointmentInput{StaffID: staffID, ServiceID: serviceID, StartsAt: time.Now(), CustomerName: "Jane Doe"})
+
+	// Assert
+	var domainErr *domain.DomainError
+	assert.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, http.StatusConflict, domainErr.HTTPCode)
+	assert.Equal(t, "STAFF_DOUBLE_BOOKED", domainErr.HTTPErrorBody.Code)
+	assert.ErrorIs(t, err, exceptions.ErrAppointmentStaffOverlap)
+}
+
+func TestAppointmentService_CreateOverActiveHold(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), mockHoldRepo, mockCentersRepo, mockMembershipRepo, newOfferingWaitlist(), newRequestingReviews(), new(mocks.LoggerMock))
+	centerID, staffID, serviceID := uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: uuid.New(), Role: domain.CenterRoleReceptionist}
+	startsAt := time.Date(2035, 1, 1, 9, 0, 0, 0, time.UTC)
+	hold := &domain.SlotHold{ID: uuid.New(), CenterID: centerID, StaffID: staffID, StartsAt: startsAt.Add(15 * time.Minute), EndsAt: startsAt.Add(45 * time.Minute)}
+
+	// Expectations
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, centerID, startsAt, startsAt.Add(30*time.Minute), mock.Anything).Return([]*domain.SlotHold{
+		{ID: uuid.New(), CenterID: centerID, StaffID: uuid.New(), StartsAt: startsAt, EndsAt: startsAt.Add(30 * time.Minute)},
+		hold,
+	}, nil)
+
+	// Execute
+	_, err := service.Create(context.Background(), actor, &domain.CreateAppointmentInput{StaffID: staffID, ServiceID: serviceID, StartsAt: startsAt, CustomerName: "Jane Doe"})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrAppointmentStaffOverlap)
+	mockAppointmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAppointmentService_CreateWithInactiveResource(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, mockMembershipRepo, newOfferingWaitlist(), newRequestingReviews(), new(mocks.LoggerMock))
+	centerID, staffID, serviceID, resourceID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+
+	// Expectations
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(actor, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockCentersRepo.On("GetResource", mock.Anything, centerID, resourceID).Return(&domain.CenterResource{ID: resourceID, CenterID: centerID, IsActive: false}, nil)
+
+	// Execute
+	_, err := service.Create(context.Background(), actor, &domain.CreateAppointmentInput{StaffID: staffID, ServiceID: serviceID, ResourceID: &resourceID, StartsAt: time.Now(), CustomerName: "Jane Doe"})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrCenterResourceNotFound)
+	mockAppointmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAppointmentService_CreateForOtherStaffWithoutPermission(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), new(MockCentersRepository), new(MockMembershipRepository), newOfferingWaitlist(), newRequestingReviews(), new(mocks.LoggerMock))
+	actor := &domain.CenterMembership{CenterID: uuid.New(), UserID: uuid.New(), Role: domain.CenterRoleStaff}
+
+	// Execute
+	_, err := service.Create(context.Background(), actor, &domain.CreateAppointmentInput{StaffID: uuid.New(), ServiceID: uuid.New(), StartsAt: time.Now(), CustomerName: "Jane Doe"})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrCenterPermissionDenied)
+	mockAppointmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAppointmentService_ChangeStatus(t *testing.T) {
+	tests := []struct {
+		from  domain.AppointmentStatus
+		to    domain.AppointmentStatus
+		valid bool
+	}{
+		{domain.AppointmentStatusRequested, domain.AppointmentStatusConfirmed, true},
+		{domain.AppointmentStatusRequested, domain.AppointmentStatusCancelled, true},
+		{domain.AppointmentStatusRequested, domain.AppointmentStatusCheckedIn, false},
+		{domain.AppointmentStatusRequested, domain.AppointmentStatusNoShow, false},
+		{domain.AppointmentStatusConfirmed, domain.AppointmentStatusCheckedIn, true},
+		{domain.AppointmentStatusConfirmed, domain.AppointmentStatusCancelled, true},
+		{domain.AppointmentStatusConfirmed, domain.AppointmentStatusNoShow, true},
+		{domain.AppointmentStatusConfirmed, domain.AppointmentStatusCompleted, false},
+		{domain.AppointmentStatusCheckedIn, domain.AppointmentStatusCompleted, true},
+		{domain.AppointmentStatusCheckedIn, domain.AppointmentStatusCancelled, false},
+		{domain.AppointmentStatusCompleted, domain.AppointmentStatusCancelled, false},
+		{domain.AppointmentStatusCancelled, domain.AppointmentStatusConfirmed, false},
+		{domain.AppointmentStatusNoShow, domain.AppointmentStatusCheckedIn, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			// Setup
+			mockAppointmentRepo := new(MockAppointmentRepository)
+			service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), new(MockCentersRepository), new(MockMembershipRepository), newOfferingWaitlist(), newRequestingReviews(), new(mocks.LoggerMock))
+			centerID := uuid.New()
+			actor := &domain.CenterMembership{CenterID: centerID, UserID: uuid.New(), Role: domain.CenterRoleAdmin}
+			appointment := &domain.Appointment{ID: uuid.New(), CenterID: centerID, StaffID: uuid.New(), Status: tt.from}
+
+			// Expectations
+			mockAppointmentRepo.On("GetByID", mock.Anything, centerID, appointment.ID).Return(appointment, nil)
+			mockAppointmentRepo.On("UpdateStatus", mock.Anything, appointment, tt.from).Return(nil)
+
+			// Execute
+			updated, err := service.ChangeStatus(context.Background(), actor, appointment.ID, &domain.AppointmentStatusInput{Status: tt.to})
+
+			// Assert
+			if tt.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, updated.Status)
+			} else {
+				var transitionErr *domain.AppointmentTransitionError
+				assert.True(t, errors.As(err, &transitionErr))
+				assert.ErrorIs(t, err, exceptions.ErrAppointmentInvalidTransition)
+				assert.Equal(t, tt.from, transitionErr.From)
+				mockAppointmentRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAppointmentService_CancelRecordsReasonAndTime(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockWaitlist := newOfferingWaitlist()
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), new(MockCentersRepository), new(MockMembershipRepository), mockWaitlist, newRequestingReviews(), new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	appointment := &domain.Appointment{ID: uuid.New(), CenterID: centerID, StaffID: staffID, Status: domain.AppointmentStatusConfirmed}
+
+	// Expectations
+	mockAppointmentRepo.On("GetByID", mock.Anything, centerID, appointment.ID).Return(appointment, nil)
+	mockAppointmentRepo.On("UpdateStatus", mock.Anything, appointment, domain.AppointmentStatusConfirmed).Return(nil)
+
+	// Execute
+	updated, err := service.ChangeStatus(context.Background(), actor, appointment.ID, &domain.AppointmentStatusInput{Status: domain.AppointmentStatusCancelled, Reason: "Sick"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Sick", updated.CancellationReason)
+	assert.NotNil(t, updated.CancelledAt)
+	mockWaitlist.AssertCalled(t, "OfferFreedSlot", mock.Anything, appointment)
+}
+
+func TestAppointmentService_CompleteRequestsReview(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockReviews := new(MockReviewService)
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), new(MockCentersRepository), new(MockMembershipRepository), newOfferingWaitlist(), mockReviews, new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	appointment := &domain.Appointment{ID: uuid.New(), CenterID: centerID, StaffID: staffID, Status: domain.AppointmentStatusCheckedIn}
+
+	// Expectations
+	mockAppointmentRepo.On("GetByID", mock.Anything, centerID, appointment.ID).Return(appointment, nil)
+	mockAppointmentRepo.On("UpdateStatus", mock.Anything, appointment, domain.AppointmentStatusCheckedIn).Return(nil)
+	mockReviews.On("RequestReview", mock.Anything, appointment).Return(errors.New("smtp unavailable"))
+
+	// Execute
+	updated, err := service.ChangeStatus(context.Background(), actor, appointment.ID, &domain.AppointmentStatusInput{Status: domain.AppointmentStatusCompleted})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AppointmentStatusCompleted, updated.Status)
+	assert.NotNil(t, updated.CompletedAt)
+	mockReviews.AssertExpectations(t)
+}
+
+func TestAppointmentService_RescheduleOffersFreedSlot(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	mockWaitlist := newOfferingWaitlist()
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), mockCentersRepo, mockMembershipRepo, mockWaitlist, newRequestingReviews(), new(mocks.LoggerMock))
+	centerID, staffID, serviceID := uuid.New(), uuid.New(), uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+	startsAt := time.Date(2035, 1, 1, 9, 0, 0, 0, time.UTC)
+	appointment := &domain.Appointment{ID: uuid.New(), CenterID: centerID, StaffID: staffID, ServiceID: serviceID, StartsAt: startsAt, EndsAt: startsAt.Add(30 * time.Minute), Status: domain.AppointmentStatusConfirmed}
+	newStart := startsAt.Add(2 * time.Hour)
+	notes := "Running late"
+
+	// Expectations
+	mockAppointmentRepo.On("GetByID", mock.Anything, centerID, appointment.ID).Return(appointment, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(actor, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockAppointmentRepo.On("Update", mock.Anything, appointment).Return(nil)
+
+	// Execute
+	_, err := service.Update(context.Background(), actor, appointment.ID, &domain.UpdateAppointmentInput{StartsAt: &newStart})
+	assert.NoError(t, err)
+	_, err = service.Update(context.Background(), actor, appointment.ID, &domain.UpdateAppointmentInput{Notes: &notes})
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, newStart, appointment.StartsAt)
+	mockWaitlist.AssertNumberOfCalls(t, "OfferFreedSlot", 1)
+	mockWaitlist.AssertCalled(t, "OfferFreedSlot", mock.Anything, mock.MatchedBy(func(freed *domain.Appointment) bool {
+		return freed.ID == appointment.ID && freed.StartsAt.Equal(startsAt)
+	}))
+}
+
+func TestAppointmentService_RescheduleCheckedIn(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	service := NewAppointmentService(mockAppointmentRepo, newEmptySeriesRepository(), newEmptyHoldRepository(), new(MockCentersRepository), new(MockMembershipRepository), newOfferingWaitlist(), newRequestingReviews(), new(mocks.LoggerMock))
+	centerID := uuid.New()
+	actor := &domain.CenterMembership{CenterID: centerID, UserID: uuid.New(), Role: domain.CenterRoleOwner}
+	appointment := &domain.Appointment{ID: uuid.New(), CenterID: centerID, StaffID: uuid.New(), Status: domain.AppointmentStatusCheckedIn}
+	startsAt := time.Now().Add(time.Hour)
+
+	// Expectations
+	mockAppointmentRepo.On("GetByID", mock.Anything, centerID, appointment.ID).Return(appointment, nil)
+
+	// Execute
+	_, err := service.Update(context.Background(), actor, appointment.ID, &domain.UpdateAppointmentInput{StartsAt: &startsAt})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrAppointmentNotReschedulable)
+	mockAppointmentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
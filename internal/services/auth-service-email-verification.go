@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/utils/random"
+)
+
+// sendVerificationEmail issues a new verification token for the user,
+// invalidating previous ones, and emails the verification link
+func (uc *AuthServiceImplementation) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	err := uc.accountTokenRepo.DeleteByUserID(ctx, user.ID, domain.AccountTokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	verificationToken := random.GenerateRandomString(64)
+	err = uc.accountTokenRepo.Create(ctx, &domain.AccountToken{
+		UserID:    user.ID,
+		Purpose:   domain.AccountTokenPurposeEmailVerification,
+		TokenHash: uc.hashAccountToken(verificationToken),
+		ExpiresAt: time.Now().Add(uc.accountConfig.EmailVerificationExpiry),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", uc.accountConfig.AppURL, url.QueryEscape(verificationToken))
+	return uc.mailer.Send(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+			user.FirstName, link, uc.accountConfig.EmailVerificationExpiry,
+		),
+	})
+}
+
+func (uc *AuthServiceImplementation) VerifyEmail(ctx context.Context, verificationToken string) error {
+	consumed, err := uc.consumeAccountToken(ctx, domain.AccountTokenPurposeEmailVerification, verificationToken)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrAccountTokenInvalid) {
+			return exceptions.ErrAuthInvalidVerifyToken
+		}
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, consumed.UserID)
+	if err != nil {
+		return exceptions.ErrAuthInvalidVerifyToken
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	return uc.userRepo.Update(ctx, user)
+}
+
+// ResendVerification sends a new verification email. Unknown and already
+// verified emails are ignored silently so accounts cannot be enumerated.
+func (uc *AuthServiceImplementation) ResendVerification(ctx context.Context, email string) error {
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	err = uc.sendVerificationEmail(ctx, user)
+	if err != nil {
+		uc.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"user_id": user.ID.String(),
+		})
+		return err
+	}
+	return nil
+}
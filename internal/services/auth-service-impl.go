@@ -15,29 +15,101 @@ import (
 	"github.com/google/uuid"
 )
 
+// accountTokenKeyPurpose derives the key of the account tokens and the
+// recovery codes from the refresh token secret
+const accountTokenKeyPurpose = "account-tokens"
+
 type AuthServiceImplementation struct {
-	userRepo   ports.UserRepository
-	sourceRepo ports.SourceRepository
-	jwtConfig  domain.JWTConfig
-	logger     ports.Logger
+	userRepo          ports.UserRepository
+	sourceRepo        ports.SourceRepository
+	accountTokenRepo  ports.AccountTokenRepository
+	recoveryCodeRepo  ports.RecoveryCodeRepository
+	loginThrottleRepo ports.LoginThrottleRepository
+	geoIPResolver     ports.GeoIPResolver
+	mailer            ports.Mailer
+	jwtConfig         domain.JWTConfig
+	signingKeys       *token.KeySet
+	accountConfig     domain.AccountConfig
+	logger            ports.Logger
 }
 
 func NewAuthService(
 	userRepo ports.UserRepository,
 	sourceRepo ports.SourceRepository,
+	accountTokenRepo ports.AccountTokenRepository,
+	recoveryCodeRepo ports.RecoveryCodeRepository,
+	loginThrottleRepo ports.LoginThrottleRepository,
+	geoIPResolver ports.GeoIPResolver,
+	mailer ports.Mailer,
 	jwtConfig domain.JWTConfig,
+	signingKeys *token.KeySet,
+	accountConfig domain.AccountConfig,
 	logger ports.Logger,
 ) ports.AuthService {
 	return &AuthServiceImplementation{
-		userRepo:   userRepo,
-		sourceRepo: sourceRepo,
-		jwtConfig:  jwtConfig,
-		logger:     logger,
+		userRepo:          userRepo,
+		sourceRepo:        sourceRepo,
+		accountTokenRepo:  accountTokenRepo,
+		recoveryCodeRepo:  recoveryCodeRepo,
+		loginThrottleRepo: loginThrottleRepo,
+		geoIPResolver:     geoIPResolver,
+		mailer:            mailer,
+		jwtConfig:         jwtConfig,
+		signingKeys:       signingKeys,
+		accountConfig:     accountConfig,
+		logger:            logger,
+	}
+}
+
+func generateTokenFromUser(user *domain.User, keys *token.KeySet, duration time.Duration, sourceID string) (string, error) {
+	return token.GenerateToken(user.ID, user.Email, keys, duration, sourceID)
+}
+
+// locateSource fills the city and country of a source from its IP address.
+// Lookup failures are logged and never block authentication.
+func (uc *AuthServiceImplementation) locateSource(ctx context.Context, source *domain.Source) {
+	location, err := uc.geoIPResolver.Resolve(ctx, source.IPAddress)
+	if err != nil {
+		uc.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"ip_address": source.IPAddress,
+		})
+		return
 	}
+	source.City = location.City
+	source.CountryCode = location.CountryCode
 }
 
-func generateTokenFromUser(user *domain.User, secret string, duration time.Duration, sourceID string) (string, error) {
-	return token.GenerateToken(user.ID, user.Email, []byte(secret), duration, sourceID)
+// hashRefreshToken returns the keyed hash under which a refresh token is stored
+func (uc *AuthServiceImplementation) hashRefreshToken(refreshToken string) string {
+	return token.Hash(refreshToken, []byte(uc.jwtConfig.RtkSecret))
+}
+
+// hashAccountToken returns the keyed hash under which an account token or a
+// recovery code is stored, with a key of their own derived from the refresh
+// token secret
+func (uc *AuthServiceImplementation) hashAccountToken(accountToken string) string {
+	return token.Hash(accountToken, token.DeriveKey([]byte(uc.jwtConfig.RtkSecret), accountTokenKeyPurpose))
+}
+
+// accountTokenHashes returns the hashes an account token or a recovery code
+// may be stored under. The ones created before their key was derived are
+// hashed with the refresh token secret itself.
+func (uc *AuthServiceImplementation) accountTokenHashes(accountToken string) []string {
+	return []string{uc.hashAccountToken(accountToken), token.Hash(accountToken, []byte(uc.jwtConfig.RtkSecret))}
+}
+
+// consumeAccountToken consumes a token of the purpose stored under any of its
+// hashes
+func (uc *AuthServiceImplementation) consumeAccountToken(ctx context.Context, purpose domain.AccountTokenPurpose, accountToken string) (*domain.AccountToken, error) {
+	var err error
+	for _, tokenHash := range uc.accountTokenHashes(accountToken) {
+		var consumed *domain.AccountToken
+		consumed, err = uc.accountTokenRepo.Consume(ctx, purpose, tokenHash)
+		if !errors.Is(err, exceptions.ErrAccountTokenInvalid) {
+			return consumed, err
+		}
+	}
+	return nil, err
 }
 
 func (uc *AuthServiceImplementation) Register(ctx context.Context, input *domain.UserRegisterInput, userAgent, ipAddress string) (*domain.RefreshTokenPayload, error) {
@@ -76,69 +148,82 @@ func (uc *AuthServiceImplementation) Register(ctx context.Context, input *domain
 		uc.logger.Error(ctx, err)
 		return nil, err
 	}
-	refreshToken := random.GenerateRandomString(128)
-	newSource := &domain.Source{
-		UserID:                user.ID,
-		RefreshToken:          refreshToken,
-		RefreshTokenExpiresAt: time.Now().Add(uc.jwtConfig.Expiry),
-		UserAgent:             userAgent,
-
-		IPAddress: ipAddress,
-		IsActive:  true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
 
-	err = uc.sourceRepo.Create(ctx, newSource)
-	if err != nil {
-		return nil, err
+	// A failed delivery must not fail the registration, the user can ask for
+	// a new verification email
+	if err := uc.sendVerificationEmail(ctx, user); err != nil {
+		uc.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"user_id": user.ID.String(),
+		})
 	}
 
-	// Generate tokens
-	accessToken, err := generateTokenFromUser(user, uc.jwtConfig.AtkSecret, uc.jwtConfig.Expiry, newSource.ID.String())
+	session, err := uc.startSession(ctx, user, userAgent, ipAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	return &domain.RefreshTokenPayload{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    int64(uc.jwtConfig.Expiry.Seconds()),
+		AccessToken:  session.AccessToken,
+		RefreshToken: session.RefreshToken,
+		ExpiresIn:    session.ExpiresIn,
 	}, nil
 }
 
 func (uc *AuthServiceImplementation) Login(ctx context.Context, login *domain.UserLoginInput, userAgent, ipAddress string) (*domain.AuthenticationPayload, error) {
+	accountKey, ipKey := loginThrottleKeys(login.Email, ipAddress)
+	err := uc.checkLoginThrottle(ctx, accountKey, ipKey)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get user by email
 	user, err := uc.userRepo.GetByEmail(ctx, login.Email)
 	if err != nil {
+		// Unknown emails are counted too, so they behave like existing ones
+		uc.recordLoginFailure(ctx, accountKey, ipKey)
 		return nil, exceptions.ErrAuthInvalidCredentials
 	}
 
 	// Verify password
 	err = password.VerifyPassword(user.Password, login.Password)
 	if err != nil {
+		uc.recordLoginFailure(ctx, accountKey, ipKey)
 		return nil, exceptions.ErrAuthInvalidCredentials
 	}
 
+	// The session is only created once the second factor is verified, the
+	// failed attempts are kept until then since they count second factor
+	// attempts too
+	if user.MFAEnabled() {
+		return uc.issueMFAChallenge(ctx, user)
+	}
+	uc.resetLoginThrottle(ctx, accountKey)
+
+	return uc.startSession(ctx, user, userAgent, ipAddress)
+}
+
+// startSession creates a new source for the user and returns its token pair
+func (uc *AuthServiceImplementation) startSession(ctx context.Context, user *domain.User, userAgent, ipAddress string) (*domain.AuthenticationPayload, error) {
 	refreshToken := random.GenerateRandomString(128)
 
 	newSource := &domain.Source{
 		UserID:                user.ID,
-		RefreshToken:          refreshToken,
+		RefreshTokenHash:      uc.hashRefreshToken(refreshToken),
 		UserAgent:             userAgent,
 		IPAddress:             ipAddress,
 		IsActive:              true,
-		RefreshTokenExpiresAt: time.Now().Add(uc.jwtConfig.Expiry),
+		RefreshTokenExpiresAt: time.Now().Add(uc.jwtConfig.RefreshExpiry),
 		CreatedAt:             time.Now(),
 		UpdatedAt:             time.Now(),
 	}
+	uc.locateSource(ctx, newSource)
 
-	err = uc.sourceRepo.Create(ctx, newSource)
+	err := uc.sourceRepo.Create(ctx, newSource)
 	if err != nil {
 		return nil, err
 	}
 	// Generate tokens
-	accessToken, err := generateTokenFromUser(user, uc.jwtConfig.AtkSecret, uc.jwtConfig.Expiry, newSource.ID.String())
+	accessToken, err := generateTokenFromUser(user, uc.signingKeys, uc.jwtConfig.Expiry, newSource.ID.String())
 	if err != nil {
 		return nil, err
 	}
@@ -155,17 +240,17 @@ func (uc *AuthServiceImplementation) Login(ctx context.Context, login *domain.Us
 	}, nil
 }
 
-func (uc *AuthServiceImplementation) RefreshToken(ctx context.Context, refreshToken string) (*domain.RefreshTokenPayload, error) {
-	// Validate refresh token
-	claims, err := token.ValidateToken(refreshToken, []byte(uc.jwtConfig.AtkSecret))
+// RefreshToken exchanges an opaque refresh token for a new token pair. Every
+// exchange rotates the token of the source; presenting a token that was
+// already rotated revokes the whole source, since it means the token leaked.
+func (uc *AuthServiceImplementation) RefreshToken(ctx context.Context, refreshToken, userAgent, ipAddress string) (*domain.RefreshTokenPayload, error) {
+	refreshTokenHash := uc.hashRefreshToken(refreshToken)
+	source, err := uc.sourceRepo.GetByRefreshTokenHash(ctx, refreshTokenHash)
 	if err != nil {
-		return nil, errors.New("invalid refresh token")
-	}
-
-	// Check if source exists and is active
-	source, err := uc.sourceRepo.GetByID(ctx, claims.SourceID)
-	if err != nil {
-		return nil, errors.New("invalid refresh token")
+		if errors.Is(err, exceptions.ErrAuthSourceNotFound) {
+			return nil, uc.detectRefreshTokenReuse(ctx, refreshTokenHash, ipAddress)
+		}
+		return nil, err
 	}
 
 	if !source.IsActive || time.Now().After(source.RefreshTokenExpiresAt) {
@@ -173,25 +258,34 @@ func (uc *AuthServiceImplementation) RefreshToken(ctx context.Context, refreshTo
 	}
 
 	// Get user
-	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
+	user, err := uc.userRepo.GetByID(ctx, source.UserID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
 
 	// Generate new tokens
-	newAccessToken, err := generateTokenFromUser(user, uc.jwtConfig.AtkSecret, uc.jwtConfig.Expiry, source.ID.String())
+	newAccessToken, err := generateTokenFromUser(user, uc.signingKeys, uc.jwtConfig.Expiry, source.ID.String())
 	if err != nil {
 		return nil, err
 	}
 
 	newRefreshToken := random.GenerateRandomString(128)
 
-	source.RefreshToken = newRefreshToken
-	source.RefreshTokenExpiresAt = time.Now().Add(uc.jwtConfig.Expiry)
+	source.RefreshTokenHash = uc.hashRefreshToken(newRefreshToken)
+	source.RefreshTokenExpiresAt = time.Now().Add(uc.jwtConfig.RefreshExpiry)
 	source.UpdatedAt = time.Now()
+	if source.IPAddress != ipAddress {
+		source.IPAddress = ipAddress
+		uc.locateSource(ctx, source)
+	}
+	source.UserAgent = userAgent
 
-	err = uc.sourceRepo.Update(ctx, source)
+	err = uc.sourceRepo.Rotate(ctx, source, refreshTokenHash)
 	if err != nil {
+		if errors.Is(err, exceptions.ErrAuthRefreshTokenReused) {
+			// Another request rotated this token first
+			return nil, uc.detectRefreshTokenReuse(ctx, refreshTokenHash, ipAddress)
+		}
 		return nil, err
 	}
 
@@ -202,11 +296,38 @@ func (uc *AuthServiceImplementation) RefreshToken(ctx context.Context, refreshTo
 	}, nil
 }
 
+// detectRefreshTokenReuse checks whether an unknown refresh token belongs to an
+// already rotated generation and, if so, revokes the source it was issued for.
+func (uc *AuthServiceImplementation) detectRefreshTokenReuse(ctx context.Context, refreshTokenHash, ipAddress string) error {
+	source, err := uc.sourceRepo.GetByRotatedRefreshTokenHash(ctx, refreshTokenHash)
+	if err != nil {
+		return exceptions.ErrAuthInvalidRefreshToken
+	}
+
+	uc.logger.ErrorWithVar(ctx, exceptions.ErrAuthRefreshTokenReused, map[string]interface{}{
+		"event":      "refresh_token_reuse",
+		"source_id":  source.ID.String(),
+		"user_id":    source.UserID.String(),
+		"ip_address": source.IPAddress,
+		"reused_by":  ipAddress,
+	})
+
+	if source.IsActive {
+		source.IsActive = false
+		source.UpdatedAt = time.Now()
+		if err := uc.sourceRepo.Update(ctx, source); err != nil {
+			return err
+		}
+	}
+
+	return exceptions.ErrAuthRefreshTokenReused
+}
+
 func (uc *AuthServiceImplementation) Logout(ctx context.Context, refreshToken string) error {
 	// Get source by refresh token
-	source, err := uc.sourceRepo.GetByID(ctx, refreshToken)
+	source, err := uc.sourceRepo.GetByRefreshTokenHash(ctx, uc.hashRefreshToken(refreshToken))
 	if err != nil {
-		return errors.New("invalid refresh token")
+		return exceptions.ErrAuthInvalidRefreshToken
 	}
 
 	// Deactivate source
@@ -237,20 +358,26 @@ func (uc *AuthServiceImplementation) LogoutAll(ctx context.Context, userID strin
 }
 
 func (uc *AuthServiceImplementation) ValidateToken(ctx context.Context, tokenString string) (*domain.JWTClaims, error) {
-	claims, err := token.ValidateToken(tokenString, []byte(uc.jwtConfig.AtkSecret))
+	claims, err := token.ValidateToken(tokenString, uc.signingKeys)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify user still exists
-	_, err = uc.userRepo.GetByID(ctx, claims.UserID)
+	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
+	claims.EmailVerified = user.EmailVerifiedAt != nil
 
 	return claims, nil
 }
 
+// JWKS returns the public keys that verify access tokens
+func (uc *AuthServiceImplementation) JWKS(ctx context.Context) domain.JWKS {
+	return uc.signingKeys.JWKS(time.Now())
+}
+
 func (uc *AuthServiceImplementation) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
 	// Get user by ID
 	user, err := uc.userRepo.GetByID(ctx, userID)
@@ -0,0 +1,995 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/adapters/geoip"
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"bifur.app/core/internal/utils/password"
+	"bifur.app/core/internal/utils/token"
+	"bifur.app/core/internal/utils/totp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock implementations for testing
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockSourceRepository struct {
+	mock.Mock
+}
+
+func (m *MockSourceRepository) Create(ctx context.Context, source *domain.Source) error {
+	args := m.Called(ctx, source)
+	return args.Error(0)
+}
+
+func (m *MockSourceRepository) GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Source, error) {
+	args := m.Called(ctx, refreshTokenHash)
+	return args.Get(0).(*domain.Source), args.Error(1)
+}
+
+func (m *MockSourceRepository) GetByRotatedRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*domain.Source, error) {
+	args := m.Called(ctx, refreshTokenHash)
+	return args.Get(0).(*domain.Source), args.Error(1)
+}
+
+func (m *MockSourceRepository) Rotate(ctx context.Context, source *domain.Source, previousRefreshTokenHash string) error {
+	args := m.Called(ctx, source, previousRefreshTokenHash)
+	return args.Error(0)
+}
+
+func (m *MockSourceRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Source, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*domain.Source), args.Error(1)
+}
+
+func (m *MockSourceRepository) Update(ctx context.Context, source *domain.Source) error {
+	args := m.Called(ctx, source)
+	return args.Error(0)
+}
+
+func (m *MockSourceRepository) GetByID(ctx context.Context, id string) (*domain.Source, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.Source), args.Error(1)
+}
+
+func (m *MockSourceRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSourceRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockSourceRepository) DeleteExpired(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockAccountTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccountTokenRepository) Create(ctx context.Context, token *domain.AccountToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAccountTokenRepository) GetValid(ctx context.Context, purpose domain.AccountTokenPurpose, tokenHash string) (*domain.AccountToken, error) {
+	args := m.Called(ctx, purpose, tokenHash)
+	return args.Get(0).(*domain.AccountToken), args.Error(1)
+}
+
+func (m *MockAccountTokenRepository) Consume(ctx context.Context, purpose domain.AccountTokenPurpose, tokenHash string) (*domain.AccountToken, error) {
+	args := m.Called(ctx, purpose, tokenHash)
+	return args.Get(0).(*domain.AccountToken), args.Error(1)
+}
+
+func (m *MockAccountTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose domain.AccountTokenPurpose) error {
+	args := m.Called(ctx, userID, purpose)
+	return args.Error(0)
+}
+
+type MockRecoveryCodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecoveryCodeRepository) ReplaceForUser(ctx context.Context, userID uuid.UUID, codes []*domain.RecoveryCode) error {
+	args := m.Called(ctx, userID, codes)
+	return args.Error(0)
+}
+
+func (m *MockRecoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Error(0)
+}
+
+func (m *MockRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type MockLoginThrottleRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginThrottleRepository) Get(ctx context.Context, key string) (*domain.LoginThrottle, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(*domain.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) RecordFailure(ctx context.Context, key string, at time.Time, windowStart time.Time) (*domain.LoginThrottle, error) {
+	args := m.Called(ctx, key, at, windowStart)
+	return args.Get(0).(*domain.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginThrottleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	args := m.Called(ctx, key, until)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottleRepository) Reset(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// newTestLoginThrottleRepository returns a throttle repository without any
+// failed attempt recorded
+func newTestLoginThrottleRepository() *MockLoginThrottleRepository {
+	repo := new(MockLoginThrottleRepository)
+	repo.On("Get", mock.Anything, mock.Anything).Return((*domain.LoginThrottle)(nil), nil)
+	repo.On("RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&domain.LoginThrottle{FailedCount: 1}, nil)
+	repo.On("Reset", mock.Anything, mock.Anything).Return(nil)
+	return repo
+}
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, message *domain.EmailMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+// getTestJWTConfig returns a test JWT configuration
+func getTestJWTConfig() domain.JWTConfig {
+	return domain.JWTConfig{
+		AtkSecret: "test_access_secret",
+		RtkSecret: "test_refresh_secret",
+
+		Expiry:        15 * time.Minute,
+		RefreshExpiry: 24 * time.Hour,
+	}
+}
+
+// getTestSigningKeys returns the key set matching the test JWT configuration
+func getTestSigningKeys() *token.KeySet {
+	return token.NewHMACKeySet([]byte(getTestJWTConfig().AtkSecret))
+}
+
+// getTestAccountConfig returns a test account configuration
+func getTestAccountConfig() domain.AccountConfig {
+	return domain.AccountConfig{
+		AppURL:                  "http://localhost:3000",
+		PasswordResetExpiry:     time.Hour,
+		EmailVerificationExpiry: 48 * time.Hour,
+		UnverifiedEmailPolicy:   domain.UnverifiedEmailPolicyAllow,
+		MFAIssuer:               "Scheduly",
+		MFAChallengeExpiry:      5 * time.Minute,
+		LoginThrottle: domain.LoginThrottleConfig{
+			MaxFailedAttempts:      5,
+			MaxFailedAttemptsPerIP: 50,
+			Window:                 15 * time.Minute,
+			LockoutDuration:        15 * time.Minute,
+			DelayAfter:             3,
+			BaseDelay:              time.Second,
+			MaxDelay:               30 * time.Second,
+		},
+	}
+}
+
+// hashTestRefreshToken hashes a refresh token the way the service stores it
+func hashTestRefreshToken(refreshToken string) string {
+	return token.Hash(refreshToken, []byte(getTestJWTConfig().RtkSecret))
+}
+
+func TestAuthUseCase_Register(t *testing.T) {
+	// Setup
+	mockLogger := new(mocks.LoggerMock)
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockMailer := new(MockMailer)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, mockAccountTokenRepo, new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), mockMailer, getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	registration := &domain.UserRegisterInput{
+		Email:     "test@example.com",
+		Password:  "password123",
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	// Expectations
+	mockUserRepo.On("ExistsByEmail", mock.Anything, registration.Email).Return(false, nil)
+	mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return password.VerifyPassword(user.Password, registration.Password) == nil
+	})).Return(nil)
+	mockAccountTokenRepo.On("DeleteByUserID", mock.Anything, mock.Anything, domain.AccountTokenPurposeEmailVerification).Return(nil)
+	mockAccountTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AccountToken")).Return(nil)
+	mockMailer.On("Send", mock.Anything, mock.AnythingOfType("*domain.EmailMessage")).Return(nil)
+	mockSourceRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Source")).Return(nil)
+
+	// Execute
+	response, err := authUseCase.Register(context.Background(), registration, "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.NotEmpty(t, response.RefreshToken)
+	claims, err := token.ValidateToken(response.AccessToken, getTestSigningKeys())
+	assert.NoError(t, err)
+	assert.Equal(t, registration.Email, claims.Email)
+
+	// Verify all expectations were met
+	mockUserRepo.AssertExpectations(t)
+	mockSourceRepo.AssertExpectations(t)
+	mockAccountTokenRepo.AssertExpectations(t)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Register_UserAlreadyExists(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockLogger := new(mocks.LoggerMock)
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	registration := &domain.UserRegisterInput{
+		Email:     "test@example.com",
+		Password:  "password123",
+		FirstName: "John",
+		LastName:  "Doe",
+	}
+
+	// Expectations
+	mockUserRepo.On("ExistsByEmail", mock.Anything, registration.Email).Return(true, nil)
+
+	// Execute
+	response, err := authUseCase.Register(context.Background(), registration, "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "user already exists", err.Error())
+
+	// Verify all expectations were met
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Login(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	login := &domain.UserLoginInput{
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+
+	hashedPassword, _ := password.HashPassword(login.Password)
+	user := &domain.User{
+		ID:        uuid.New(),
+		Email:     "test@example.com",
+		Password:  hashedPassword,
+		FirstName: "John",
+		LastName:  "Doe",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// Expectations
+	mockUserRepo.On("GetByEmail", mock.Anything, login.Email).Return(user, nil)
+	mockSourceRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Source")).Return(nil)
+
+	// Execute
+	response, err := authUseCase.Login(context.Background(), login, "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.NotEmpty(t, response.RefreshToken)
+	assert.NotNil(t, response.User)
+	claims, err := token.ValidateToken(response.AccessToken, getTestSigningKeys())
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+
+	// Verify all expectations were met
+	mockUserRepo.AssertExpectations(t)
+	mockSourceRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_GetProfile(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	userID := uuid.New()
+	user := &domain.User{
+		ID:        userID,
+		Email:     "test@example.com",
+		Password:  "hashed_password",
+		FirstName: "John",
+		LastName:  "Doe",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// Expectations
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+
+	// Execute
+	response, err := authUseCase.GetProfile(context.Background(), userID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+
+	// Verify all expectations were met
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_GetProfile_UserNotFound(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	userID := uuid.New()
+
+	// Expectations
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return((*domain.User)(nil), errors.New("user not found"))
+
+	// Execute
+	response, err := authUseCase.GetProfile(context.Background(), userID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, "user not found", err.Error())
+
+	// Verify all expectations were met
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_RefreshToken_Rotates(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com"}
+	source := &domain.Source{
+		ID:                    uuid.New(),
+		UserID:                user.ID,
+		RefreshTokenHash:      hashTestRefreshToken("old_refresh_token"),
+		RefreshTokenExpiresAt: time.Now().Add(time.Hour),
+		IsActive:              true,
+	}
+
+	// Expectations
+	mockSourceRepo.On("GetByRefreshTokenHash", mock.Anything, hashTestRefreshToken("old_refresh_token")).Return(source, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockSourceRepo.On("Rotate", mock.Anything, source, hashTestRefreshToken("old_refresh_token")).Return(nil)
+
+	// Execute
+	response, err := authUseCase.RefreshToken(context.Background(), "old_refresh_token", "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.NotEqual(t, "old_refresh_token", response.RefreshToken)
+	assert.Equal(t, hashTestRefreshToken(response.RefreshToken), source.RefreshTokenHash)
+
+	// Verify all expectations were met
+	mockSourceRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_RefreshToken_ReuseRevokesSource(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	source := &domain.Source{
+		ID:                    uuid.New(),
+		UserID:                uuid.New(),
+		RefreshTokenHash:      hashTestRefreshToken("current_refresh_token"),
+		RefreshTokenExpiresAt: time.Now().Add(time.Hour),
+		IsActive:              true,
+	}
+
+	// Expectations
+	mockSourceRepo.On("GetByRefreshTokenHash", mock.Anything, hashTestRefreshToken("rotated_refresh_token")).Return((*domain.Source)(nil), exceptions.ErrAuthSourceNotFound)
+	mockSourceRepo.On("GetByRotatedRefreshTokenHash", mock.Anything, hashTestRefreshToken("rotated_refresh_token")).Return(source, nil)
+	mockSourceRepo.On("Update", mock.Anything, source).Return(nil)
+
+	// Execute
+	response, err := authUseCase.RefreshToken(context.Background(), "rotated_refresh_token", "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrAuthRefreshTokenReused)
+	assert.Nil(t, response)
+	assert.False(t, source.IsActive)
+
+	// Verify all expectations were met
+	mockSourceRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Login_StoresRefreshTokenHash(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	hashedPassword, _ := password.HashPassword("password123")
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword}
+	var stored *domain.Source
+
+	// Expectations
+	mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockSourceRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Source")).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.Source)
+	}).Return(nil)
+
+	// Execute
+	first, err := authUseCase.Login(context.Background(), &domain.UserLoginInput{Email: user.Email, Password: "password123"}, "test-agent", "127.0.0.1")
+	assert.NoError(t, err)
+	firstHash := stored.RefreshTokenHash
+	second, err := authUseCase.Login(context.Background(), &domain.UserLoginInput{Email: user.Email, Password: "password123"}, "test-agent", "127.0.0.1")
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, hashTestRefreshToken(first.RefreshToken), firstHash)
+	assert.NotEqual(t, first.RefreshToken, firstHash)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.Equal(t, hashTestRefreshToken(second.RefreshToken), stored.RefreshTokenHash)
+}
+
+func TestAuthUseCase_ForgotPassword(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockMailer := new(MockMailer)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, mockAccountTokenRepo, new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), mockMailer, getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", FirstName: "John"}
+
+	// Expectations
+	mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockAccountTokenRepo.On("DeleteByUserID", mock.Anything, user.ID, domain.AccountTokenPurposePasswordReset).Return(nil)
+	mockAccountTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AccountToken")).Return(nil)
+	mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(message *domain.EmailMessage) bool {
+		return message.To == user.Email
+	})).Return(nil)
+
+	// Execute
+	err := authUseCase.ForgotPassword(context.Background(), user.Email)
+
+	// Assert
+	assert.NoError(t, err)
+
+	// Verify all expectations were met
+	mockUserRepo.AssertExpectations(t)
+	mockAccountTokenRepo.AssertExpectations(t)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestAuthUseCase_ForgotPassword_UnknownEmail(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockMailer := new(MockMailer)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, new(MockSourceRepository), new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), mockMailer, getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	// Expectations
+	mockUserRepo.On("GetByEmail", mock.Anything, "unknown@example.com").Return((*domain.User)(nil), exceptions.ErrUserNotFound)
+
+	// Execute
+	err := authUseCase.ForgotPassword(context.Background(), "unknown@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_ResetPassword_RevokesSources(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, mockAccountTokenRepo, new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: "old_hash"}
+	source := &domain.Source{ID: uuid.New(), UserID: user.ID, IsActive: true}
+	resetToken := &domain.AccountToken{ID: uuid.New(), UserID: user.ID, Purpose: domain.AccountTokenPurposePasswordReset}
+
+	// Expectations
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposePasswordReset, mock.AnythingOfType("string")).Return(resetToken, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockSourceRepo.On("GetByUserID", mock.Anything, user.ID.String()).Return([]*domain.Source{source}, nil)
+	mockSourceRepo.On("Update", mock.Anything, source).Return(nil)
+
+	// Execute
+	err := authUseCase.ResetPassword(context.Background(), "reset_token", "new_password")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, "old_hash", user.Password)
+	assert.False(t, source.IsActive)
+
+	// Verify all expectations were met
+	mockAccountTokenRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockSourceRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_ResetPassword_LegacyTokenHash(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, mockAccountTokenRepo, new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: "old_hash"}
+	resetToken := &domain.AccountToken{ID: uuid.New(), UserID: user.ID, Purpose: domain.AccountTokenPurposePasswordReset}
+	secret := []byte(getTestJWTConfig().RtkSecret)
+
+	// Expectations
+	// The token was sent before account tokens had a key of their own
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposePasswordReset, token.Hash("reset_token", token.DeriveKey(secret, "account-tokens"))).Return((*domain.AccountToken)(nil), exceptions.ErrAccountTokenInvalid).Once()
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposePasswordReset, token.Hash("reset_token", secret)).Return(resetToken, nil).Once()
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockSourceRepo.On("GetByUserID", mock.Anything, user.ID.String()).Return([]*domain.Source{}, nil)
+
+	// Execute
+	err := authUseCase.ResetPassword(context.Background(), "reset_token", "new_password")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, "old_hash", user.Password)
+	mockAccountTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_VerifyEmail(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, new(MockSourceRepository), mockAccountTokenRepo, new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com"}
+	verificationToken := &domain.AccountToken{ID: uuid.New(), UserID: user.ID, Purpose: domain.AccountTokenPurposeEmailVerification}
+
+	// Expectations
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposeEmailVerification, mock.AnythingOfType("string")).Return(verificationToken, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+
+	// Execute
+	err := authUseCase.VerifyEmail(context.Background(), "verification_token")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, user.EmailVerifiedAt)
+
+	// Verify all expectations were met
+	mockAccountTokenRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_VerifyEmail_InvalidToken(t *testing.T) {
+	// Setup
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(new(MockUserRepository), new(MockSourceRepository), mockAccountTokenRepo, new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	// Expectations
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposeEmailVerification, mock.AnythingOfType("string")).Return((*domain.AccountToken)(nil), exceptions.ErrAccountTokenInvalid)
+
+	// Execute
+	err := authUseCase.VerifyEmail(context.Background(), "unknown_token")
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrAuthInvalidVerifyToken)
+}
+
+func TestAuthUseCase_Login_MFARequired(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, mockAccountTokenRepo, new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	hashedPassword, _ := password.HashPassword("password123")
+	enabledAt := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword, MFASecret: totp.GenerateSecret(), MFAEnabledAt: &enabledAt}
+
+	// Expectations
+	mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockAccountTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(token *domain.AccountToken) bool {
+		return token.Purpose == domain.AccountTokenPurposeMFAChallenge && token.UserID == user.ID
+	})).Return(nil)
+
+	// Execute
+	response, err := authUseCase.Login(context.Background(), &domain.UserLoginInput{Email: user.Email, Password: "password123"}, "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.MFARequired)
+	assert.NotEmpty(t, response.MFAToken)
+	assert.Empty(t, response.AccessToken)
+	assert.Empty(t, response.RefreshToken)
+
+	// No session is created before the second factor
+	mockSourceRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockAccountTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_LoginMFA(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, mockAccountTokenRepo, new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	enabledAt := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", MFASecret: totp.GenerateSecret(), MFAEnabledAt: &enabledAt}
+	challenge := &domain.AccountToken{ID: uuid.New(), UserID: user.ID, Purpose: domain.AccountTokenPurposeMFAChallenge}
+	code, _ := totp.Code(user.MFASecret, totp.Step(time.Now()))
+
+	// Expectations
+	mockAccountTokenRepo.On("GetValid", mock.Anything, domain.AccountTokenPurposeMFAChallenge, mock.AnythingOfType("string")).Return(challenge, nil)
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposeMFAChallenge, mock.AnythingOfType("string")).Return(challenge, nil).Once()
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposeMFAChallenge, mock.AnythingOfType("string")).Return((*domain.AccountToken)(nil), exceptions.ErrAccountTokenInvalid)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockSourceRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Source")).Return(nil).Once()
+
+	// Execute
+	input := &domain.LoginMFAInput{MFAToken: "challenge", Code: code}
+	response, err := authUseCase.LoginMFA(context.Background(), input, "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.AccessToken)
+	assert.NotEmpty(t, response.RefreshToken)
+	assert.Equal(t, totp.Step(time.Now()), user.MFALastUsedStep)
+
+	// The same code cannot be used twice
+	_, err = authUseCase.LoginMFA(context.Background(), input, "test-agent", "127.0.0.1")
+	assert.ErrorIs(t, err, exceptions.ErrMFAInvalidCode)
+
+	// Verify all expectations were met
+	mockAccountTokenRepo.AssertExpectations(t)
+	mockSourceRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_LoginMFA_WrongCodeDiscardsChallenge(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockRecoveryCodeRepo := new(MockRecoveryCodeRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, mockAccountTokenRepo, mockRecoveryCodeRepo, newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	enabledAt := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", MFASecret: totp.GenerateSecret(), MFAEnabledAt: &enabledAt}
+	challenge := &domain.AccountToken{ID: uuid.New(), UserID: user.ID, Purpose: domain.AccountTokenPurposeMFAChallenge}
+	code, _ := totp.Code(user.MFASecret, totp.Step(time.Now()))
+
+	// Expectations
+	mockAccountTokenRepo.On("GetValid", mock.Anything, domain.AccountTokenPurposeMFAChallenge, mock.AnythingOfType("string")).Return(challenge, nil).Once()
+	mockAccountTokenRepo.On("GetValid", mock.Anything, domain.AccountTokenPurposeMFAChallenge, mock.AnythingOfType("string")).Return((*domain.AccountToken)(nil), exceptions.ErrAccountTokenInvalid)
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposeMFAChallenge, mock.AnythingOfType("string")).Return(challenge, nil).Once()
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockRecoveryCodeRepo.On("Consume", mock.Anything, user.ID, mock.AnythingOfType("string")).Return(exceptions.ErrMFAInvalidCode)
+
+	// Execute
+	_, wrongErr := authUseCase.LoginMFA(context.Background(), &domain.LoginMFAInput{MFAToken: "challenge", Code: "000000"}, "test-agent", "127.0.0.1")
+	_, retryErr := authUseCase.LoginMFA(context.Background(), &domain.LoginMFAInput{MFAToken: "challenge", Code: code}, "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.ErrorIs(t, wrongErr, exceptions.ErrMFAInvalidCode)
+	// The challenge allowed a single guess, even the right code needs a new one
+	assert.ErrorIs(t, retryErr, exceptions.ErrMFAInvalidChallenge)
+	mockSourceRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockAccountTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Login_MFAKeepsFailedAttempts(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockSourceRepo := new(MockSourceRepository)
+	mockAccountTokenRepo := new(MockAccountTokenRepository)
+	mockRecoveryCodeRepo := new(MockRecoveryCodeRepository)
+	mockThrottleRepo := new(MockLoginThrottleRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, mockSourceRepo, mockAccountTokenRepo, mockRecoveryCodeRepo, mockThrottleRepo, geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	hashedPassword, _ := password.HashPassword("password123")
+	enabledAt := time.Now()
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword, MFASecret: totp.GenerateSecret(), MFAEnabledAt: &enabledAt}
+	challenge := &domain.AccountToken{ID: uuid.New(), UserID: user.ID, Purpose: domain.AccountTokenPurposeMFAChallenge}
+	accountKey := "account:test@example.com"
+
+	// Expectations
+	mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockAccountTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AccountToken")).Return(nil)
+	mockAccountTokenRepo.On("GetValid", mock.Anything, domain.AccountTokenPurposeMFAChallenge, mock.AnythingOfType("string")).Return(challenge, nil)
+	mockAccountTokenRepo.On("Consume", mock.Anything, domain.AccountTokenPurposeMFAChallenge, mock.AnythingOfType("string")).Return(challenge, nil)
+	mockThrottleRepo.On("Get", mock.Anything, mock.Anything).Return((*domain.LoginThrottle)(nil), nil)
+	mockThrottleRepo.On("RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&domain.LoginThrottle{FailedCount: 1}, nil)
+	mockRecoveryCodeRepo.On("Consume", mock.Anything, user.ID, mock.AnythingOfType("string")).Return(exceptions.ErrMFAInvalidCode)
+
+	// Execute
+	// A wrong code, then the password again to get a new challenge
+	first, err := authUseCase.Login(context.Background(), &domain.UserLoginInput{Email: user.Email, Password: "password123"}, "test-agent", "127.0.0.1")
+	assert.NoError(t, err)
+	_, codeErr := authUseCase.LoginMFA(context.Background(), &domain.LoginMFAInput{MFAToken: first.MFAToken, Code: "000000"}, "test-agent", "127.0.0.1")
+	second, err := authUseCase.Login(context.Background(), &domain.UserLoginInput{Email: user.Email, Password: "password123"}, "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.ErrorIs(t, codeErr, exceptions.ErrMFAInvalidCode)
+	assert.NoError(t, err)
+	assert.True(t, second.MFARequired)
+	mockThrottleRepo.AssertCalled(t, "RecordFailure", mock.Anything, accountKey, mock.Anything, mock.Anything)
+	// The password alone never clears the failed second factor attempts
+	mockThrottleRepo.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+}
+
+// writeTestKey writes a new Ed25519 private key named after its kid
+func writeTestKey(t *testing.T, dir, kid string) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	assert.NoError(t, err)
+}
+
+// loadTestKeySet writes a manifest scheduling the given keys and loads it
+func loadTestKeySet(t *testing.T, dir string, activations map[string]time.Time) *token.KeySet {
+	var keys []map[string]interface{}
+	for kid, activateAt := range activations {
+		keys = append(keys, map[string]interface{}{
+			"kid":              kid,
+			"alg":              token.AlgorithmEdDSA,
+			"private_key_file": kid + ".pem",
+			"activate_at":      activateAt,
+		})
+	}
+	raw, err := json.Marshal(map[string]interface{}{"keys": keys})
+	assert.NoError(t, err)
+	manifestPath := filepath.Join(dir, "keys.json")
+	assert.NoError(t, os.WriteFile(manifestPath, raw, 0o600))
+
+	keySet, err := token.LoadKeySet(manifestPath)
+	assert.NoError(t, err)
+	return keySet
+}
+
+func TestAuthUseCase_ValidateToken_KeyRotation(t *testing.T) {
+	// Setup
+	dir := t.TempDir()
+	mockUserRepo := new(MockUserRepository)
+	mockLogger := new(mocks.LoggerMock)
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com"}
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	writeTestKey(t, dir, "key-1")
+	writeTestKey(t, dir, "key-2")
+	initialKeys := loadTestKeySet(t, dir, map[string]time.Time{"key-1": time.Now().Add(-time.Hour)})
+	rotatedKeys := loadTestKeySet(t, dir, map[string]time.Time{
+		"key-1": time.Now().Add(-time.Hour),
+		"key-2": time.Now().Add(-time.Minute),
+	})
+
+	authUseCase := NewAuthService(mockUserRepo, new(MockSourceRepository), new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), newTestLoginThrottleRepository(), geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), rotatedKeys, getTestAccountConfig(), mockLogger)
+
+	// Execute
+	oldToken, err := token.GenerateToken(user.ID, user.Email, initialKeys, time.Minute, uuid.NewString())
+	assert.NoError(t, err)
+	newToken, err := token.GenerateToken(user.ID, user.Email, rotatedKeys, time.Minute, uuid.NewString())
+	assert.NoError(t, err)
+	hmacToken, err := token.GenerateToken(user.ID, user.Email, getTestSigningKeys(), time.Minute, uuid.NewString())
+	assert.NoError(t, err)
+
+	// Assert
+	// Tokens signed before the rotation stay valid
+	_, err = authUseCase.ValidateToken(context.Background(), oldToken)
+	assert.NoError(t, err)
+	claims, err := authUseCase.ValidateToken(context.Background(), newToken)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+	// Tokens signed with the shared secret are rejected once keys are configured
+	_, err = authUseCase.ValidateToken(context.Background(), hmacToken)
+	assert.Error(t, err)
+
+	jwks := authUseCase.JWKS(context.Background())
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "key-2", jwks.Keys[0].KeyID)
+	assert.Equal(t, "OKP", jwks.Keys[0].KeyType)
+}
+
+func TestAuthUseCase_Login_LocksAccountAfterMaxFailures(t *testing.T) {
+	// Setup
+	mockUserRepo := new(MockUserRepository)
+	mockThrottleRepo := new(MockLoginThrottleRepository)
+	mockLogger := new(mocks.LoggerMock)
+
+	authUseCase := NewAuthService(mockUserRepo, new(MockSourceRepository), new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), mockThrottleRepo, geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), mockLogger)
+
+	hashedPassword, _ := password.HashPassword("password123")
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword}
+	accountKey := "account:test@example.com"
+	ipKey := "ip:127.0.0.1"
+
+	// Expectations
+	mockUserRepo.On("GetByEmail", mock.Anything, "Test@Example.com").Return(user, nil)
+	mockThrottleRepo.On("Get", mock.Anything, mock.Anything).Return((*domain.LoginThrottle)(nil), nil)
+	mockThrottleRepo.On("RecordFailure", mock.Anything, accountKey, mock.Anything, mock.Anything).Return(&domain.LoginThrottle{Key: accountKey, FailedCount: 5}, nil)
+	mockThrottleRepo.On("RecordFailure", mock.Anything, ipKey, mock.Anything, mock.Anything).Return(&domain.LoginThrottle{Key: ipKey, FailedCount: 5}, nil)
+	mockThrottleRepo.On("Lock", mock.Anything, accountKey, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	// Execute
+	_, err := authUseCase.Login(context.Background(), &domain.UserLoginInput{Email: "Test@Example.com", Password: "wrong"}, "test-agent", "127.0.0.1")
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrAuthInvalidCredentials)
+	// The client IP is below its own limit
+	mockThrottleRepo.AssertNotCalled(t, "Lock", mock.Anything, ipKey, mock.Anything)
+	mockThrottleRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Login_Throttled(t *testing.T) {
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	tests := []struct {
+		name       string
+		throttle   *domain.LoginThrottle
+		key        string
+		err        error
+		retryAfter time.Duration
+	}{
+		{
+			name:       "locked account",
+			key:        "account:test@example.com",
+			throttle:   &domain.LoginThrottle{FailedCount: 5, WindowStartedAt: time.Now(), LastFailedAt: time.Now(), LockedUntil: &lockedUntil},
+			err:        exceptions.ErrAuthAccountLocked,
+			retryAfter: 10 * time.Minute,
+		},
+		{
+			name:       "locked client ip",
+			key:        "ip:127.0.0.1",
+			throttle:   &domain.LoginThrottle{FailedCount: 50, WindowStartedAt: time.Now(), LastFailedAt: time.Now(), LockedUntil: &lockedUntil},
+			err:        exceptions.ErrAuthTooManyAttempts,
+			retryAfter: 10 * time.Minute,
+		},
+		{
+			name:       "progressive delay",
+			key:        "account:test@example.com",
+			throttle:   &domain.LoginThrottle{FailedCount: 4, WindowStartedAt: time.Now(), LastFailedAt: time.Now()},
+			err:        exceptions.ErrAuthTooManyAttempts,
+			retryAfter: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockUserRepo := new(MockUserRepository)
+			mockThrottleRepo := new(MockLoginThrottleRepository)
+			authUseCase := NewAuthService(mockUserRepo, new(MockSourceRepository), new(MockAccountTokenRepository), new(MockRecoveryCodeRepository), mockThrottleRepo, geoip.NewNoopResolver(), new(MockMailer), getTestJWTConfig(), getTestSigningKeys(), getTestAccountConfig(), new(mocks.LoggerMock))
+
+			// Expectations
+			mockThrottleRepo.On("Get", mock.Anything, tt.key).Return(tt.throttle, nil)
+			mockThrottleRepo.On("Get", mock.Anything, mock.Anything).Return((*domain.LoginThrottle)(nil), nil)
+
+			// Execute
+			_, err := authUseCase.Login(context.Background(), &domain.UserLoginInput{Email: "test@example.com", Password: "password123"}, "test-agent", "127.0.0.1")
+
+			// Assert
+			assert.ErrorIs(t, err, tt.err)
+			var throttledErr *domain.LoginThrottledError
+			assert.ErrorAs(t, err, &throttledErr)
+			assert.InDelta(t, tt.retryAfter.Seconds(), throttledErr.RetryAfter.Seconds(), 1)
+			// Credentials are not checked while throttled
+			mockUserRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+		})
+	}
+}
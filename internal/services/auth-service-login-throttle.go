@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+)
+
+// loginThrottleKeys returns the account and client IP keys a login attempt is
+// counted against
+func loginThrottleKeys(email, ipAddress string) (string, string) {
+	accountKey := domain.LoginThrottleKey(domain.LoginThrottleScopeAccount, strings.ToLower(strings.TrimSpace(email)))
+	ipKey := ""
+	if ipAddress != "" {
+		ipKey = domain.LoginThrottleKey(domain.LoginThrottleScopeIP, ipAddress)
+	}
+	return accountKey, ipKey
+}
+
+// loginDelay is the time a key has to wait after its last failure, it doubles
+// with every failure past DelayAfter
+func (uc *AuthServiceImplementation) loginDelay(failedCount int) time.Duration {
+	cfg := uc.accountConfig.LoginThrottle
+	if cfg.DelayAfter <= 0 || failedCount < cfg.DelayAfter {
+		return 0
+	}
+
+	delay := cfg.BaseDelay
+	for i := cfg.DelayAfter; i < failedCount && delay < cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > cfg.MaxDelay {
+		delay = cfg.MaxDelay
+	}
+	return delay
+}
+
+// checkLoginThrottle rejects an attempt when the account or the client IP is
+// locked, or when the account has to wait its progressive delay. Client IPs
+// are only locked, since many users can share one behind a NAT.
+func (uc *AuthServiceImplementation) checkLoginThrottle(ctx context.Context, accountKey, ipKey string) error {
+	now := time.Now()
+	for _, key := range []string{accountKey, ipKey} {
+		if key == "" {
+			continue
+		}
+
+		throttle, err := uc.loginThrottleRepo.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if throttle == nil {
+			continue
+		}
+
+		if throttle.LockedUntil != nil && now.Before(*throttle.LockedUntil) {
+			lockErr := exceptions.ErrAuthTooManyAttempts
+			if key == accountKey {
+				lockErr = exceptions.ErrAuthAccountLocked
+			}
+			return &domain.LoginThrottledError{Err: lockErr, RetryAfter: throttle.LockedUntil.Sub(now)}
+		}
+
+		windowStart := now.Add(-uc.accountConfig.LoginThrottle.Window)
+		if key == accountKey && !throttle.WindowStartedAt.Before(windowStart) {
+			nextAttempt := throttle.LastFailedAt.Add(uc.loginDelay(throttle.FailedCount))
+			if now.Before(nextAttempt) {
+				return &domain.LoginThrottledError{Err: exceptions.ErrAuthTooManyAttempts, RetryAfter: nextAttempt.Sub(now)}
+			}
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed attempt and locks the keys that reached
+// their maximum. Errors are logged so they never hide the failed login.
+func (uc *AuthServiceImplementation) recordLoginFailure(ctx context.Context, accountKey, ipKey string) {
+	cfg := uc.accountConfig.LoginThrottle
+	now := time.Now()
+
+	limits := map[string]int{accountKey: cfg.MaxFailedAttempts}
+	if ipKey != "" {
+		limits[ipKey] = cfg.MaxFailedAttemptsPerIP
+	}
+
+	for key, limit := range limits {
+		throttle, err := uc.loginThrottleRepo.RecordFailure(ctx, key, now, now.Add(-cfg.Window))
+		if err != nil {
+			uc.logger.Error(ctx, err)
+			continue
+		}
+		if limit <= 0 || throttle.FailedCount < limit {
+			continue
+		}
+
+		uc.logger.ErrorWithVar(ctx, exceptions.ErrAuthTooManyAttempts, map[string]interface{}{
+			"event":        "login_lockout",
+			"key":          key,
+			"failed_count": throttle.FailedCount,
+		})
+		if err := uc.loginThrottleRepo.Lock(ctx, key, now.Add(cfg.LockoutDuration)); err != nil {
+			uc.logger.Error(ctx, err)
+		}
+	}
+}
+
+// resetLoginThrottle clears the failed attempts of an account, after a
+// successful login or a password reset
+func (uc *AuthServiceImplementation) resetLoginThrottle(ctx context.Context, accountKey string) {
+	if err := uc.loginThrottleRepo.Reset(ctx, accountKey); err != nil {
+		uc.logger.Error(ctx, err)
+	}
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/utils/random"
+	"bifur.app/core/internal/utils/totp"
+	"github.com/google/uuid"
+)
+
+const (
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+	// totpSkew is the number of 30s steps of clock drift accepted each way
+	totpSkew = 1
+)
+
+// issueMFAChallenge stores a short-lived challenge that LoginMFA exchanges for
+// a session once the second factor has been verified
+func (uc *AuthServiceImplementation) issueMFAChallenge(ctx context.Context, user *domain.User) (*domain.AuthenticationPayload, error) {
+	challenge := random.GenerateRandomString(64)
+	err := uc.accountTokenRepo.Create(ctx, &domain.AccountToken{
+		UserID:    user.ID,
+		Purpose:   domain.AccountTokenPurposeMFAChallenge,
+		TokenHash: uc.hashAccountToken(challenge),
+		ExpiresAt: time.Now().Add(uc.accountConfig.MFAChallengeExpiry),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthenticationPayload{
+		User:        &domain.AuthenticationUserPayload{ID: user.ID},
+		MFARequired: true,
+		MFAToken:    challenge,
+	}, nil
+}
+
+// LoginMFA completes a login started by Login for a user with MFA enabled. The
+// code may be a TOTP code or one of the user's recovery codes.
+func (uc *AuthServiceImplementation) LoginMFA(ctx context.Context, input *domain.LoginMFAInput, userAgent, ipAddress string) (*domain.AuthenticationPayload, error) {
+	challengeHash := uc.hashAccountToken(input.MFAToken)
+	challenge, err := uc.accountTokenRepo.GetValid(ctx, domain.AccountTokenPurposeMFAChallenge, challengeHash)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrAccountTokenInvalid) {
+			return nil, exceptions.ErrMFAInvalidChallenge
+		}
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil || !user.MFAEnabled() {
+		return nil, exceptions.ErrMFAInvalidChallenge
+	}
+
+	// Second factor attempts share the counters of the password attempts
+	accountKey, ipKey := loginThrottleKeys(user.Email, ipAddress)
+	err = uc.checkLoginThrottle(ctx, accountKey, ipKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.verifySecondFactor(ctx, user, input.Code, true)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrMFAInvalidCode) {
+			uc.recordLoginFailure(ctx, accountKey, ipKey)
+			uc.discardMFAChallenge(ctx, challengeHash)
+		}
+		return nil, err
+	}
+
+	// Consuming the challenge once the code is verified guarantees a single
+	// session per challenge
+	_, err = uc.accountTokenRepo.Consume(ctx, domain.AccountTokenPurposeMFAChallenge, challengeHash)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrAccountTokenInvalid) {
+			return nil, exceptions.ErrMFAInvalidChallenge
+		}
+		return nil, err
+	}
+	uc.resetLoginThrottle(ctx, accountKey)
+
+	return uc.startSession(ctx, user, userAgent, ipAddress)
+}
+
+// discardMFAChallenge consumes a challenge after a wrong code, so each
+// challenge allows a single guess and a new one takes the password again.
+// Errors are logged so they never hide the wrong code.
+func (uc *AuthServiceImplementation) discardMFAChallenge(ctx context.Context, challengeHash string) {
+	_, err := uc.accountTokenRepo.Consume(ctx, domain.AccountTokenPurposeMFAChallenge, challengeHash)
+	if err != nil && !errors.Is(err, exceptions.ErrAccountTokenInvalid) {
+		uc.logger.Error(ctx, err)
+	}
+}
+
+// SetupTOTP generates a new secret for the user. MFA is not enforced until the
+// secret is confirmed with ConfirmTOTP.
+func (uc *AuthServiceImplementation) SetupTOTP(ctx context.Context, userID uuid.UUID) (*domain.TOTPSetupPayload, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled() {
+		return nil, exceptions.ErrMFAAlreadyEnabled
+	}
+
+	user.MFASecret = totp.GenerateSecret()
+	user.UpdatedAt = time.Now()
+	err = uc.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TOTPSetupPayload{
+		Secret:     user.MFASecret,
+		OTPAuthURI: totp.URI(uc.accountConfig.MFAIssuer, user.Email, user.MFASecret),
+	}, nil
+}
+
+// ConfirmTOTP enables MFA once the user proves the authenticator app holds the
+// secret, and returns the recovery codes. They are only shown this once.
+func (uc *AuthServiceImplementation) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) (*domain.RecoveryCodesPayload, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled() {
+		return nil, exceptions.ErrMFAAlreadyEnabled
+	}
+	if user.MFASecret == "" {
+		return nil, exceptions.ErrMFASetupNotStarted
+	}
+
+	step, ok := totp.Validate(user.MFASecret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, exceptions.ErrMFAInvalidCode
+	}
+
+	now := time.Now()
+	user.MFAEnabledAt = &now
+	user.MFALastUsedStep = step
+	user.UpdatedAt = now
+	err = uc.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.replaceRecoveryCodes(ctx, user.ID)
+}
+
+// DisableTOTP turns MFA off, it requires a valid TOTP or recovery code
+func (uc *AuthServiceImplementation) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled() {
+		return exceptions.ErrMFANotEnabled
+	}
+
+	err = uc.verifySecondFactor(ctx, user, code, true)
+	if err != nil {
+		return err
+	}
+
+	user.MFASecret = ""
+	user.MFAEnabledAt = nil
+	user.MFALastUsedStep = 0
+	user.UpdatedAt = time.Now()
+	err = uc.userRepo.Update(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	return uc.recoveryCodeRepo.DeleteByUserID(ctx, user.ID)
+}
+
+// RegenerateRecoveryCodes invalidates the current recovery codes and returns a
+// new set, it requires a valid TOTP code
+func (uc *AuthServiceImplementation) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*domain.RecoveryCodesPayload, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled() {
+		return nil, exceptions.ErrMFANotEnabled
+	}
+
+	err = uc.verifySecondFactor(ctx, user, code, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.replaceRecoveryCodes(ctx, user.ID)
+}
+
+// verifySecondFactor checks a TOTP code, rejecting codes of a time step that
+// was already used, and optionally falls back to the recovery codes
+func (uc *AuthServiceImplementation) verifySecondFactor(ctx context.Context, user *domain.User, code string, allowRecoveryCode bool) error {
+	step, ok := totp.Validate(user.MFASecret, code, time.Now(), totpSkew)
+	if ok {
+		if step <= user.MFALastUsedStep {
+			return exceptions.ErrMFAInvalidCode
+		}
+		user.MFALastUsedStep = step
+		user.UpdatedAt = time.Now()
+		return uc.userRepo.Update(ctx, user)
+	}
+
+	if !allowRecoveryCode {
+		return exceptions.ErrMFAInvalidCode
+	}
+
+	var err error
+	for _, codeHash := range uc.accountTokenHashes(normalizeRecoveryCode(code)) {
+		err = uc.recoveryCodeRepo.Consume(ctx, user.ID, codeHash)
+		if !errors.Is(err, exceptions.ErrMFAInvalidCode) {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info(ctx, "recovery code used by user", user.ID.String())
+	return nil
+}
+
+func (uc *AuthServiceImplementation) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) (*domain.RecoveryCodesPayload, error) {
+	plainCodes := make([]string, recoveryCodeCount)
+	codes := make([]*domain.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		plain := strings.ToLower(random.GenerateRandomString(recoveryCodeSize))
+		plainCodes[i] = plain[:recoveryCodeSize/2] + "-" + plain[recoveryCodeSize/2:]
+		codes[i] = &domain.RecoveryCode{
+			UserID:    userID,
+			CodeHash:  uc.hashAccountToken(plain),
+			CreatedAt: time.Now(),
+		}
+	}
+
+	err := uc.recoveryCodeRepo.ReplaceForUser(ctx, userID, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RecoveryCodesPayload{RecoveryCodes: plainCodes}, nil
+}
+
+// normalizeRecoveryCode makes recovery codes case and separator insensitive
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
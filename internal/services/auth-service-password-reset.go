@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/utils/password"
+	"bifur.app/core/internal/utils/random"
+)
+
+// ForgotPassword emails a password reset link to the user. Unknown emails are
+// ignored silently so the endpoint cannot be used to enumerate accounts.
+func (uc *AuthServiceImplementation) ForgotPassword(ctx context.Context, email string) error {
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Only the latest reset link is valid
+	err = uc.accountTokenRepo.DeleteByUserID(ctx, user.ID, domain.AccountTokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	resetToken := random.GenerateRandomString(64)
+	err = uc.accountTokenRepo.Create(ctx, &domain.AccountToken{
+		UserID:    user.ID,
+		Purpose:   domain.AccountTokenPurposePasswordReset,
+		TokenHash: uc.hashAccountToken(resetToken),
+		ExpiresAt: time.Now().Add(uc.accountConfig.PasswordResetExpiry),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", uc.accountConfig.AppURL, url.QueryEscape(resetToken))
+	err = uc.mailer.Send(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link expires in %s. If you did not request it, you can ignore this email.\n",
+			user.FirstName, link, uc.accountConfig.PasswordResetExpiry,
+		),
+	})
+	if err != nil {
+		uc.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"user_id": user.ID.String(),
+		})
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token and revokes every
+// session of the user
+func (uc *AuthServiceImplementation) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	consumed, err := uc.consumeAccountToken(ctx, domain.AccountTokenPurposePasswordReset, resetToken)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrAccountTokenInvalid) {
+			return exceptions.ErrAuthInvalidResetToken
+		}
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, consumed.UserID)
+	if err != nil {
+		return exceptions.ErrAuthInvalidResetToken
+	}
+
+	hashedPassword, err := password.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	user.Password = hashedPassword
+	user.UpdatedAt = time.Now()
+	err = uc.userRepo.Update(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	// Resetting the password is the way out of a lockout
+	accountKey, _ := loginThrottleKeys(user.Email, "")
+	uc.resetLoginThrottle(ctx, accountKey)
+
+	return uc.LogoutAll(ctx, user.ID.String())
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+)
+
+type AvailabilityServiceImplementation struct {
+	availabilityRepo ports.AvailabilityRepository
+	membershipRepo   ports.MembershipRepository
+	logger           ports.Logger
+}
+
+func NewAvailabilityService(availabilityRepo ports.AvailabilityRepository, membershipRepo ports.MembershipRepository, logger ports.Logger) ports.AvailabilityService {
+	return &AvailabilityServiceImplementation{
+		availabilityRepo: availabilityRepo,
+		membershipRepo:   membershipRepo,
+		logger:           logger,
+	}
+}
+
+func (s *AvailabilityServiceImplementation) List(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID) ([]*domain.AvailabilityRule, error) {
+	_, err := s.membershipRepo.GetByCenterAndUser(ctx, centerID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	return s.availabilityRepo.ListByStaff(ctx, centerID, staffID)
+}
+
+func (s *AvailabilityServiceImplementation) Create(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, input *domain.AvailabilityRuleInput) (*domain.AvailabilityRule, error) {
+	_, err := s.membershipRepo.GetByCenterAndUser(ctx, centerID, staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &domain.AvailabilityRule{
+		CenterID: centerID,
+		UserID:   staffID,
+	}
+	applyAvailabilityInput(rule, input)
+
+	err = s.validateRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.availabilityRepo.Create(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *AvailabilityServiceImplementation) Update(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, ruleID uuid.UUID, input *domain.AvailabilityRuleInput) (*domain.AvailabilityRule, error) {
+	rule, err := s.getStaffRule(ctx, centerID, staffID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	applyAvailabilityInput(rule, input)
+
+	err = s.validateRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.availabilityRepo.Update(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *AvailabilityServiceImplementation) Delete(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, ruleID uuid.UUID) error {
+	_, err := s.getStaffRule(ctx, centerID, staffID, ruleID)
+	if err != nil {
+		return err
+	}
+	return s.availabilityRepo.Delete(ctx, centerID, ruleID)
+}
+
+// getStaffRule hides the rules of other staff members behind a not found
+func (s *AvailabilityServiceImplementation) getStaffRule(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, ruleID uuid.UUID) (*domain.AvailabilityRule, error) {
+	rule, err := s.availabilityRepo.GetByID(ctx, centerID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.UserID != staffID {
+		return nil, exceptions.ErrAvailabilityRuleNotFound
+	}
+	return rule, nil
+}
+
+func applyAvailabilityInput(rule *domain.AvailabilityRule, input *domain.AvailabilityRuleInput) {
+	rule.Weekday = input.Weekday
+	rule.StartTime = input.StartTime
+	rule.EndTime = input.EndTime
+	rule.EffectiveFrom = input.EffectiveFrom
+	rule.EffectiveUntil = input.EffectiveUntil
+	rule.IsActive = input.IsActive == nil || *input.IsActive
+}
+
+// validateRule checks the rule on its own and against the other rules of the
+// staff member in the center
+func (s *AvailabilityServiceImplementation) validateRule(ctx context.Context, rule *domain.AvailabilityRule) error {
+	if rule.Weekday < time.Sunday || rule.Weekday > time.Saturday || rule.EndTime <= rule.StartTime || rule.EndTime > domain.EndOfDay {
+		return exceptions.ErrAvailabilityInvalidInterval
+	}
+	if rule.EffectiveFrom != nil && rule.EffectiveUntil != nil && rule.EffectiveUntil.Before(*rule.EffectiveFrom) {
+		return exceptions.ErrAvailabilityInvalidInterval
+	}
+
+	existing, err := s.availabilityRepo.ListByStaff(ctx, rule.CenterID, rule.UserID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != rule.ID && rule.Overlaps(other) {
+			return exceptions.ErrAvailabilityOverlap
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAvailabilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityRepository) Create(ctx context.Context, rule *domain.AvailabilityRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AvailabilityRule, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.AvailabilityRule), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) ListByStaff(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) ([]*domain.AvailabilityRule, error) {
+	args := m.Called(ctx, centerID, userID)
+	return args.Get(0).([]*domain.AvailabilityRule), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) ListActiveByCenter(ctx context.Context, centerID uuid.UUID) ([]*domain.AvailabilityRule, error) {
+	args := m.Called(ctx, centerID)
+	return args.Get(0).([]*domain.AvailabilityRule), args.Error(1)
+}
+
+func (m *MockAvailabilityRepository) Update(ctx context.Context, rule *domain.AvailabilityRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockAvailabilityRepository) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	args := m.Called(ctx, centerID, id)
+	return args.Error(0)
+}
+
+func mustParseDate(value string) *domain.Date {
+	date, err := domain.ParseDate(value)
+	if err != nil {
+		panic(err)
+	}
+	return &date
+}
+
+func TestAvailabilityService_Create(t *testing.T) {
+	existing := &domain.AvailabilityRule{
+		ID:             uuid.New(),
+		Weekday:        time.Monday,
+		StartTime:      9 * 60,
+		EndTime:        13 * 60,
+		EffectiveUntil: mustParseDate("2025-06-30"),
+		IsActive:       true,
+	}
+	inactive := false
+
+	tests := []struct {
+		name  string
+		input domain.AvailabilityRuleInput
+		err   error
+	}{
+		{"afternoon of the same day", domain.AvailabilityRuleInput{Weekday: time.Monday, StartTime: 15 * 60, EndTime: 19 * 60}, nil},
+		{"adjacent interval", domain.AvailabilityRuleInput{Weekday: time.Monday, StartTime: 13 * 60, EndTime: 14 * 60}, nil},
+		{"another weekday", domain.AvailabilityRuleInput{Weekday: time.Tuesday, StartTime: 9 * 60, EndTime: 13 * 60}, nil},
+		{"after the existing rule ends", domain.AvailabilityRuleInput{Weekday: time.Monday, StartTime: 10 * 60, EndTime: 12 * 60, EffectiveFrom: mustParseDate("2025-07-01")}, nil},
+		{"inactive rule", domain.AvailabilityRuleInput{Weekday: time.Monday, StartTime: 10 * 60, EndTime: 12 * 60, IsActive: &inactive}, nil},
+		{"overlapping interval", domain.AvailabilityRuleInput{Weekday: time.Monday, StartTime: 12 * 60, EndTime: 14 * 60}, exceptions.ErrAvailabilityOverlap},
+		{"overlapping period", domain.AvailabilityRuleInput{Weekday: time.Monday, StartTime: 10 * 60, EndTime: 12 * 60, EffectiveFrom: mustParseDate("2025-06-30")}, exceptions.ErrAvailabilityOverlap},
+		{"ends before it starts", domain.AvailabilityRuleInput{Weekday: time.Monday, StartTime: 19 * 60, EndTime: 15 * 60}, exceptions.ErrAvailabilityInvalidInterval},
+		{"inverted effective dates", domain.AvailabilityRuleInput{Weekday: time.Friday, StartTime: 9 * 60, EndTime: 10 * 60, EffectiveFrom: mustParseDate("2025-02-01"), EffectiveUntil: mustParseDate("2025-01-01")}, exceptions.ErrAvailabilityInvalidInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockAvailabilityRepo := new(MockAvailabilityRepository)
+			mockMembershipRepo := new(MockMembershipRepository)
+			service := NewAvailabilityService(mockAvailabilityRepo, mockMembershipRepo, new(mocks.LoggerMock))
+			centerID, staffID := uuid.New(), uuid.New()
+
+			// Expectations
+			mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+			mockAvailabilityRepo.On("ListByStaff", mock.Anything, centerID, staffID).Return([]*domain.AvailabilityRule{existing}, nil)
+			mockAvailabilityRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AvailabilityRule")).Return(nil)
+
+			// Execute
+			rule, err := service.Create(context.Background(), centerID, staffID, &tt.input)
+
+			// Assert
+			if tt.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, staffID, rule.UserID)
+				mockAvailabilityRepo.AssertCalled(t, "Create", mock.Anything, rule)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+				mockAvailabilityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAvailabilityService_UpdateOtherStaffRule(t *testing.T) {
+	// Setup
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	service := NewAvailabilityService(mockAvailabilityRepo, new(MockMembershipRepository), new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	rule := &domain.AvailabilityRule{ID: uuid.New(), CenterID: centerID, UserID: uuid.New(), Weekday: time.Monday, StartTime: 9 * 60, EndTime: 13 * 60, IsActive: true}
+
+	// Expectations
+	mockAvailabilityRepo.On("GetByID", mock.Anything, centerID, rule.ID).Return(rule, nil)
+
+	// Execute
+	_, err := service.Update(context.Background(), centerID, staffID, rule.ID, &domain.AvailabilityRuleInput{Weekday: time.Monday, StartTime: 9 * 60, EndTime: 14 * 60})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrAvailabilityRuleNotFound)
+	mockAvailabilityRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
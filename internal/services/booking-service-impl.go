@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/utils/random"
+	"bifur.app/core/internal/utils/token"
+	"github.com/google/uuid"
+)
+
+const holdTokenLength = 32
+
+type BookingServiceImplementation struct {
+	centersRepo     ports.CentersRepository
+	appointmentRepo ports.AppointmentRepository
+	holdRepo        ports.SlotHoldRepository
+	leadRepo        ports.LeadRepository
+	slotService     ports.SlotService
+	scheduler       *appointmentScheduler
+	links           *appointmentLinkSigner
+	config          domain.BookingConfig
+	hashSecret      []byte
+	logger          ports.Logger
+}
+
+func NewBookingService(centersRepo ports.CentersRepository, membershipRepo ports.MembershipRepository, seriesRepo ports.AppointmentSeriesRepository, appointmentRepo ports.AppointmentRepository, holdRepo ports.SlotHoldRepository, leadRepo ports.LeadRepository, slotService ports.SlotService, config domain.BookingConfig, appURL string, linkSecret, hashSecret []byte, logger ports.Logger) ports.BookingService {
+	return &BookingServiceImplementation{
+		centersRepo:     centersRepo,
+		appointmentRepo: appointmentRepo,
+		holdRepo:        holdRepo,
+		leadRepo:        leadRepo,
+		slotService:     slotService,
+		scheduler: &appointmentScheduler{
+			centersRepo:    centersRepo,
+			membershipRepo: membershipRepo,
+			seriesRepo:     seriesRepo,
+			holdRepo:       holdRepo,
+		},
+		links:      &appointmentLinkSigner{appURL: appURL, secret: linkSecret},
+		config:     config,
+		hashSecret: hashSecret,
+		logger:     logger,
+	}
+}
+
+func (s *BookingServiceImplementation) GetCenter(ctx context.Context, slug string) (*domain.BookingCenter, error) {
+	center, err := s.centersRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.centersRepo.ListServices(ctx, center.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingCenter := &domain.BookingCenter{
+		Name:                    center.Name,
+		Slug:                    center.Slug,
+		Address:                 center.Address,
+		Phone:                   center.Phone,
+		Email:                   center.Email,
+		Timezone:                center.Timezone,
+		Locale:                  center.Locale,
+		Currency:                center.Currency,
+		CancellationWindowHours: center.CancellationWindowHours,
+		Services:                []*domain.BookingService{},
+	}
+	for _, service := range services {
+		if service.IsActive {
+			bookingCenter.Services = append(bookingCenter.Services, &domain.BookingService{
+				ID:              service.ID,
+				Name:            service.Name,
+				DurationMinutes: service.DurationMinutes,
+			})
+		}
+	}
+	return bookingCenter, nil
+}
+
+func (s *BookingServiceImplementation) FindSlots(ctx context.Context, slug string, query *domain.SlotQuery) ([]*domain.Slot, error) {
+	center, err := s.centersRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.slotService.FindSlots(ctx, center.ID, query)
+}
+
+// Hold only holds a slot that FindSlots returns, so the holds keep to the
+// availability of the staff. Without a staff member, the first one free at
+// that time is held, and the next one when another customer got them first.
+func (s *BookingServiceImplementation) Hold(ctx context.Context, slug string, input *domain.HoldSlotInput, ipAddress string) (*domain.SlotHold, error) {
+	center, err := s.centersRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if s.config.MaxHoldsPerIP > 0 && ipAddress != "" {
+		count, err := s.holdRepo.CountActiveByIP(ctx, center.ID, ipAddress, now)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(s.config.MaxHoldsPerIP) {
+			return nil, exceptions.ErrSlotHoldLimit
+		}
+	}
+
+	slots, err := s.slotService.FindSlots(ctx, center.ID, &domain.SlotQuery{
+		ServiceID: input.ServiceID,
+		StaffID:   input.StaffID,
+		From:      input.StartsAt,
+		To:        input.StartsAt.Add(slotGranularity),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	holdToken := random.GenerateRandomString(holdTokenLength)
+	for _, slot := range slots {
+		if !slot.StartsAt.Equal(input.StartsAt) {
+			continue
+		}
+		hold := &domain.SlotHold{
+			CenterID:  center.ID,
+			StaffID:   slot.StaffID,
+			ServiceID: input.ServiceID,
+			StartsAt:  slot.StartsAt,
+			EndsAt:    slot.EndsAt,
+			ExpiresAt: now.Add(s.config.HoldDuration),
+			TokenHash: token.Hash(holdToken, s.hashSecret),
+			IPAddress: ipAddress,
+		}
+		err = s.holdRepo.Create(ctx, hold)
+		if errors.Is(err, exceptions.ErrSlotUnavailable) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		hold.Token = holdToken
+		return hold, nil
+	}
+	return nil, exceptions.ErrSlotUnavailable
+}
+
+func (s *BookingServiceImplementation) Release(ctx context.Context, slug string, holdID uuid.UUID, holdToken string) error {
+	center, hold, err := s.getHold(ctx, slug, holdID, holdToken)
+	if err != nil {
+		return err
+	}
+	return s.holdRepo.Delete(ctx, center.ID, hold.ID)
+}
+
+func (s *BookingServiceImplementation) Confirm(ctx context.Context, slug string, holdID uuid.UUID, holdToken string, input *domain.ConfirmBookingInput, userAgent, ipAddress string) (*domain.Booking, error) {
+	center, hold, err := s.getHold(ctx, slug, holdID, holdToken)
+	if err != nil {
+		return nil, err
+	}
+	if !hold.IsActive(time.Now()) {
+		return nil, exceptions.ErrSlotHoldExpired
+	}
+
+	customer := &domain.Lead{CenterID: center.ID, Name: input.Name}
+	err = setLeadContact(customer, input.Email, input.Phone)
+	if err != nil {
+		return nil, err
+	}
+	consents := make([]*domain.LeadConsent, len(input.Consents))
+	for i, consentInput := range input.Consents {
+		consents[i], err = newConsent(consentInput, domain.ConsentSourceBooking, nil, userAgent, ipAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lead, err := matchLead(ctx, s.leadRepo, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment := &domain.Appointment{
+		CenterID:      center.ID,
+		StaffID:       hold.StaffID,
+		ServiceID:     hold.ServiceID,
+		LeadID:        &lead.ID,
+		StartsAt:      hold.StartsAt,
+		Status:        domain.AppointmentStatusRequested,
+		CustomerName:  customer.Name,
+		CustomerEmail: customer.Email,
+		CustomerPhone: customer.Phone,
+		Notes:         input.Notes,
+	}
+	err = s.scheduler.scheduleHeld(ctx, appointment, hold.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The consents are recorded before booking, so no appointment is left
+	// without them and a failed confirmation can be retried with the hold
+	for _, consent := range consents {
+		consent.LeadID = lead.ID
+		err = s.leadRepo.CreateConsent(ctx, consent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.appointmentRepo.Create(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+
+	// The booking is made, a hold left behind only lasts until it expires
+	err = s.holdRepo.Delete(ctx, center.ID, hold.ID)
+	if err != nil {
+		s.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"hold_id":        hold.ID.String(),
+			"appointment_id": appointment.ID.String(),
+		})
+	}
+
+	return &domain.Booking{
+		AppointmentID: appointment.ID,
+		LeadID:        lead.ID,
+		StaffID:       appointment.StaffID,
+		ServiceID:     appointment.ServiceID,
+		StartsAt:      appointment.StartsAt,
+		EndsAt:        appointment.EndsAt,
+		Status:        appointment.Status,
+		Links:         s.links.links(appointment),
+	}, nil
+}
+
+// getHold returns the hold of the center, a wrong token is reported as a
+// missing hold
+func (s *BookingServiceImplementation) getHold(ctx context.Context, slug string, holdID uuid.UUID, holdToken string) (*domain.Center, *domain.SlotHold, error) {
+	center, err := s.centersRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	hold, err := s.holdRepo.GetByID(ctx, center.ID, holdID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hold.TokenHash), []byte(token.Hash(holdToken, s.hashSecret))) != 1 {
+		return nil, nil, exceptions.ErrSlotHoldNotFound
+	}
+	return center, hold, nil
+}
+
+// matchLead returns the lead of the center with the email of the customer,
+// or else with their phone, and creates one when no lead has either
+func matchLead(ctx context.Context, leadRepo ports.LeadRepository, customer *domain.Lead) (*domain.Lead, error) {
+	matches, err := leadRepo.FindByContact(ctx, customer.CenterID, customer.Email, customer.Phone)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		err = leadRepo.Create(ctx, customer)
+		if err == nil {
+			return customer, nil
+		}
+		if !errors.Is(err, exceptions.ErrLeadDuplicate) {
+			return nil, err
+		}
+		// Created by a concurrent booking of the same customer
+		matches, err = leadRepo.FindByContact(ctx, customer.CenterID, customer.Email, customer.Phone)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, exceptions.ErrLeadDuplicate
+		}
+	}
+
+	for _, match := range matches {
+		if customer.Email != "" && match.Email == customer.Email {
+			return match, nil
+		}
+	}
+	return matches[0], nil
+}
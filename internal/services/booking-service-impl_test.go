@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"bifur.app/core/internal/utils/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSlotHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockSlotHoldRepository) Create(ctx context.Context, hold *domain.SlotHold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+func (m *MockSlotHoldRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.SlotHold, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.SlotHold), args.Error(1)
+}
+
+func (m *MockSlotHoldRepository) ListActiveInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time, now time.Time) ([]*domain.SlotHold, error) {
+	args := m.Called(ctx, centerID, from, to, now)
+	return args.Get(0).([]*domain.SlotHold), args.Error(1)
+}
+
+func (m *MockSlotHoldRepository) CountActiveByIP(ctx context.Context, centerID uuid.UUID, ipAddress string, now time.Time) (int64, error) {
+	args := m.Called(ctx, centerID, ipAddress, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSlotHoldRepository) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	args := m.Called(ctx, centerID, id)
+	return args.Error(0)
+}
+
+// newEmptyHoldRepository returns a repository of a center without holds
+func newEmptyHoldRepository() *MockSlotHoldRepository {
+	repo := new(MockSlotHoldRepository)
+	repo.On("ListActiveInRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.SlotHold{}, nil).Maybe()
+	return repo
+}
+
+type MockSlotService struct {
+	mock.Mock
+}
+
+func (m *MockSlotService) FindSlots(ctx context.Context, centerID uuid.UUID, query *domain.SlotQuery) ([]*domain.Slot, error) {
+	args := m.Called(ctx, centerID, query)
+	return args.Get(0).([]*domain.Slot), args.Error(1)
+}
+
+var (
+	testBookingConfig = domain.BookingConfig{HoldDuration: 10 * time.Minute, MaxHoldsPerIP: 3}
+	testHoldSecret    = []byte("hold-secret")
+)
+
+// heldSlot returns an active hold of the center placed with holdToken
+func heldSlot(centerID uuid.UUID, holdToken string) *domain.SlotHold {
+	startsAt := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	return &domain.SlotHold{
+		ID:        uuid.New(),
+		CenterID:  centerID,
+		StaffID:   uuid.New(),
+		ServiceID: uuid.New(),
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(30 * time.Minute),
+		ExpiresAt: time.Now().Add(5 * time.Minute),
+		TokenHash: token.Hash(holdToken, testHoldSecret),
+	}
+}
+
+func TestBookingService_HoldFallsBackToAnotherStaffMember(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockSlotService := new(MockSlotService)
+	service := NewBookingService(mockCentersRepo, new(MockMembershipRepository), newEmptySeriesRepository(), new(MockAppointmentRepository), mockHoldRepo, new(MockLeadRepository), mockSlotService, testBookingConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	serviceID, aliceID, bobID := uuid.New(), uuid.New(), uuid.New()
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	slots := []*domain.Slot{
+		{StaffID: aliceID, StartsAt: startsAt, EndsAt: startsAt.Add(30 * time.Minute)},
+		{StaffID: bobID, StartsAt: startsAt, EndsAt: startsAt.Add(30 * time.Minute)},
+	}
+
+	// Expectations
+	mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+	mockHoldRepo.On("CountActiveByIP", mock.Anything, center.ID, "203.0.113.7", mock.Anything).Return(int64(2), nil)
+	mockSlotService.On("FindSlots", mock.Anything, center.ID, &domain.SlotQuery{ServiceID: serviceID, From: startsAt, To: startsAt.Add(slotGranularity)}).Return(slots, nil)
+	mockHoldRepo.On("Create", mock.Anything, mock.MatchedBy(func(hold *domain.SlotHold) bool { return hold.StaffID == aliceID })).Return(exceptions.ErrSlotUnavailable)
+	mockHoldRepo.On("Create", mock.Anything, mock.MatchedBy(func(hold *domain.SlotHold) bool { return hold.StaffID == bobID })).Return(nil)
+
+	// Execute
+	hold, err := service.Hold(context.Background(), center.Slug, &domain.HoldSlotInput{ServiceID: serviceID, StartsAt: startsAt}, "203.0.113.7")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, bobID, hold.StaffID)
+	assert.Equal(t, center.ID, hold.CenterID)
+	assert.True(t, startsAt.Add(30*time.Minute).Equal(hold.EndsAt))
+	assert.WithinDuration(t, time.Now().Add(testBookingConfig.HoldDuration), hold.ExpiresAt, time.Minute)
+	assert.Len(t, hold.Token, holdTokenLength)
+	assert.Equal(t, token.Hash(hold.Token, testHoldSecret), hold.TokenHash)
+	mockHoldRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestBookingService_HoldRejections(t *testing.T) {
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	tests := []struct {
+		name     string
+		slug     string
+		held     int64
+		slots    []*domain.Slot
+		expected error
+	}{
+		{"unknown center", "unknown", 0, nil, exceptions.ErrCenterNotFound},
+		{"too many holds", "clinica-sol", 3, nil, exceptions.ErrSlotHoldLimit},
+		{"slot not offered", "clinica-sol", 0, []*domain.Slot{{StaffID: uuid.New(), StartsAt: startsAt.Add(15 * time.Minute)}}, exceptions.ErrSlotUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockCentersRepo := new(MockCentersRepository)
+			mockHoldRepo := new(MockSlotHoldRepository)
+			mockSlotService := new(MockSlotService)
+			service := NewBookingService(mockCentersRepo, new(MockMembershipRepository), newEmptySeriesRepository(), new(MockAppointmentRepository), mockHoldRepo, new(MockLeadRepository), mockSlotService, testBookingConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+			center := &domain.Center{ID: uuid.New(), Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+			mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil).Maybe()
+			mockCentersRepo.On("GetBySlug", mock.Anything, "unknown").Return((*domain.Center)(nil), exceptions.ErrCenterNotFound).Maybe()
+			mockHoldRepo.On("CountActiveByIP", mock.Anything, center.ID, mock.Anything, mock.Anything).Return(tt.held, nil).Maybe()
+			mockSlotService.On("FindSlots", mock.Anything, center.ID, mock.Anything).Return(tt.slots, nil).Maybe()
+
+			// Execute
+			_, err := service.Hold(context.Background(), tt.slug, &domain.HoldSlotInput{ServiceID: uuid.New(), StartsAt: startsAt}, "203.0.113.7")
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			mockHoldRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBookingService_ConfirmMatchesLead(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockLeadRepo := new(MockLeadRepository)
+	service := NewBookingService(mockCentersRepo, mockMembershipRepo, newEmptySeriesRepository(), mockAppointmentRepo, mockHoldRepo, mockLeadRepo, new(MockSlotService), testBookingConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	hold := heldSlot(center.ID, "hold-token")
+	byPhone := &domain.Lead{ID: uuid.New(), CenterID: center.ID, Phone: "+34612345678"}
+	byEmail := &domain.Lead{ID: uuid.New(), CenterID: center.ID, Email: "ana@example.com"}
+	input := &domain.ConfirmBookingInput{
+		Name:     "Ana García",
+		Email:    "Ana@Example.com",
+		Phone:    "+34 612 345 678",
+		Consents: []*domain.ConsentInput{{Purpose: domain.ConsentPurposeDataProcessing, Granted: true, PolicyVersion: "2024-01"}},
+	}
+
+	// Expectations
+	mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+	mockHoldRepo.On("GetByID", mock.Anything, center.ID, hold.ID).Return(hold, nil)
+	mockLeadRepo.On("FindByContact", mock.Anything, center.ID, "ana@example.com", "+34612345678").Return([]*domain.Lead{byPhone, byEmail}, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, center.ID, hold.StaffID).Return(&domain.CenterMembership{CenterID: center.ID, UserID: hold.StaffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, hold.ServiceID).Return(&domain.CenterService{ID: hold.ServiceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, center.ID, hold.StartsAt, hold.EndsAt, mock.Anything).Return([]*domain.SlotHold{hold}, nil)
+	mockAppointmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Appointment")).Return(nil)
+	mockHoldRepo.On("Delete", mock.Anything, center.ID, hold.ID).Return(nil)
+	mockLeadRepo.On("CreateConsent", mock.Anything, mock.AnythingOfType("*domain.LeadConsent")).Return(nil)
+
+	// Execute
+	booking, err := service.Confirm(context.Background(), center.Slug, hold.ID, "hold-token", input, "Mozilla/5.0", "203.0.113.7")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, byEmail.ID, booking.LeadID)
+	assert.Equal(t, domain.AppointmentStatusRequested, booking.Status)
+	assert.Contains(t, booking.Links.CancelURL, "https://app.example.com/appointments/cancel?token=")
+	appointment := mockAppointmentRepo.Calls[0].Arguments.Get(1).(*domain.Appointment)
+	assert.Equal(t, byEmail.ID, *appointment.LeadID)
+	assert.Equal(t, hold.StaffID, appointment.StaffID)
+	assert.True(t, hold.StartsAt.Equal(appointment.StartsAt))
+	assert.True(t, hold.EndsAt.Equal(appointment.EndsAt))
+	assert.Equal(t, "ana@example.com", appointment.CustomerEmail)
+	assert.Nil(t, appointment.CreatedBy)
+	consent := mockLeadRepo.Calls[1].Arguments.Get(1).(*domain.LeadConsent)
+	assert.Equal(t, byEmail.ID, consent.LeadID)
+	assert.Equal(t, domain.ConsentSourceBooking, consent.Source)
+	assert.Equal(t, "203.0.113.7", consent.IPAddress)
+	assert.Nil(t, consent.RecordedBy)
+	mockLeadRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestBookingService_ConfirmCreatesLead(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockLeadRepo := new(MockLeadRepository)
+	service := NewBookingService(mockCentersRepo, mockMembershipRepo, newEmptySeriesRepository(), mockAppointmentRepo, mockHoldRepo, mockLeadRepo, new(MockSlotService), testBookingConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	hold := heldSlot(center.ID, "hold-token")
+
+	// Expectations
+	mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+	mockHoldRepo.On("GetByID", mock.Anything, center.ID, hold.ID).Return(hold, nil)
+	mockLeadRepo.On("FindByContact", mock.Anything, center.ID, "", "612345678").Return([]*domain.Lead{}, nil)
+	mockLeadRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Lead")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Lead).ID = uuid.New()
+	}).Return(nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, center.ID, hold.StaffID).Return(&domain.CenterMembership{CenterID: center.ID, UserID: hold.StaffID}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, hold.ServiceID).Return(&domain.CenterService{ID: hold.ServiceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, center.ID, hold.StartsAt, hold.EndsAt, mock.Anything).Return([]*domain.SlotHold{hold}, nil)
+	mockAppointmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Appointment")).Return(nil)
+	mockHoldRepo.On("Delete", mock.Anything, center.ID, hold.ID).Return(nil)
+
+	// Execute
+	booking, err := service.Confirm(context.Background(), center.Slug, hold.ID, "hold-token", &domain.ConfirmBookingInput{Name: "Ana", Phone: "612 345 678"}, "", "")
+
+	// Assert
+	assert.NoError(t, err)
+	lead := mockLeadRepo.Calls[1].Arguments.Get(1).(*domain.Lead)
+	assert.Equal(t, lead.ID, booking.LeadID)
+	assert.Equal(t, center.ID, lead.CenterID)
+	assert.Equal(t, "Ana", lead.Name)
+	assert.Equal(t, "612345678", lead.Phone)
+}
+
+func TestBookingService_ConfirmKeepsHoldWhenConsentFails(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockLeadRepo := new(MockLeadRepository)
+	service := NewBookingService(mockCentersRepo, mockMembershipRepo, newEmptySeriesRepository(), mockAppointmentRepo, mockHoldRepo, mockLeadRepo, new(MockSlotService), testBookingConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	hold := heldSlot(center.ID, "hold-token")
+	lead := &domain.Lead{ID: uuid.New(), CenterID: center.ID, Email: "ana@example.com"}
+	input := &domain.ConfirmBookingInput{
+		Name:     "Ana García",
+		Email:    "ana@example.com",
+		Consents: []*domain.ConsentInput{{Purpose: domain.ConsentPurposeDataProcessing, Granted: true, PolicyVersion: "2024-01"}},
+	}
+	failure := errors.New("connection reset")
+
+	// Expectations
+	mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+	mockHoldRepo.On("GetByID", mock.Anything, center.ID, hold.ID).Return(hold, nil)
+	mockLeadRepo.On("FindByContact", mock.Anything, center.ID, "ana@example.com", "").Return([]*domain.Lead{lead}, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, center.ID, hold.StaffID).Return(&domain.CenterMembership{CenterID: center.ID, UserID: hold.StaffID}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, hold.ServiceID).Return(&domain.CenterService{ID: hold.ServiceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, center.ID, hold.StartsAt, hold.EndsAt, mock.Anything).Return([]*domain.SlotHold{hold}, nil)
+	mockLeadRepo.On("CreateConsent", mock.Anything, mock.AnythingOfType("*domain.LeadConsent")).Return(failure)
+
+	// Execute
+	_, err := service.Confirm(context.Background(), center.Slug, hold.ID, "hold-token", input, "Mozilla/5.0", "203.0.113.7")
+
+	// Assert
+	assert.ErrorIs(t, err, failure)
+	mockAppointmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockHoldRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBookingService_ConfirmRejectsHold(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		expired  bool
+		expected error
+	}{
+		{"wrong token", "another-token", false, exceptions.ErrSlotHoldNotFound},
+		{"expired", "hold-token", true, exceptions.ErrSlotHoldExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockCentersRepo := new(MockCentersRepository)
+			mockAppointmentRepo := new(MockAppointmentRepository)
+			mockHoldRepo := new(MockSlotHoldRepository)
+			mockLeadRepo := new(MockLeadRepository)
+			service := NewBookingService(mockCentersRepo, new(MockMembershipRepository), newEmptySeriesRepository(), mockAppointmentRepo, mockHoldRepo, mockLeadRepo, new(MockSlotService), testBookingConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+			center := &domain.Center{ID: uuid.New(), Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+			hold := heldSlot(center.ID, "hold-token")
+			if tt.expired {
+				hold.ExpiresAt = time.Now().Add(-time.Second)
+			}
+			mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+			mockHoldRepo.On("GetByID", mock.Anything, center.ID, hold.ID).Return(hold, nil)
+
+			// Execute
+			_, err := service.Confirm(context.Background(), center.Slug, hold.ID, tt.token, &domain.ConfirmBookingInput{Name: "Ana", Email: "ana@example.com"}, "", "")
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			mockAppointmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			mockLeadRepo.AssertNotCalled(t, "FindByContact", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
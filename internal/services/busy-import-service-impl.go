@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/scheduling"
+	"bifur.app/core/internal/utils/ical"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxImportedBlocks bounds the blocks of a single import, occurrences of
+	// recurring events included
+	maxImportedBlocks = 5000
+	// maxExpandedOccurrences stops the expansion of a recurring event that
+	// started long ago, before its occurrences reach the imported range
+	maxExpandedOccurrences = 100000
+)
+
+type BusyImportServiceImplementation struct {
+	timeBlockRepo  ports.TimeBlockRepository
+	calendarRepo   ports.ExternalCalendarRepository
+	centersRepo    ports.CentersRepository
+	membershipRepo ports.MembershipRepository
+	fetcher        ports.CalendarFetcher
+	config         domain.CalendarConfig
+	logger         ports.Logger
+}
+
+func NewBusyImportService(timeBlockRepo ports.TimeBlockRepository, calendarRepo ports.ExternalCalendarRepository, centersRepo ports.CentersRepository, membershipRepo ports.MembershipRepository, fetcher ports.CalendarFetcher, config domain.CalendarConfig, logger ports.Logger) ports.BusyImportService {
+	return &BusyImportServiceImplementation{
+		timeBlockRepo:  timeBlockRepo,
+		calendarRepo:   calendarRepo,
+		centersRepo:    centersRepo,
+		membershipRepo: membershipRepo,
+		fetcher:        fetcher,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+func (s *BusyImportServiceImplementation) ImportFile(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, file io.Reader) (*domain.BusyImportResult, error) {
+	data, err := io.ReadAll(io.LimitReader(file, s.config.ImportMaxBytes+1))
+	if err != nil {
+		return nil, exceptions.ErrExternalCalendarInvalidData
+	}
+	if int64(len(data)) > s.config.ImportMaxBytes {
+		return nil, exceptions.ErrExternalCalendarTooLarge
+	}
+	return s.importCalendar(ctx, centerID, staffID, domain.TimeBlockSourceICSFile, data)
+}
+
+func (s *BusyImportServiceImplementation) GetCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID) (*domain.ExternalCalendar, error) {
+	return s.calendarRepo.Get(ctx, centerID, staffID)
+}
+
+func (s *BusyImportServiceImplementation) SetCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, input *domain.ExternalCalendarInput) (*domain.ExternalCalendar, error) {
+	_, err := s.membershipRepo.GetByCenterAndUser(ctx, centerID, staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarURL, err := url.Parse(strings.TrimSpace(input.URL))
+	if err != nil || calendarURL.Host == "" {
+		return nil, exceptions.ErrExternalCalendarInvalidURL
+	}
+	switch strings.ToLower(calendarURL.Scheme) {
+	case "http", "https", "webcal":
+	default:
+		return nil, exceptions.ErrExternalCalendarInvalidURL
+	}
+
+	calendar := &domain.ExternalCalendar{
+		CenterID: centerID,
+		UserID:   staffID,
+		URL:      calendarURL.String(),
+	}
+	err = s.calendarRepo.Save(ctx, calendar)
+	if err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func (s *BusyImportServiceImplementation) DeleteCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID) error {
+	return s.calendarRepo.Delete(ctx, centerID, staffID, time.Now())
+}
+
+func (s *BusyImportServiceImplementation) SyncCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID) (*domain.BusyImportResult, error) {
+	calendar, err := s.calendarRepo.Get(ctx, centerID, staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.fetcher.Fetch(ctx, calendar.URL)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.importCalendar(ctx, centerID, staffID, domain.TimeBlockSourceICSURL, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.calendarRepo.MarkSynced(ctx, calendar.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importCalendar replaces the upcoming blocks of the source with the busy
+// events of the calendar. A calendar URL serves the whole calendar, so the
+// blocks of the events it no longer has are deleted. A file may hold a part
+// of it only, so only the blocks of the events in the file are replaced.
+func (s *BusyImportServiceImplementation) importCalendar(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, source domain.TimeBlockSource, data []byte) (*domain.BusyImportResult, error) {
+	_, err := s.membershipRepo.GetByCenterAndUser(ctx, centerID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	center, err := s.centersRepo.GetByID(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := center.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	calendar, err := ical.Parse(data)
+	if err != nil || calendar.Name != "VCALENDAR" {
+		return nil, exceptions.ErrExternalCalendarInvalidData
+	}
+	now := time.Now()
+	busy, err := readBusyTimes(calendar, loc, now, now.Add(s.config.ImportHorizon))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, block := range busy.blocks {
+		block.CenterID = centerID
+		block.UserID = &staffID
+		block.Source = source
+	}
+	uids := busy.uids
+	if source == domain.TimeBlockSourceICSURL {
+		uids = nil
+	}
+
+	result, err := s.timeBlockRepo.SyncImported(ctx, centerID, staffID, source, now, uids, busy.blocks)
+	if err != nil {
+		return nil, err
+	}
+	result.Skipped = busy.skipped
+	return result, nil
+}
+
+// busyTimes are the blocks read from a calendar, along with the UIDs of all
+// its events, busy or not
+type busyTimes struct {
+	blocks  []*domain.TimeBlock
+	uids    []string
+	skipped int
+}
+
+// busyEvent is a VEVENT with its times read. All-day events last a number
+// of days, so that they keep covering whole days across DST changes.
+type busyEvent struct {
+	component *ical.Component
+	start     time.Time
+	allDay    bool
+	duration  time.Duration
+	days      int
+}
+
+func (e *busyEvent) end(start time.Time) time.Time {
+	if e.allDay {
+		return start.AddDate(0, 0, e.days)
+	}
+	return start.Add(e.duration)
+}
+
+// isBusy leaves out the cancelled events and the ones shown as free
+func (e *busyEvent) isBusy() bool {
+	if status, found := e.component.Get("STATUS"); found && strings.EqualFold(status.Value, "CANCELLED") {
+		return false
+	}
+	if transp, found := e.component.Get("TRANSP"); found && strings.EqualFold(transp.Value, "TRANSPARENT") {
+		return false
+	}
+	return true
+}
+
+// readBusyTimes turns the events of the calendar between from and to into
+// blocks, expanding the recurring ones. Their times without a timezone, and
+// the dates of all-day events, are read in the timezone of the center. The
+// occurrences of a recurring event are told apart by their original start,
+// the RECURRENCE-ID of the events that reschedule them.
+func readBusyTimes(calendar *ical.Component, loc *time.Location, from time.Time, to time.Time) (*busyTimes, error) {
+	busy := &busyTimes{uids: []string{}}
+	masters := make(map[string]*busyEvent)
+	overrides := make(map[string]map[time.Time]*busyEvent)
+	for _, component := range calendar.Components {
+		if component.Name != "VEVENT" {
+			continue
+		}
+		uid, found := component.Get("UID")
+		if !found || uid.Value == "" {
+			busy.skipped++
+			continue
+		}
+		event, err := readBusyEvent(component, loc)
+		if err != nil {
+			busy.skipped++
+			continue
+		}
+
+		if _, seen := masters[uid.Value]; !seen && overrides[uid.Value] == nil {
+			busy.uids = append(busy.uids, uid.Value)
+		}
+		recurrenceID, found := component.Get("RECURRENCE-ID")
+		if !found {
+			masters[uid.Value] = event
+			continue
+		}
+		originalStart, _, err := recurrenceID.Time(loc)
+		if err != nil {
+			busy.skipped++
+			continue
+		}
+		if overrides[uid.Value] == nil {
+			overrides[uid.Value] = make(map[time.Time]*busyEvent)
+		}
+		overrides[uid.Value][originalStart.UTC()] = event
+	}
+
+	add := func(uid string, key string, event *busyEvent, start time.Time) {
+		if !event.isBusy() {
+			return
+		}
+		end := event.end(start)
+		if !end.After(from) || !start.Before(to) {
+			return
+		}
+		busy.blocks = append(busy.blocks, &domain.TimeBlock{
+			StartsAt: start,
+			EndsAt:   end,
+			AllDay:   event.allDay,
+			// The summary stays in the staff member's calendar
+			Reason:      domain.TimeBlockReasonOther,
+			ExternalUID: uid,
+			ExternalKey: key,
+		})
+	}
+
+	for _, uid := range busy.uids {
+		eventOverrides := overrides[uid]
+		master := masters[uid]
+		if master != nil {
+			if _, recurring := master.component.Get("RRULE"); !recurring {
+				add(uid, uid, master, master.start)
+			} else if err := expandBusyEvent(uid, master, eventOverrides, to, add); err != nil {
+				busy.skipped++
+			}
+		}
+		for originalStart, override := range eventOverrides {
+			add(uid, busyOccurrenceKey(uid, originalStart), override, override.start)
+		}
+	}
+
+	if len(busy.blocks) > maxImportedBlocks {
+		return nil, exceptions.ErrExternalCalendarTooLarge
+	}
+	return busy, nil
+}
+
+// expandBusyEvent adds the occurrences of a recurring event starting before
+// to, leaving out its EXDATE and the rescheduled ones
+func expandBusyEvent(uid string, master *busyEvent, overrides map[time.Time]*busyEvent, to time.Time, add func(string, string, *busyEvent, time.Time)) error {
+	rrule, _ := master.component.Get("RRULE")
+	rule, err := scheduling.ParseRRule(rrule.Value)
+	if err != nil {
+		return err
+	}
+	excluded := make(map[time.Time]bool)
+	for _, exDate := range master.component.GetAll("EXDATE") {
+		times, _, err := exDate.Times(master.start.Location())
+		if err != nil {
+			return err
+		}
+		for _, t := range times {
+			excluded[t.UTC()] = true
+		}
+	}
+
+	it := rule.Iterator(master.start)
+	for i := 0; i < maxExpandedOccurrences; i++ {
+		start, ok := it.Next()
+		if !ok || !start.Before(to) {
+			break
+		}
+		if excluded[start.UTC()] || overrides[start.UTC()] != nil {
+			continue
+		}
+		add(uid, busyOccurrenceKey(uid, start), master, start)
+	}
+	return nil
+}
+
+func busyOccurrenceKey(uid string, originalStart time.Time) string {
+	return uid + "/" + originalStart.UTC().Format(ical.UTCLayout)
+}
+
+// readBusyEvent reads the start and the length of the event, from its DTEND
+// or its DURATION. All-day events without either last a day, others without
+// either take no time and can't be imported.
+func readBusyEvent(component *ical.Component, loc *time.Location) (*busyEvent, error) {
+	dtStart, found := component.Get("DTSTART")
+	if !found {
+		return nil, exceptions.ErrExternalCalendarInvalidData
+	}
+	start, allDay, err := dtStart.Time(loc)
+	if err != nil {
+		return nil, err
+	}
+	event := &busyEvent{component: component, start: start, allDay: allDay, days: 1}
+
+	if dtEnd, found := component.Get("DTEND"); found {
+		end, _, err := dtEnd.Time(loc)
+		if err != nil {
+			return nil, err
+		}
+		event.duration = end.Sub(start)
+		event.days = daysBetween(domain.DateOf(start), domain.DateOf(end))
+	} else if durationProperty, found := component.Get("DURATION"); found {
+		duration, err := ical.ParseDuration(durationProperty.Value)
+		if err != nil {
+			return nil, err
+		}
+		event.duration = duration
+		event.days = int(duration / (24 * time.Hour))
+	}
+
+	if (allDay && event.days <= 0) || (!allDay && event.duration <= 0) {
+		return nil, exceptions.ErrExternalCalendarInvalidData
+	}
+	return event, nil
+}
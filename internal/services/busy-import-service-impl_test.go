@@ -0,0 +1,211 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExternalCalendarRepository struct {
+	mock.Mock
+}
+
+func (m *MockExternalCalendarRepository) Save(ctx context.Context, calendar *domain.ExternalCalendar) error {
+	args := m.Called(ctx, calendar)
+	return args.Error(0)
+}
+
+func (m *MockExternalCalendarRepository) Get(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.ExternalCalendar, error) {
+	args := m.Called(ctx, centerID, userID)
+	return args.Get(0).(*domain.ExternalCalendar), args.Error(1)
+}
+
+func (m *MockExternalCalendarRepository) MarkSynced(ctx context.Context, id uuid.UUID, syncedAt time.Time) error {
+	args := m.Called(ctx, id, syncedAt)
+	return args.Error(0)
+}
+
+func (m *MockExternalCalendarRepository) Delete(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, from time.Time) error {
+	args := m.Called(ctx, centerID, userID, from)
+	return args.Error(0)
+}
+
+type MockCalendarFetcher struct {
+	mock.Mock
+}
+
+func (m *MockCalendarFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	args := m.Called(ctx, url)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+var testImportConfig = domain.CalendarConfig{
+	ImportHorizon:  365 * 24 * time.Hour,
+	ImportMaxBytes: 1 << 20,
+}
+
+// calendarOf wraps the events in a VCALENDAR with CRLF line endings
+func calendarOf(events ...string) []byte {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//Test//EN"}
+	for _, event := range events {
+		lines = append(lines, strings.Split(strings.TrimSpace(event), "\n")...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func TestBusyImportService_ImportFile(t *testing.T) {
+	// Setup
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewBusyImportService(mockTimeBlockRepo, new(MockExternalCalendarRepository), mockCentersRepo, mockMembershipRepo, new(MockCalendarFetcher), testImportConfig, new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	now := time.Now().In(madrid)
+	day := func(days, hour int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, hour, 0, 0, 0, madrid)
+	}
+	local := func(t time.Time) string { return t.Format("20060102T150405") }
+	london, _ := time.LoadLocation("Europe/London")
+	data := calendarOf(
+		// A doctor's appointment, in another timezone
+		"BEGIN:VEVENT\nUID:doctor@example.com\nDTSTART;TZID=Europe/London:"+local(day(2, 10).In(london))+"\nDTEND;TZID=Europe/London:"+local(day(2, 11).In(london))+"\nSUMMARY:Doctor\nEND:VEVENT",
+		// A weekly class, the second occurrence excluded and the third moved
+		"BEGIN:VEVENT\nUID:class@example.com\nDTSTART;TZID=Europe/Madrid:"+local(day(1, 18))+"\nDURATION:PT1H30M\nRRULE:FREQ=WEEKLY;COUNT=4\nEXDATE;TZID=Europe/Madrid:"+local(day(8, 18))+"\nEND:VEVENT",
+		"BEGIN:VEVENT\nUID:class@example.com\nRECURRENCE-ID;TZID=Europe/Madrid:"+local(day(15, 18))+"\nDTSTART;TZID=Europe/Madrid:"+local(day(16, 8))+"\nDTEND;TZID=Europe/Madrid:"+local(day(16, 9))+"\nEND:VEVENT",
+		// A day off, as a date
+		"BEGIN:VEVENT\nUID:day-off@example.com\nDTSTART;VALUE=DATE:"+day(3, 0).Format("20060102")+"\nEND:VEVENT",
+		// Neither cancelled nor free events are busy, events without UID
+		// can't be imported again
+		"BEGIN:VEVENT\nUID:cancelled@example.com\nDTSTART:"+day(4, 9).UTC().Format("20060102T150405Z")+"\nDURATION:PT1H\nSTATUS:CANCELLED\nEND:VEVENT",
+		"BEGIN:VEVENT\nUID:free@example.com\nDTSTART:"+day(4, 9).UTC().Format("20060102T150405Z")+"\nDURATION:PT1H\nTRANSP:TRANSPARENT\nEND:VEVENT",
+		"BEGIN:VEVENT\nDTSTART:"+day(4, 9).UTC().Format("20060102T150405Z")+"\nDURATION:PT1H\nEND:VEVENT",
+	)
+
+	// Expectations
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Timezone: "Europe/Madrid"}, nil)
+	mockTimeBlockRepo.On("SyncImported", mock.Anything, centerID, staffID, domain.TimeBlockSourceICSFile, mock.Anything, mock.Anything, mock.Anything).Return(&domain.BusyImportResult{Created: 5}, nil)
+
+	// Execute
+	result, err := service.ImportFile(context.Background(), centerID, staffID, bytes.NewReader(data))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.BusyImportResult{Created: 5, Skipped: 1}, result)
+	call := mockTimeBlockRepo.Calls[0].Arguments
+	assert.Equal(t, []string{"doctor@example.com", "class@example.com", "day-off@example.com", "cancelled@example.com", "free@example.com"}, call.Get(5))
+	blocks := make(map[string]*domain.TimeBlock)
+	for _, block := range call.Get(6).([]*domain.TimeBlock) {
+		assert.Equal(t, staffID, *block.UserID)
+		assert.Equal(t, domain.TimeBlockSourceICSFile, block.Source)
+		assert.Empty(t, block.Note)
+		blocks[block.ExternalKey] = block
+	}
+	assert.Len(t, blocks, 5)
+	occurrence := func(t time.Time) string { return "class@example.com/" + t.UTC().Format("20060102T150405Z") }
+	expected := map[string][2]time.Time{
+		"doctor@example.com":    {day(2, 10), day(2, 11)},
+		occurrence(day(1, 18)):  {day(1, 18), day(1, 18).Add(90 * time.Minute)},
+		occurrence(day(15, 18)): {day(16, 8), day(16, 9)},
+		occurrence(day(22, 18)): {day(22, 18), day(22, 18).Add(90 * time.Minute)},
+		"day-off@example.com":   {day(3, 0), day(4, 0)},
+	}
+	for key, times := range expected {
+		if assert.Contains(t, blocks, key) {
+			assert.True(t, times[0].Equal(blocks[key].StartsAt), key)
+			assert.True(t, times[1].Equal(blocks[key].EndsAt), key)
+		}
+	}
+	assert.True(t, blocks["day-off@example.com"].AllDay)
+	assert.NotContains(t, blocks, occurrence(day(8, 18)))
+}
+
+func TestBusyImportService_ImportFileRejectsInvalidData(t *testing.T) {
+	// Setup
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewBusyImportService(mockTimeBlockRepo, new(MockExternalCalendarRepository), mockCentersRepo, mockMembershipRepo, new(MockCalendarFetcher), testImportConfig, new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	large := bytes.NewReader(make([]byte, 2*testImportConfig.ImportMaxBytes))
+
+	// Expectations
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Timezone: "Europe/Madrid"}, nil)
+
+	// Execute
+	_, invalidErr := service.ImportFile(context.Background(), centerID, staffID, strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n"))
+	_, largeErr := service.ImportFile(context.Background(), centerID, staffID, large)
+
+	// Assert
+	assert.ErrorIs(t, invalidErr, exceptions.ErrExternalCalendarInvalidData)
+	assert.ErrorIs(t, largeErr, exceptions.ErrExternalCalendarTooLarge)
+	assert.Equal(t, int(testImportConfig.ImportMaxBytes-1), large.Len(), "the file is read up to one byte past the limit")
+	mockTimeBlockRepo.AssertNotCalled(t, "SyncImported", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBusyImportService_SyncCalendar(t *testing.T) {
+	// Setup
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockCalendarRepo := new(MockExternalCalendarRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	mockFetcher := new(MockCalendarFetcher)
+	service := NewBusyImportService(mockTimeBlockRepo, mockCalendarRepo, mockCentersRepo, mockMembershipRepo, mockFetcher, testImportConfig, new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	calendar := &domain.ExternalCalendar{ID: uuid.New(), CenterID: centerID, UserID: staffID, URL: "webcal://calendar.example.com/private.ics"}
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Hour)
+	data := calendarOf("BEGIN:VEVENT\nUID:dentist@example.com\nDTSTART:" + start.Format("20060102T150405Z") + "\nDTEND:" + start.Add(time.Hour).Format("20060102T150405Z") + "\nEND:VEVENT")
+
+	// Expectations
+	mockCalendarRepo.On("Get", mock.Anything, centerID, staffID).Return(calendar, nil)
+	mockFetcher.On("Fetch", mock.Anything, calendar.URL).Return(data, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Timezone: "Europe/Madrid"}, nil)
+	mockTimeBlockRepo.On("SyncImported", mock.Anything, centerID, staffID, domain.TimeBlockSourceICSURL, mock.Anything, []string(nil), mock.Anything).Return(&domain.BusyImportResult{Created: 1, Deleted: 2}, nil)
+	mockCalendarRepo.On("MarkSynced", mock.Anything, calendar.ID, mock.Anything).Return(nil)
+
+	// Execute
+	result, err := service.SyncCalendar(context.Background(), centerID, staffID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.BusyImportResult{Created: 1, Deleted: 2}, result)
+	blocks := mockTimeBlockRepo.Calls[0].Arguments.Get(6).([]*domain.TimeBlock)
+	if assert.Len(t, blocks, 1) {
+		assert.Equal(t, "dentist@example.com", blocks[0].ExternalKey)
+		assert.True(t, start.Equal(blocks[0].StartsAt))
+	}
+	mockCalendarRepo.AssertExpectations(t)
+}
+
+func TestBusyImportService_SyncCalendarFetchFailure(t *testing.T) {
+	// Setup
+	mockCalendarRepo := new(MockExternalCalendarRepository)
+	mockFetcher := new(MockCalendarFetcher)
+	service := NewBusyImportService(new(MockTimeBlockRepository), mockCalendarRepo, new(MockCentersRepository), new(MockMembershipRepository), mockFetcher, testImportConfig, new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	calendar := &domain.ExternalCalendar{ID: uuid.New(), CenterID: centerID, UserID: staffID, URL: "https://calendar.example.com/private.ics"}
+
+	// Expectations
+	mockCalendarRepo.On("Get", mock.Anything, centerID, staffID).Return(calendar, nil)
+	mockFetcher.On("Fetch", mock.Anything, calendar.URL).Return([]byte(nil), exceptions.ErrExternalCalendarUnreachable)
+
+	// Execute
+	_, err := service.SyncCalendar(context.Background(), centerID, staffID)
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrExternalCalendarUnreachable)
+	mockCalendarRepo.AssertNotCalled(t, "MarkSynced", mock.Anything, mock.Anything, mock.Anything)
+}
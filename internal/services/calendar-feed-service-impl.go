@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/scheduling"
+	"bifur.app/core/internal/utils/ical"
+	"bifur.app/core/internal/utils/random"
+	"bifur.app/core/internal/utils/token"
+	"github.com/google/uuid"
+)
+
+const (
+	// feedTokenPrefix tells feed tokens apart from access tokens, which are
+	// never accepted by the feed, and the other way around
+	feedTokenPrefix = "cal_"
+	feedTokenLength = 48
+	feedProductID   = "-//Scheduly//Calendar Feed//EN"
+	// feedUIDDomain makes the UIDs of the events globally unique, they must
+	// never change for a calendar app to update its copy of an event
+	feedUIDDomain = "scheduly"
+	// feedRefresh is the polling interval suggested to calendar apps
+	feedRefresh = "PT1H"
+)
+
+type CalendarFeedServiceImplementation struct {
+	feedRepo        ports.CalendarFeedRepository
+	appointmentRepo ports.AppointmentRepository
+	seriesRepo      ports.AppointmentSeriesRepository
+	centersRepo     ports.CentersRepository
+	membershipRepo  ports.MembershipRepository
+	config          domain.CalendarConfig
+	hashSecret      []byte
+	// legacyHashSecret hashed the feeds created before they had a key of
+	// their own, they are still served
+	legacyHashSecret []byte
+	logger           ports.Logger
+}
+
+func NewCalendarFeedService(feedRepo ports.CalendarFeedRepository, appointmentRepo ports.AppointmentRepository, seriesRepo ports.AppointmentSeriesRepository, centersRepo ports.CentersRepository, membershipRepo ports.MembershipRepository, config domain.CalendarConfig, hashSecret, legacyHashSecret []byte, logger ports.Logger) ports.CalendarFeedService {
+	return &CalendarFeedServiceImplementation{
+		feedRepo:         feedRepo,
+		appointmentRepo:  appointmentRepo,
+		seriesRepo:       seriesRepo,
+		centersRepo:      centersRepo,
+		membershipRepo:   membershipRepo,
+		config:           config,
+		hashSecret:       hashSecret,
+		legacyHashSecret: legacyHashSecret,
+		logger:           logger,
+	}
+}
+
+func (s *CalendarFeedServiceImplementation) Get(ctx context.Context, actor *domain.CenterMembership) (*domain.CalendarFeed, error) {
+	return s.feedRepo.GetActive(ctx, actor.CenterID, actor.UserID)
+}
+
+func (s *CalendarFeedServiceImplementation) Rotate(ctx context.Context, actor *domain.CenterMembership) (*domain.CalendarFeedLink, error) {
+	feedToken := feedTokenPrefix + random.GenerateRandomString(feedTokenLength)
+	feed := &domain.CalendarFeed{
+		CenterID:  actor.CenterID,
+		UserID:    actor.UserID,
+		TokenHash: token.Hash(feedToken, s.hashSecret),
+	}
+	err := s.feedRepo.Rotate(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CalendarFeedLink{
+		URL:       fmt.Sprintf("%s/%s.ics", strings.TrimSuffix(s.config.FeedURL, "/"), feedToken),
+		CreatedAt: feed.CreatedAt,
+	}, nil
+}
+
+func (s *CalendarFeedServiceImplementation) Revoke(ctx context.Context, actor *domain.CenterMembership) error {
+	return s.feedRepo.Revoke(ctx, actor.CenterID, actor.UserID)
+}
+
+func (s *CalendarFeedServiceImplementation) Render(ctx context.Context, feedToken string) ([]byte, error) {
+	if !strings.HasPrefix(feedToken, feedTokenPrefix) {
+		return nil, exceptions.ErrCalendarFeedNotFound
+	}
+	feed, err := s.feedRepo.GetActiveByTokenHash(ctx, token.Hash(feedToken, s.hashSecret))
+	if errors.Is(err, exceptions.ErrCalendarFeedNotFound) && s.legacyHashSecret != nil {
+		feed, err = s.feedRepo.GetActiveByTokenHash(ctx, token.Hash(feedToken, s.legacyHashSecret))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The feed dies with the membership
+	_, err = s.membershipRepo.GetByCenterAndUser(ctx, feed.CenterID, feed.UserID)
+	if err != nil {
+		if errors.Is(err, exceptions.ErrMembershipNotFound) {
+			return nil, exceptions.ErrCalendarFeedNotFound
+		}
+		return nil, err
+	}
+
+	center, err := s.centersRepo.GetByID(ctx, feed.CenterID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := center.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from, to := now.Add(-s.config.FeedPast), now.Add(s.config.FeedHorizon)
+	appointments, err := s.appointmentRepo.List(ctx, feed.CenterID, &domain.AppointmentFilter{StaffID: &feed.UserID, From: &from, To: &to})
+	if err != nil {
+		return nil, err
+	}
+	activeSeries, err := s.seriesRepo.ListActive(ctx, feed.CenterID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var seriesList []*domain.AppointmentSeries
+	var seriesIDs []uuid.UUID
+	for _, series := range activeSeries {
+		if series.StaffID == feed.UserID {
+			seriesList = append(seriesList, series)
+			seriesIDs = append(seriesIDs, series.ID)
+		}
+	}
+	// Edited occurrences of the series, including the ones given to another
+	// staff member
+	overrides, err := s.seriesRepo.ListOccurrences(ctx, feed.CenterID, seriesIDs, domain.DateOf(from.In(loc)).AddDays(-1), domain.DateOf(to.In(loc)))
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.centersRepo.ListServices(ctx, feed.CenterID)
+	if err != nil {
+		return nil, err
+	}
+	serviceNames := make(map[uuid.UUID]string, len(services))
+	for _, service := range services {
+		serviceNames[service.ID] = service.Name
+	}
+
+	calendar := ical.NewComponent("VCALENDAR")
+	calendar.Add("VERSION", "2.0")
+	calendar.Add("PRODID", feedProductID)
+	calendar.Add("CALSCALE", "GREGORIAN")
+	calendar.Add("METHOD", "PUBLISH")
+	calendar.AddText("NAME", center.Name)
+	calendar.AddText("X-WR-CALNAME", center.Name)
+	calendar.Add("X-WR-TIMEZONE", loc.String())
+	calendar.Add("REFRESH-INTERVAL", feedRefresh, ical.Param{Name: "VALUE", Value: "DURATION"})
+	calendar.Add("X-PUBLISHED-TTL", feedRefresh)
+
+	timezoneFrom := from
+	for _, series := range seriesList {
+		if series.StartsAt.Before(timezoneFrom) {
+			timezoneFrom = series.StartsAt
+		}
+	}
+	calendar.AddComponent(ical.Timezone(loc, timezoneFrom, to))
+
+	inFeed := make(map[uuid.UUID]*domain.AppointmentSeries, len(seriesList))
+	for _, series := range seriesList {
+		event, err := seriesEvent(series, serviceNames[series.ServiceID], loc)
+		if err != nil {
+			return nil, err
+		}
+		calendar.AddComponent(event)
+		inFeed[series.ID] = series
+	}
+	for _, appointment := range overrides {
+		series := inFeed[*appointment.SeriesID]
+		// The occurrence given to another staff member leaves this calendar
+		status := eventStatus(appointment.Status)
+		if appointment.StaffID != feed.UserID {
+			status = "CANCELLED"
+		}
+		event := appointmentEvent(seriesUID(series.ID), appointment, serviceNames[appointment.ServiceID], status, loc)
+		ruleStart := series.StartsAt.In(loc)
+		date := *appointment.OccurrenceDate
+		recurrenceID := time.Date(date.Year, date.Month, date.Day, ruleStart.Hour(), ruleStart.Minute(), ruleStart.Second(), 0, loc)
+		event.AddLocal("RECURRENCE-ID", recurrenceID)
+		calendar.AddComponent(event)
+	}
+	for _, appointment := range appointments {
+		if appointment.SeriesID != nil && inFeed[*appointment.SeriesID] != nil {
+			continue
+		}
+		calendar.AddComponent(appointmentEvent(fmt.Sprintf("appointment-%s@%s", appointment.ID, feedUIDDomain), appointment, serviceNames[appointment.ServiceID], eventStatus(appointment.Status), loc))
+	}
+
+	return calendar.Encode(), nil
+}
+
+func seriesUID(id uuid.UUID) string {
+	return fmt.Sprintf("series-%s@%s", id, feedUIDDomain)
+}
+
+// seriesEvent is the recurring event of the series, in the timezone of the
+// center so that its occurrences keep their wall clock time
+func seriesEvent(series *domain.AppointmentSeries, serviceName string, loc *time.Location) (*ical.Component, error) {
+	rule, err := scheduling.ParseRRule(series.RRule)
+	if err != nil {
+		return nil, exceptions.ErrAppointmentSeriesInvalidRule
+	}
+	// UNTIL must be a date-time when DTSTART is one
+	if rule.UntilDate != nil {
+		until := rule.UntilDate.AddDays(1).In(loc).Add(-time.Second).UTC()
+		rule.Until, rule.UntilDate = &until, nil
+	}
+
+	start := series.StartsAt.In(loc)
+	event := ical.NewComponent("VEVENT")
+	event.Add("UID", seriesUID(series.ID))
+	event.AddUTC("DTSTAMP", series.UpdatedAt)
+	event.AddUTC("LAST-MODIFIED", series.UpdatedAt)
+	event.AddLocal("DTSTART", start)
+	event.AddLocal("DTEND", start.Add(series.Duration()))
+	event.Add("RRULE", rule.String())
+	if len(series.ExDates) > 0 {
+		exDates := make([]string, len(series.ExDates))
+		sorted := append([]domain.Date{}, series.ExDates...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+		for i, date := range sorted {
+			exDates[i] = time.Date(date.Year, date.Month, date.Day, start.Hour(), start.Minute(), start.Second(), 0, loc).Format(ical.LocalLayout)
+		}
+		event.Add("EXDATE", strings.Join(exDates, ","), ical.Param{Name: "TZID", Value: loc.String()})
+	}
+	event.AddText("SUMMARY", eventSummary(serviceName, series.CustomerName))
+	if description := eventDescription(series.CustomerEmail, series.CustomerPhone, series.Notes); description != "" {
+		event.AddText("DESCRIPTION", description)
+	}
+	event.Add("STATUS", eventStatus(series.Status))
+	return event, nil
+}
+
+func appointmentEvent(uid string, appointment *domain.Appointment, serviceName string, status string, loc *time.Location) *ical.Component {
+	event := ical.NewComponent("VEVENT")
+	event.Add("UID", uid)
+	event.AddUTC("DTSTAMP", appointment.UpdatedAt)
+	event.AddUTC("LAST-MODIFIED", appointment.UpdatedAt)
+	event.AddLocal("DTSTART", appointment.StartsAt.In(loc))
+	event.AddLocal("DTEND", appointment.EndsAt.In(loc))
+	event.AddText("SUMMARY", eventSummary(serviceName, appointment.CustomerName))
+	if description := eventDescription(appointment.CustomerEmail, appointment.CustomerPhone, appointment.Notes); description != "" {
+		event.AddText("DESCRIPTION", description)
+	}
+	event.Add("STATUS", status)
+	return event
+}
+
+func eventSummary(serviceName string, customerName string) string {
+	if serviceName == "" {
+		return customerName
+	}
+	return serviceName + " - " + customerName
+}
+
+func eventDescription(email string, phone string, notes string) string {
+	var lines []string
+	for _, line := range []string{email, phone, notes} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// eventStatus maps the status of an appointment to the STATUS of its event,
+// the appointments that won't happen are CANCELLED
+func eventStatus(status domain.AppointmentStatus) string {
+	switch status {
+	case domain.AppointmentStatusRequested:
+		return "TENTATIVE"
+	case domain.AppointmentStatusCancelled, domain.AppointmentStatusNoShow:
+		return "CANCELLED"
+	default:
+		return "CONFIRMED"
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"bifur.app/core/internal/utils/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCalendarFeedRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarFeedRepository) Rotate(ctx context.Context, feed *domain.CalendarFeed) error {
+	args := m.Called(ctx, feed)
+	return args.Error(0)
+}
+
+func (m *MockCalendarFeedRepository) Revoke(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, centerID, userID)
+	return args.Error(0)
+}
+
+func (m *MockCalendarFeedRepository) GetActive(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	args := m.Called(ctx, centerID, userID)
+	return args.Get(0).(*domain.CalendarFeed), args.Error(1)
+}
+
+func (m *MockCalendarFeedRepository) GetActiveByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(*domain.CalendarFeed), args.Error(1)
+}
+
+var testCalendarConfig = domain.CalendarConfig{
+	FeedURL:     "https://api.example.com/api/v1/calendar-feeds/",
+	FeedPast:    30 * 24 * time.Hour,
+	FeedHorizon: 365 * 24 * time.Hour,
+}
+
+func TestCalendarFeedService_Rotate(t *testing.T) {
+	// Setup
+	mockFeedRepo := new(MockCalendarFeedRepository)
+	secret := []byte("feed_secret")
+	service := NewCalendarFeedService(mockFeedRepo, new(MockAppointmentRepository), new(MockAppointmentSeriesRepository), new(MockCentersRepository), new(MockMembershipRepository), testCalendarConfig, secret, nil, new(mocks.LoggerMock))
+	actor := &domain.CenterMembership{CenterID: uuid.New(), UserID: uuid.New(), Role: domain.CenterRoleStaff}
+
+	// Expectations
+	mockFeedRepo.On("Rotate", mock.Anything, mock.AnythingOfType("*domain.CalendarFeed")).Return(nil)
+
+	// Execute
+	link, err := service.Rotate(context.Background(), actor)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(link.URL, "https://api.example.com/api/v1/calendar-feeds/cal_"))
+	assert.True(t, strings.HasSuffix(link.URL, ".ics"))
+	feedToken := strings.TrimSuffix(strings.TrimPrefix(link.URL, "https://api.example.com/api/v1/calendar-feeds/"), ".ics")
+	feed := mockFeedRepo.Calls[0].Arguments.Get(1).(*domain.CalendarFeed)
+	assert.Equal(t, token.Hash(feedToken, secret), feed.TokenHash)
+	assert.Equal(t, actor.UserID, feed.UserID)
+}
+
+func TestCalendarFeedService_RenderRejectsOtherTokens(t *testing.T) {
+	// Setup
+	mockFeedRepo := new(MockCalendarFeedRepository)
+	service := NewCalendarFeedService(mockFeedRepo, new(MockAppointmentRepository), new(MockAppointmentSeriesRepository), new(MockCentersRepository), new(MockMembershipRepository), testCalendarConfig, []byte("feed_secret"), nil, new(mocks.LoggerMock))
+
+	// Expectations
+	mockFeedRepo.On("GetActiveByTokenHash", mock.Anything, mock.Anything).Return((*domain.CalendarFeed)(nil), exceptions.ErrCalendarFeedNotFound)
+
+	// Execute
+	_, accessTokenErr := service.Render(context.Background(), "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.signature")
+	_, revokedErr := service.Render(context.Background(), "cal_revoked")
+
+	// Assert
+	assert.ErrorIs(t, accessTokenErr, exceptions.ErrCalendarFeedNotFound)
+	assert.ErrorIs(t, revokedErr, exceptions.ErrCalendarFeedNotFound)
+	mockFeedRepo.AssertNumberOfCalls(t, "GetActiveByTokenHash", 1)
+}
+
+func TestCalendarFeedService_RenderLegacyFeed(t *testing.T) {
+	// Setup
+	mockFeedRepo := new(MockCalendarFeedRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	secret, legacySecret := []byte("feed_secret"), []byte("refresh_secret")
+	service := NewCalendarFeedService(mockFeedRepo, new(MockAppointmentRepository), new(MockAppointmentSeriesRepository), new(MockCentersRepository), mockMembershipRepo, testCalendarConfig, secret, legacySecret, new(mocks.LoggerMock))
+	feed := &domain.CalendarFeed{ID: uuid.New(), CenterID: uuid.New(), UserID: uuid.New()}
+
+	// Expectations
+	mockFeedRepo.On("GetActiveByTokenHash", mock.Anything, token.Hash("cal_secret", secret)).Return((*domain.CalendarFeed)(nil), exceptions.ErrCalendarFeedNotFound)
+	mockFeedRepo.On("GetActiveByTokenHash", mock.Anything, token.Hash("cal_secret", legacySecret)).Return(feed, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, feed.CenterID, feed.UserID).Return((*domain.CenterMembership)(nil), exceptions.ErrMembershipNotFound)
+
+	// Execute
+	_, err := service.Render(context.Background(), "cal_secret")
+
+	// Assert
+	// The feed subscribed to before feeds had their own key is still found
+	assert.ErrorIs(t, err, exceptions.ErrCalendarFeedNotFound)
+	mockFeedRepo.AssertExpectations(t)
+	mockMembershipRepo.AssertExpectations(t)
+}
+
+func TestCalendarFeedService_Render(t *testing.T) {
+	// Setup
+	mockFeedRepo := new(MockCalendarFeedRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockSeriesRepo := new(MockAppointmentSeriesRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	secret := []byte("feed_secret")
+	service := NewCalendarFeedService(mockFeedRepo, mockAppointmentRepo, mockSeriesRepo, mockCentersRepo, mockMembershipRepo, testCalendarConfig, secret, nil, new(mocks.LoggerMock))
+	centerID, staffID, serviceID, seriesID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	now := time.Now().In(madrid)
+	day := func(days, hour int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+days, hour, 0, 0, 0, madrid)
+	}
+	feed := &domain.CalendarFeed{ID: uuid.New(), CenterID: centerID, UserID: staffID}
+	cancelled := &domain.Appointment{ID: uuid.New(), StaffID: staffID, ServiceID: serviceID, StartsAt: day(2, 9), EndsAt: day(2, 10), Status: domain.AppointmentStatusCancelled, CustomerName: "Jane Doe", Notes: "Knee; left, again"}
+	series := &domain.AppointmentSeries{ID: seriesID, CenterID: centerID, StaffID: staffID, ServiceID: serviceID, RRule: "FREQ=WEEKLY;UNTIL=20990101", StartsAt: day(1, 17), DurationMinutes: 60, Timezone: "Europe/Madrid", ExDates: []domain.Date{domain.DateOf(day(8, 0))}, Status: domain.AppointmentStatusConfirmed, CustomerName: "John Roe"}
+	reassignedDate := domain.DateOf(day(15, 0))
+	reassigned := &domain.Appointment{ID: uuid.New(), StaffID: uuid.New(), ServiceID: serviceID, SeriesID: &seriesID, OccurrenceDate: &reassignedDate, StartsAt: day(15, 18), EndsAt: day(15, 19), Status: domain.AppointmentStatusConfirmed, CustomerName: "John Roe"}
+
+	// Expectations
+	mockFeedRepo.On("GetActiveByTokenHash", mock.Anything, token.Hash("cal_secret", secret)).Return(feed, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Name: "Physio, Madrid", Timezone: "Europe/Madrid"}, nil)
+	mockCentersRepo.On("ListServices", mock.Anything, centerID).Return([]*domain.CenterService{{ID: serviceID, Name: "Physiotherapy"}}, nil)
+	mockAppointmentRepo.On("List", mock.Anything, centerID, mock.AnythingOfType("*domain.AppointmentFilter")).Return([]*domain.Appointment{cancelled}, nil)
+	mockSeriesRepo.On("ListActive", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.AppointmentSeries{series}, nil)
+	mockSeriesRepo.On("ListOccurrences", mock.Anything, centerID, []uuid.UUID{seriesID}, mock.Anything, mock.Anything).Return([]*domain.Appointment{reassigned}, nil)
+
+	// Execute
+	body, err := service.Render(context.Background(), "cal_secret")
+
+	// Assert
+	assert.NoError(t, err)
+	calendar := string(body)
+	for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	local := func(t time.Time) string { return t.Format("20060102T150405") }
+	expected := []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Physio\\, Madrid\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Madrid\r\n",
+		// The appointment cancelled
+		"UID:appointment-" + cancelled.ID.String() + "@scheduly\r\n",
+		"DTSTART;TZID=Europe/Madrid:" + local(day(2, 9)) + "\r\n",
+		"DESCRIPTION:Knee\\; left\\, again\r\n",
+		"STATUS:CANCELLED\r\n",
+		// The series, with its date-only UNTIL as a date-time
+		"UID:series-" + seriesID.String() + "@scheduly\r\n",
+		"RRULE:FREQ=WEEKLY;UNTIL=20990101T225959Z\r\n",
+		"EXDATE;TZID=Europe/Madrid:" + local(day(8, 17)) + "\r\n",
+		"SUMMARY:Physiotherapy - John Roe\r\n",
+		// The occurrence given to another staff member
+		"RECURRENCE-ID;TZID=Europe/Madrid:" + local(day(15, 17)) + "\r\n",
+		"END:VCALENDAR\r\n",
+	}
+	for _, fragment := range expected {
+		assert.Contains(t, calendar, fragment)
+	}
+	assert.Equal(t, 2, strings.Count(calendar, "STATUS:CANCELLED"))
+	assert.Equal(t, 2, strings.Count(calendar, "UID:series-"))
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultServiceName     = "General appointment"
+	defaultServiceDuration = 30
+)
+
+type CentersServiceImplementation struct {
+	centersRepo ports.CentersRepository
+	logger      ports.Logger
+}
+
+func NewCentersService(centersRepo ports.CentersRepository, logger ports.Logger) ports.CentersService {
+	return &CentersServiceImplementation{
+		centersRepo: centersRepo,
+		logger:      logger,
+	}
+}
+
+// newCenter builds a center from its profile, filling the defaults of the
+// optional settings
+func newCenter(ownerID uuid.UUID, name string, profile *domain.CenterProfileInput) *domain.Center {
+	center := &domain.Center{
+		Name:     name,
+		OwnerID:  ownerID,
+		Address:  profile.Address,
+		Phone:    profile.Phone,
+		Email:    strings.ToLower(profile.Email),
+		Timezone: profile.Timezone,
+		Locale:   profile.Locale,
+		Currency: profile.Currency,
+	}
+	if center.Timezone == "" {
+		center.Timezone = domain.DefaultCenterTimezone
+	}
+	if center.Locale == "" {
+		center.Locale = domain.DefaultCenterLocale
+	}
+	if center.Currency == "" {
+		center.Currency = domain.DefaultCenterCurrency
+	}
+	return center
+}
+
+// defaultOpeningHours opens the center from Monday to Friday, 09:00 to 18:00
+func defaultOpeningHours() []domain.OpeningHoursInput {
+	hours := make([]domain.OpeningHoursInput, 0, 5)
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		hours = append(hours, domain.OpeningHoursInput{Weekday: weekday, OpensAt: 9 * 60, ClosesAt: 18 * 60})
+	}
+	return hours
+}
+
+// buildOpeningHours validates the ranges of every weekday: each one must close
+// after it opens and ranges of the same day must not overlap
+func buildOpeningHours(inputs []domain.OpeningHoursInput) ([]*domain.OpeningHours, error) {
+	hours := make([]*domain.OpeningHours, len(inputs))
+	for i, input := range inputs {
+		if input.Weekday < time.Sunday || input.Weekday > time.Saturday || input.ClosesAt <= input.OpensAt || input.ClosesAt > domain.EndOfDay {
+			return nil, exceptions.ErrCenterInvalidOpeningHours
+		}
+		hours[i] = &domain.OpeningHours{Weekday: input.Weekday, OpensAt: input.OpensAt, ClosesAt: input.ClosesAt}
+	}
+
+	sorted := append([]*domain.OpeningHours(nil), hours...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Weekday != sorted[j].Weekday {
+			return sorted[i].Weekday < sorted[j].Weekday
+		}
+		return sorted[i].OpensAt < sorted[j].OpensAt
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Weekday == sorted[i-1].Weekday && sorted[i].OpensAt < sorted[i-1].ClosesAt {
+			return nil, exceptions.ErrCenterInvalidOpeningHours
+		}
+	}
+	return hours, nil
+}
+
+func (s *CentersServiceImplementation) List(ctx context.Context, userID uuid.UUID) ([]*domain.Center, error) {
+	return s.centersRepo.GetAll(ctx, userID)
+}
+
+func (s *CentersServiceImplementation) Get(ctx context.Context, centerID uuid.UUID) (*domain.Center, error) {
+	return s.centersRepo.GetByID(ctx, centerID)
+}
+
+func (s *CentersServiceImplementation) Create(ctx context.Context, ownerID uuid.UUID, input *domain.CreateCenterInput) (*domain.Center, error) {
+	center := newCenter(ownerID, input.Name, &input.CenterProfileInput)
+
+	err := s.centersRepo.Create(ctx, center)
+	if err != nil {
+		return nil, err
+	}
+	return center, nil
+}
+
+func (s *CentersServiceImplementation) Setup(ctx context.Context, ownerID uuid.UUID, input *domain.CenterSetupInput) (*domain.CenterSetup, error) {
+	hoursInput := input.OpeningHours
+	if len(hoursInput) == 0 {
+		hoursInput = defaultOpeningHours()
+	}
+	openingHours, err := buildOpeningHours(hoursInput)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceInput := input.Service
+	if serviceInput == nil {
+		serviceInput = &domain.CenterServiceInput{Name: defaultServiceName, DurationMinutes: defaultServiceDuration}
+	}
+
+	setup := &domain.CenterSetup{
+		Center:       newCenter(ownerID, input.Name, &input.CenterProfileInput),
+		OpeningHours: openingHours,
+		Services: []*domain.CenterService{{
+			Name:                serviceInput.Name,
+			DurationMinutes:     serviceInput.DurationMinutes,
+			BufferBeforeMinutes: serviceInput.BufferBeforeMinutes,
+			BufferAfterMinutes:  serviceInput.BufferAfterMinutes,
+			IsActive:            true,
+		}},
+	}
+
+	err = s.centersRepo.CreateWithSetup(ctx, setup)
+	if err != nil {
+		s.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"owner_id": ownerID.String(),
+		})
+		return nil, err
+	}
+	return setup, nil
+}
+
+func (s *CentersServiceImplementation) Update(ctx context.Context, centerID uuid.UUID, input *domain.UpdateCenterInput) (*domain.Center, error) {
+	center, err := s.centersRepo.GetByID(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		center.Name = *input.Name
+	}
+	if input.Slug != nil {
+		if *input.Slug != "" && !domain.IsValidSlug(*input.Slug) {
+			return nil, exceptions.ErrCenterInvalidSlug
+		}
+		center.Slug = *input.Slug
+	}
+	if input.Address != nil {
+		center.Address = input.Address
+	}
+	if input.Phone != nil {
+		center.Phone = *input.Phone
+	}
+	if input.Email != nil {
+		center.Email = strings.ToLower(*input.Email)
+	}
+	if input.Timezone != nil {
+		// The binding skips empty values, which would leave the center in UTC
+		if *input.Timezone == "" {
+			return nil, exceptions.ErrCenterInvalidTimezone
+		}
+		center.Timezone = *input.Timezone
+	}
+	if input.Locale != nil {
+		center.Locale = *input.Locale
+	}
+	if input.Currency != nil {
+		center.Currency = *input.Currency
+	}
+	if input.CancellationWindowHours != nil {
+		center.CancellationWindowHours = *input.CancellationWindowHours
+	}
+
+	err = s.centersRepo.Update(ctx, center)
+	if err != nil {
+		return nil, err
+	}
+	return center, nil
+}
+
+func (s *CentersServiceImplementation) Delete(ctx context.Context, centerID uuid.UUID) error {
+	return s.centersRepo.Delete(ctx, centerID)
+}
+
+func (s *CentersServiceImplementation) Restore(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.Center, error) {
+	center, err := s.centersRepo.GetDeletedByID(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+	// Deleted centers are invisible to the center guard, the owner is checked
+	// here instead
+	if center.OwnerID != userID {
+		return nil, exceptions.ErrCenterNotFound
+	}
+
+	err = s.centersRepo.Restore(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.centersRepo.GetByID(ctx, centerID)
+}
+
+func (s *CentersServiceImplementation) ListOpeningHours(ctx context.Context, centerID uuid.UUID) ([]*domain.OpeningHours, error) {
+	return s.centersRepo.ListOpeningHours(ctx, centerID)
+}
+
+func (s *CentersServiceImplementation) ListServices(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterService, error) {
+	return s.centersRepo.ListServices(ctx, centerID)
+}
+
+func (s *CentersServiceImplementation) ListResources(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterResource, error) {
+	return s.centersRepo.ListResources(ctx, centerID)
+}
+
+func (s *CentersServiceImplementation) CreateResource(ctx context.Context, centerID uuid.UUID, input *domain.CenterResourceInput) (*domain.CenterResource, error) {
+	resource := &domain.CenterResource{
+		CenterID: centerID,
+		Name:     input.Name,
+		IsActive: true,
+	}
+	err := s.centersRepo.CreateResource(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (s *CentersServiceImplementation) UpdateResource(ctx context.Context, centerID uuid.UUID, resourceID uuid.UUID, input *domain.UpdateCenterResourceInput) (*domain.CenterResource, error) {
+	resource, err := s.centersRepo.GetResource(ctx, centerID, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		resource.Name = *input.Name
+	}
+	if input.IsActive != nil {
+		resource.IsActive = *input.IsActive
+	}
+
+	err = s.centersRepo.UpdateResource(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCentersRepository struct {
+	mock.Mock
+}
+
+func (m *MockCentersRepository) GetAll(ctx context.Context, userID uuid.UUID) ([]*domain.Center, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*domain.Center), args.Error(1)
+}
+
+func (m *MockCentersRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Center, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.Center), args.Error(1)
+}
+
+func (m *MockCentersRepository) GetBySlug(ctx context.Context, slug string) (*domain.Center, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(*domain.Center), args.Error(1)
+}
+
+func (m *MockCentersRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Center, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.Center), args.Error(1)
+}
+
+func (m *MockCentersRepository) Create(ctx context.Context, center *domain.Center) error {
+	args := m.Called(ctx, center)
+	return args.Error(0)
+}
+
+func (m *MockCentersRepository) CreateWithSetup(ctx context.Context, setup *domain.CenterSetup) error {
+	args := m.Called(ctx, setup)
+	return args.Error(0)
+}
+
+func (m *MockCentersRepository) Update(ctx context.Context, center *domain.Center) error {
+	args := m.Called(ctx, center)
+	return args.Error(0)
+}
+
+func (m *MockCentersRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCentersRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCentersRepository) ListOpeningHours(ctx context.Context, centerID uuid.UUID) ([]*domain.OpeningHours, error) {
+	args := m.Called(ctx, centerID)
+	return args.Get(0).([]*domain.OpeningHours), args.Error(1)
+}
+
+func (m *MockCentersRepository) ListServices(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterService, error) {
+	args := m.Called(ctx, centerID)
+	return args.Get(0).([]*domain.CenterService), args.Error(1)
+}
+
+func (m *MockCentersRepository) GetService(ctx context.Context, centerID uuid.UUID, serviceID uuid.UUID) (*domain.CenterService, error) {
+	args := m.Called(ctx, centerID, serviceID)
+	return args.Get(0).(*domain.CenterService), args.Error(1)
+}
+
+func (m *MockCentersRepository) ListResources(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterResource, error) {
+	args := m.Called(ctx, centerID)
+	return args.Get(0).([]*domain.CenterResource), args.Error(1)
+}
+
+func (m *MockCentersRepository) GetResource(ctx context.Context, centerID uuid.UUID, resourceID uuid.UUID) (*domain.CenterResource, error) {
+	args := m.Called(ctx, centerID, resourceID)
+	return args.Get(0).(*domain.CenterResource), args.Error(1)
+}
+
+func (m *MockCentersRepository) CreateResource(ctx context.Context, resource *domain.CenterResource) error {
+	args := m.Called(ctx, resource)
+	return args.Error(0)
+}
+
+func (m *MockCentersRepository) UpdateResource(ctx context.Context, resource *domain.CenterResource) error {
+	args := m.Called(ctx, resource)
+	return args.Error(0)
+}
+
+func TestCentersService_SetupDefaults(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewCentersService(mockCentersRepo, new(mocks.LoggerMock))
+	ownerID := uuid.New()
+
+	// Expectations
+	mockCentersRepo.On("CreateWithSetup", mock.Anything, mock.AnythingOfType("*domain.CenterSetup")).Return(nil)
+
+	// Execute
+	setup, err := service.Setup(context.Background(), ownerID, &domain.CenterSetupInput{Name: "Clinic"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, ownerID, setup.Center.OwnerID)
+	assert.Equal(t, domain.DefaultCenterTimezone, setup.Center.Timezone)
+	assert.Equal(t, domain.DefaultCenterLocale, setup.Center.Locale)
+	assert.Equal(t, domain.DefaultCenterCurrency, setup.Center.Currency)
+	assert.Len(t, setup.OpeningHours, 5)
+	assert.Equal(t, time.Monday, setup.OpeningHours[0].Weekday)
+	assert.Equal(t, domain.TimeOfDay(9*60), setup.OpeningHours[0].OpensAt)
+	assert.Equal(t, domain.TimeOfDay(18*60), setup.OpeningHours[0].ClosesAt)
+	assert.Len(t, setup.Services, 1)
+	assert.Equal(t, defaultServiceDuration, setup.Services[0].DurationMinutes)
+	assert.True(t, setup.Services[0].IsActive)
+	mockCentersRepo.AssertExpectations(t)
+}
+
+func TestCentersService_SetupInvalidOpeningHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours []domain.OpeningHoursInput
+	}{
+		{"closes before opening", []domain.OpeningHoursInput{{Weekday: time.Monday, OpensAt: 18 * 60, ClosesAt: 9 * 60}}},
+		{"empty range", []domain.OpeningHoursInput{{Weekday: time.Monday, OpensAt: 9 * 60, ClosesAt: 9 * 60}}},
+		{"invalid weekday", []domain.OpeningHoursInput{{Weekday: 7, OpensAt: 9 * 60, ClosesAt: 18 * 60}}},
+		{"overlapping ranges", []domain.OpeningHoursInput{
+			{Weekday: time.Monday, OpensAt: 14 * 60, ClosesAt: 18 * 60},
+			{Weekday: time.Monday, OpensAt: 9 * 60, ClosesAt: 15 * 60},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockCentersRepo := new(MockCentersRepository)
+			service := NewCentersService(mockCentersRepo, new(mocks.LoggerMock))
+
+			// Execute
+			_, err := service.Setup(context.Background(), uuid.New(), &domain.CenterSetupInput{Name: "Clinic", OpeningHours: tt.hours})
+
+			// Assert
+			assert.ErrorIs(t, err, exceptions.ErrCenterInvalidOpeningHours)
+			mockCentersRepo.AssertNotCalled(t, "CreateWithSetup", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCentersService_UpdateProfile(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewCentersService(mockCentersRepo, new(mocks.LoggerMock))
+	centerID := uuid.New()
+	center := &domain.Center{ID: centerID, Name: "Clinic", Phone: "+34600111222", Timezone: "UTC", Locale: "en", Currency: "EUR"}
+	timezone, email := "Europe/Madrid", "Front@Clinic.com"
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(center, nil)
+	mockCentersRepo.On("Update", mock.Anything, center).Return(nil)
+
+	// Execute
+	updated, err := service.Update(context.Background(), centerID, &domain.UpdateCenterInput{Timezone: &timezone, Email: &email})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Clinic", updated.Name)
+	assert.Equal(t, "+34600111222", updated.Phone)
+	assert.Equal(t, "front@clinic.com", updated.Email)
+	assert.Equal(t, "Europe/Madrid", updated.Timezone)
+	mockCentersRepo.AssertExpectations(t)
+}
+
+func TestCentersService_UpdateRejectsEmptyTimezone(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewCentersService(mockCentersRepo, new(mocks.LoggerMock))
+	centerID := uuid.New()
+	center := &domain.Center{ID: centerID, Name: "Clinic", Timezone: "Europe/Madrid"}
+	timezone := ""
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(center, nil)
+
+	// Execute
+	_, err := service.Update(context.Background(), centerID, &domain.UpdateCenterInput{Timezone: &timezone})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrCenterInvalidTimezone)
+	assert.Equal(t, "Europe/Madrid", center.Timezone)
+	mockCentersRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCentersService_RestoreOnlyByOwner(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewCentersService(mockCentersRepo, new(mocks.LoggerMock))
+	centerID := uuid.New()
+	deletedAt := time.Now()
+	center := &domain.Center{ID: centerID, OwnerID: uuid.New(), DeletedAt: &deletedAt}
+
+	// Expectations
+	mockCentersRepo.On("GetDeletedByID", mock.Anything, centerID).Return(center, nil)
+
+	// Execute
+	_, err := service.Restore(context.Background(), centerID, uuid.New())
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrCenterNotFound)
+	mockCentersRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
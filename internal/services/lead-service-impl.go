@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLeadPageSize = 50
+	maxLeadPageSize     = 200
+)
+
+type LeadServiceImplementation struct {
+	leadRepo ports.LeadRepository
+	logger   ports.Logger
+}
+
+func NewLeadService(leadRepo ports.LeadRepository, logger ports.Logger) ports.LeadService {
+	return &LeadServiceImplementation{
+		leadRepo: leadRepo,
+		logger:   logger,
+	}
+}
+
+func (s *LeadServiceImplementation) List(ctx context.Context, centerID uuid.UUID, filter *domain.LeadFilter) ([]*domain.Lead, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLeadPageSize
+	}
+	if filter.Limit > maxLeadPageSize {
+		filter.Limit = maxLeadPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.leadRepo.List(ctx, centerID, filter)
+}
+
+func (s *LeadServiceImplementation) Get(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Lead, error) {
+	lead, err := s.leadRepo.GetByID(ctx, centerID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.withConsents(ctx, lead)
+}
+
+func (s *LeadServiceImplementation) Create(ctx context.Context, actor *domain.CenterMembership, input *domain.CreateLeadInput, userAgent, ipAddress string) (*domain.Lead, error) {
+	lead := &domain.Lead{
+		CenterID: actor.CenterID,
+		Name:     input.Name,
+		Notes:    input.Notes,
+	}
+	err := setLeadContact(lead, input.Email, input.Phone)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkDuplicate(ctx, lead)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, consentInput := range input.Consents {
+		consent, err := newConsent(consentInput, domain.ConsentSourceStaff, &actor.UserID, userAgent, ipAddress)
+		if err != nil {
+			return nil, err
+		}
+		lead.Consents = append(lead.Consents, consent)
+	}
+
+	err = s.leadRepo.Create(ctx, lead)
+	if err != nil {
+		return nil, err
+	}
+	lead.Consents = domain.CurrentConsents(lead.Consents)
+	return lead, nil
+}
+
+func (s *LeadServiceImplementation) Update(ctx context.Context, centerID uuid.UUID, id uuid.UUID, input *domain.UpdateLeadInput) (*domain.Lead, error) {
+	lead, err := s.leadRepo.GetByID(ctx, centerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		lead.Name = *input.Name
+	}
+	if input.Notes != nil {
+		lead.Notes = *input.Notes
+	}
+	email, phone := lead.Email, lead.Phone
+	if input.Email != nil {
+		email = *input.Email
+	}
+	if input.Phone != nil {
+		phone = *input.Phone
+	}
+	err = setLeadContact(lead, email, phone)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkDuplicate(ctx, lead)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.leadRepo.Update(ctx, lead)
+	if err != nil {
+		return nil, err
+	}
+	return s.withConsents(ctx, lead)
+}
+
+func (s *LeadServiceImplementation) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	return s.leadRepo.Delete(ctx, centerID, id)
+}
+
+func (s *LeadServiceImplementation) ListConsents(ctx context.Context, centerID uuid.UUID, leadID uuid.UUID) ([]*domain.LeadConsent, error) {
+	_, err := s.leadRepo.GetByID(ctx, centerID, leadID)
+	if err != nil {
+		return nil, err
+	}
+	return s.leadRepo.ListConsents(ctx, leadID)
+}
+
+func (s *LeadServiceImplementation) RecordConsent(ctx context.Context, actor *domain.CenterMembership, leadID uuid.UUID, input *domain.ConsentInput, userAgent, ipAddress string) (*domain.LeadConsent, error) {
+	_, err := s.leadRepo.GetByID(ctx, actor.CenterID, leadID)
+	if err != nil {
+		return nil, err
+	}
+
+	consent, err := newConsent(input, domain.ConsentSourceStaff, &actor.UserID, userAgent, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	consent.LeadID = leadID
+	err = s.leadRepo.CreateConsent(ctx, consent)
+	if err != nil {
+		return nil, err
+	}
+	return consent, nil
+}
+
+func (s *LeadServiceImplementation) withConsents(ctx context.Context, lead *domain.Lead) (*domain.Lead, error) {
+	consents, err := s.leadRepo.ListConsents(ctx, lead.ID)
+	if err != nil {
+		return nil, err
+	}
+	lead.Consents = domain.CurrentConsents(consents)
+	return lead, nil
+}
+
+// checkDuplicate tells which lead already has the email or the phone, the
+// unique indexes only tell that one does
+func (s *LeadServiceImplementation) checkDuplicate(ctx context.Context, lead *domain.Lead) error {
+	matches, err := s.leadRepo.FindByContact(ctx, lead.CenterID, lead.Email, lead.Phone)
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if match.ID != lead.ID {
+			return &domain.DuplicateLeadError{Err: exceptions.ErrLeadDuplicate, LeadID: match.ID}
+		}
+	}
+	return nil
+}
+
+// setLeadContact validates and normalizes the email and the phone of the
+// lead, one of them at least is required
+func setLeadContact(lead *domain.Lead, email string, phone string) error {
+	email = strings.TrimSpace(email)
+	if email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			return exceptions.ErrLeadInvalidEmail
+		}
+	}
+	lead.Email = domain.NormalizeEmail(email)
+
+	lead.Phone = ""
+	if strings.TrimSpace(phone) != "" {
+		lead.Phone = domain.NormalizePhone(phone)
+		if lead.Phone == "" {
+			return exceptions.ErrLeadInvalidPhone
+		}
+	}
+
+	if lead.Email == "" && lead.Phone == "" {
+		return exceptions.ErrLeadMissingContact
+	}
+	return nil
+}
+
+// newConsent records a consent from the request that gave it, recordedBy is
+// the member of the center who recorded it, if any
+func newConsent(input *domain.ConsentInput, source domain.ConsentSource, recordedBy *uuid.UUID, userAgent, ipAddress string) (*domain.LeadConsent, error) {
+	if !input.Purpose.IsValid() {
+		return nil, exceptions.ErrConsentInvalidPurpose
+	}
+	return &domain.LeadConsent{
+		Purpose:       input.Purpose,
+		Granted:       input.Granted,
+		PolicyVersion: input.PolicyVersion,
+		Statement:     input.Statement,
+		Source:        source,
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+		RecordedBy:    recordedBy,
+	}, nil
+}
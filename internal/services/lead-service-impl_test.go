@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLeadRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeadRepository) Create(ctx context.Context, lead *domain.Lead) error {
+	args := m.Called(ctx, lead)
+	return args.Error(0)
+}
+
+func (m *MockLeadRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.Lead, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.Lead), args.Error(1)
+}
+
+func (m *MockLeadRepository) FindByContact(ctx context.Context, centerID uuid.UUID, email string, phone string) ([]*domain.Lead, error) {
+	args := m.Called(ctx, centerID, email, phone)
+	return args.Get(0).([]*domain.Lead), args.Error(1)
+}
+
+func (m *MockLeadRepository) List(ctx context.Context, centerID uuid.UUID, filter *domain.LeadFilter) ([]*domain.Lead, error) {
+	args := m.Called(ctx, centerID, filter)
+	return args.Get(0).([]*domain.Lead), args.Error(1)
+}
+
+func (m *MockLeadRepository) Update(ctx context.Context, lead *domain.Lead) error {
+	args := m.Called(ctx, lead)
+	return args.Error(0)
+}
+
+func (m *MockLeadRepository) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	args := m.Called(ctx, centerID, id)
+	return args.Error(0)
+}
+
+func (m *MockLeadRepository) CreateConsent(ctx context.Context, consent *domain.LeadConsent) error {
+	args := m.Called(ctx, consent)
+	return args.Error(0)
+}
+
+func (m *MockLeadRepository) ListConsents(ctx context.Context, leadID uuid.UUID) ([]*domain.LeadConsent, error) {
+	args := m.Called(ctx, leadID)
+	return args.Get(0).([]*domain.LeadConsent), args.Error(1)
+}
+
+func TestLeadService_Create(t *testing.T) {
+	// Setup
+	leadRepo := new(MockLeadRepository)
+	service := NewLeadService(leadRepo, new(mocks.LoggerMock))
+	actor := &domain.CenterMembership{CenterID: uuid.New(), UserID: uuid.New(), Role: domain.CenterRoleStaff}
+	input := &domain.CreateLeadInput{
+		Name:  "Ana García",
+		Email: "Ana.Garcia@Example.com",
+		Phone: "0034 612-345-678",
+		Consents: []*domain.ConsentInput{
+			{Purpose: domain.ConsentPurposeMarketing, Granted: false, PolicyVersion: "2024-01"},
+			{Purpose: domain.ConsentPurposeDataProcessing, Granted: true, PolicyVersion: "2024-01", Statement: "I agree to the privacy policy"},
+		},
+	}
+
+	// Expectations
+	leadRepo.On("FindByContact", mock.Anything, actor.CenterID, "ana.garcia@example.com", "+34612345678").Return([]*domain.Lead{}, nil)
+	leadRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Lead")).Return(nil)
+
+	// Execute
+	lead, err := service.Create(context.Background(), actor, input, "Mozilla/5.0", "203.0.113.7")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, actor.CenterID, lead.CenterID)
+	assert.Equal(t, "ana.garcia@example.com", lead.Email)
+	assert.Equal(t, "+34612345678", lead.Phone)
+	if assert.Len(t, lead.Consents, 2) {
+		assert.Equal(t, domain.ConsentPurposeDataProcessing, lead.Consents[0].Purpose)
+		for _, consent := range lead.Consents {
+			assert.Equal(t, domain.ConsentSourceStaff, consent.Source)
+			assert.Equal(t, "203.0.113.7", consent.IPAddress)
+			assert.Equal(t, "Mozilla/5.0", consent.UserAgent)
+			assert.Equal(t, actor.UserID, *consent.RecordedBy)
+		}
+	}
+	assert.True(t, lead.HasConsent(domain.ConsentPurposeDataProcessing))
+	assert.False(t, lead.HasConsent(domain.ConsentPurposeMarketing))
+	leadRepo.AssertExpectations(t)
+}
+
+func TestLeadService_CreateDuplicate(t *testing.T) {
+	// Setup
+	leadRepo := new(MockLeadRepository)
+	service := NewLeadService(leadRepo, new(mocks.LoggerMock))
+	actor := &domain.CenterMembership{CenterID: uuid.New(), UserID: uuid.New()}
+	existing := &domain.Lead{ID: uuid.New(), CenterID: actor.CenterID, Phone: "612345678"}
+
+	// Expectations
+	leadRepo.On("FindByContact", mock.Anything, actor.CenterID, "", "612345678").Return([]*domain.Lead{existing}, nil)
+
+	// Execute
+	_, err := service.Create(context.Background(), actor, &domain.CreateLeadInput{Name: "Ana", Phone: "612 34 56 78"}, "", "")
+
+	// Assert
+	var duplicateErr *domain.DuplicateLeadError
+	if assert.ErrorAs(t, err, &duplicateErr) {
+		assert.Equal(t, existing.ID, duplicateErr.LeadID)
+	}
+	assert.ErrorIs(t, err, exceptions.ErrLeadDuplicate)
+	leadRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLeadService_CreateInvalidInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *domain.CreateLeadInput
+		expected error
+	}{
+		{"no contact", &domain.CreateLeadInput{Name: "Ana"}, exceptions.ErrLeadMissingContact},
+		{"invalid email", &domain.CreateLeadInput{Name: "Ana", Email: "Ana <ana@example.com>"}, exceptions.ErrLeadInvalidEmail},
+		{"letters in phone", &domain.CreateLeadInput{Name: "Ana", Phone: "612-ABC-678"}, exceptions.ErrLeadInvalidPhone},
+		{"short phone", &domain.CreateLeadInput{Name: "Ana", Phone: "1234"}, exceptions.ErrLeadInvalidPhone},
+		{"invalid purpose", &domain.CreateLeadInput{Name: "Ana", Email: "ana@example.com", Consents: []*domain.ConsentInput{{Purpose: "profiling", PolicyVersion: "1"}}}, exceptions.ErrConsentInvalidPurpose},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			leadRepo := new(MockLeadRepository)
+			service := NewLeadService(leadRepo, new(mocks.LoggerMock))
+			actor := &domain.CenterMembership{CenterID: uuid.New(), UserID: uuid.New()}
+			leadRepo.On("FindByContact", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Lead{}, nil).Maybe()
+
+			// Execute
+			_, err := service.Create(context.Background(), actor, tt.input, "", "")
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			leadRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestLeadService_UpdateKeepsOwnContact(t *testing.T) {
+	// Setup
+	leadRepo := new(MockLeadRepository)
+	service := NewLeadService(leadRepo, new(mocks.LoggerMock))
+	centerID := uuid.New()
+	lead := &domain.Lead{ID: uuid.New(), CenterID: centerID, Name: "Ana", Email: "ana@example.com"}
+	name, phone := "Ana García", "+34 612 345 678"
+
+	// Expectations
+	leadRepo.On("GetByID", mock.Anything, centerID, lead.ID).Return(lead, nil)
+	leadRepo.On("FindByContact", mock.Anything, centerID, "ana@example.com", "+34612345678").Return([]*domain.Lead{{ID: lead.ID}}, nil)
+	leadRepo.On("Update", mock.Anything, lead).Return(nil)
+	leadRepo.On("ListConsents", mock.Anything, lead.ID).Return([]*domain.LeadConsent{}, nil)
+
+	// Execute
+	updated, err := service.Update(context.Background(), centerID, lead.ID, &domain.UpdateLeadInput{Name: &name, Phone: &phone})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana García", updated.Name)
+	assert.Equal(t, "ana@example.com", updated.Email)
+	assert.Equal(t, "+34612345678", updated.Phone)
+	leadRepo.AssertExpectations(t)
+}
+
+func TestLeadService_GetReturnsCurrentConsents(t *testing.T) {
+	// Setup
+	leadRepo := new(MockLeadRepository)
+	service := NewLeadService(leadRepo, new(mocks.LoggerMock))
+	centerID := uuid.New()
+	lead := &domain.Lead{ID: uuid.New(), CenterID: centerID, Name: "Ana", Email: "ana@example.com"}
+	now := time.Now()
+	withdrawal := &domain.LeadConsent{Purpose: domain.ConsentPurposeMarketing, Granted: false, CreatedAt: now}
+	processing := &domain.LeadConsent{Purpose: domain.ConsentPurposeDataProcessing, Granted: true, CreatedAt: now.Add(-48 * time.Hour)}
+
+	// Expectations
+	leadRepo.On("GetByID", mock.Anything, centerID, lead.ID).Return(lead, nil)
+	leadRepo.On("ListConsents", mock.Anything, lead.ID).Return([]*domain.LeadConsent{
+		withdrawal,
+		{Purpose: domain.ConsentPurposeMarketing, Granted: true, CreatedAt: now.Add(-24 * time.Hour)},
+		processing,
+	}, nil)
+
+	// Execute
+	result, err := service.Get(context.Background(), centerID, lead.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.LeadConsent{processing, withdrawal}, result.Consents)
+	assert.False(t, result.HasConsent(domain.ConsentPurposeMarketing))
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+)
+
+type MembershipServiceImplementation struct {
+	membershipRepo ports.MembershipRepository
+	userRepo       ports.UserRepository
+	logger         ports.Logger
+}
+
+func NewMembershipService(membershipRepo ports.MembershipRepository, userRepo ports.UserRepository, logger ports.Logger) ports.MembershipService {
+	return &MembershipServiceImplementation{
+		membershipRepo: membershipRepo,
+		userRepo:       userRepo,
+		logger:         logger,
+	}
+}
+
+func (s *MembershipServiceImplementation) Authorize(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, permission domain.Permission) (*domain.CenterMembership, error) {
+	membership, err := s.membershipRepo.GetByCenterAndUser(ctx, centerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !membership.Role.Can(permission) {
+		return nil, exceptions.ErrCenterPermissionDenied
+	}
+	return membership, nil
+}
+
+func (s *MembershipServiceImplementation) AuthorizeStaff(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, staffID uuid.UUID, permission domain.Permission) (*domain.CenterMembership, error) {
+	if userID != staffID {
+		return s.Authorize(ctx, centerID, userID, permission)
+	}
+	return s.membershipRepo.GetByCenterAndUser(ctx, centerID, userID)
+}
+
+func (s *MembershipServiceImplementation) ListMembers(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterMember, error) {
+	return s.membershipRepo.ListMembers(ctx, centerID)
+}
+
+// assignableRole tells whether a role can be given through the members API,
+// a center has a single owner which is set when the center is created
+func assignableRole(role domain.CenterRole) bool {
+	return role.IsValid() && role != domain.CenterRoleOwner
+}
+
+func (s *MembershipServiceImplementation) AddMember(ctx context.Context, centerID uuid.UUID, input *domain.AddMemberInput) (*domain.CenterMember, error) {
+	if !assignableRole(input.Role) {
+		return nil, exceptions.ErrMembershipInvalidRole
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, input.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	membership := &domain.CenterMembership{
+		CenterID: centerID,
+		UserID:   user.ID,
+		Role:     input.Role,
+	}
+	err = s.membershipRepo.Create(ctx, membership)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CenterMember{
+		UserID:      user.ID,
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Role:        membership.Role,
+		Permissions: membership.Role.Permissions(),
+		JoinedAt:    membership.CreatedAt,
+	}, nil
+}
+
+// getChangeableMembership returns the membership of a member other than the
+// owner, which cannot be demoted nor removed
+func (s *MembershipServiceImplementation) getChangeableMembership(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CenterMembership, error) {
+	membership, err := s.membershipRepo.GetByCenterAndUser(ctx, centerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role == domain.CenterRoleOwner {
+		return nil, exceptions.ErrMembershipOwnerLocked
+	}
+	return membership, nil
+}
+
+func (s *MembershipServiceImplementation) UpdateMemberRole(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, role domain.CenterRole) error {
+	if !assignableRole(role) {
+		return exceptions.ErrMembershipInvalidRole
+	}
+
+	_, err := s.getChangeableMembership(ctx, centerID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.membershipRepo.UpdateRole(ctx, centerID, userID, role)
+}
+
+func (s *MembershipServiceImplementation) RemoveMember(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) error {
+	_, err := s.getChangeableMembership(ctx, centerID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.membershipRepo.Delete(ctx, centerID, userID)
+}
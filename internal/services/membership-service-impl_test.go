@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMembershipRepository struct {
+	mock.Mock
+}
+
+func (m *MockMembershipRepository) Create(ctx context.Context, membership *domain.CenterMembership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockMembershipRepository) GetByCenterAndUser(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) (*domain.CenterMembership, error) {
+	args := m.Called(ctx, centerID, userID)
+	return args.Get(0).(*domain.CenterMembership), args.Error(1)
+}
+
+func (m *MockMembershipRepository) ListMembers(ctx context.Context, centerID uuid.UUID) ([]*domain.CenterMember, error) {
+	args := m.Called(ctx, centerID)
+	return args.Get(0).([]*domain.CenterMember), args.Error(1)
+}
+
+func (m *MockMembershipRepository) UpdateRole(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, role domain.CenterRole) error {
+	args := m.Called(ctx, centerID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockMembershipRepository) Delete(ctx context.Context, centerID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, centerID, userID)
+	return args.Error(0)
+}
+
+func TestMembershipService_Authorize(t *testing.T) {
+	tests := []struct {
+		role       domain.CenterRole
+		permission domain.Permission
+		err        error
+	}{
+		{domain.CenterRoleOwner, domain.PermissionCenterDelete, nil},
+		{domain.CenterRoleAdmin, domain.PermissionCenterDelete, exceptions.ErrCenterPermissionDenied},
+		{domain.CenterRoleAdmin, domain.PermissionMembersManage, nil},
+		{domain.CenterRoleStaff, domain.PermissionMembersManage, exceptions.ErrCenterPermissionDenied},
+		{domain.CenterRoleStaff, domain.PermissionScheduleRead, nil},
+		{domain.CenterRoleReceptionist, domain.PermissionAppointmentsManage, nil},
+		{domain.CenterRoleReceptionist, domain.PermissionScheduleManage, exceptions.ErrCenterPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" "+string(tt.permission), func(t *testing.T) {
+			// Setup
+			mockMembershipRepo := new(MockMembershipRepository)
+			service := NewMembershipService(mockMembershipRepo, new(MockUserRepository), new(mocks.LoggerMock))
+			centerID, userID := uuid.New(), uuid.New()
+			membership := &domain.CenterMembership{CenterID: centerID, UserID: userID, Role: tt.role}
+
+			// Expectations
+			mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, userID).Return(membership, nil)
+
+			// Execute
+			_, err := service.Authorize(context.Background(), centerID, userID, tt.permission)
+
+			// Assert
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMembershipService_OwnerCannotBeChanged(t *testing.T) {
+	// Setup
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewMembershipService(mockMembershipRepo, new(MockUserRepository), new(mocks.LoggerMock))
+	centerID, ownerID := uuid.New(), uuid.New()
+	owner := &domain.CenterMembership{CenterID: centerID, UserID: ownerID, Role: domain.CenterRoleOwner}
+
+	// Expectations
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, ownerID).Return(owner, nil)
+
+	// Execute
+	updateErr := service.UpdateMemberRole(context.Background(), centerID, ownerID, domain.CenterRoleStaff)
+	removeErr := service.RemoveMember(context.Background(), centerID, ownerID)
+	promoteErr := service.UpdateMemberRole(context.Background(), centerID, uuid.New(), domain.CenterRoleOwner)
+
+	// Assert
+	assert.ErrorIs(t, updateErr, exceptions.ErrMembershipOwnerLocked)
+	assert.ErrorIs(t, removeErr, exceptions.ErrMembershipOwnerLocked)
+	assert.ErrorIs(t, promoteErr, exceptions.ErrMembershipInvalidRole)
+	mockMembershipRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMembershipRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMembershipService_AuthorizeStaff(t *testing.T) {
+	// Setup
+	mockMembershipRepo := new(MockMembershipRepository)
+	service := NewMembershipService(mockMembershipRepo, new(MockUserRepository), new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	staff := &domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}
+
+	// Expectations
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(staff, nil)
+
+	// Execute
+	_, ownErr := service.AuthorizeStaff(context.Background(), centerID, staffID, staffID, domain.PermissionScheduleManage)
+	_, otherErr := service.AuthorizeStaff(context.Background(), centerID, staffID, uuid.New(), domain.PermissionScheduleManage)
+
+	// Assert
+	assert.NoError(t, ownErr)
+	assert.ErrorIs(t, otherErr, exceptions.ErrCenterPermissionDenied)
+}
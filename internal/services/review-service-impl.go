@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultReviewPageSize = 20
+	maxReviewPageSize     = 100
+)
+
+type ReviewServiceImplementation struct {
+	reviewRepo      ports.ReviewRepository
+	appointmentRepo ports.AppointmentRepository
+	centersRepo     ports.CentersRepository
+	leadRepo        ports.LeadRepository
+	mailer          ports.Mailer
+	signer          *appointmentLinkSigner
+	config          domain.BookingConfig
+	logger          ports.Logger
+}
+
+func NewReviewService(reviewRepo ports.ReviewRepository, appointmentRepo ports.AppointmentRepository, centersRepo ports.CentersRepository, leadRepo ports.LeadRepository, mailer ports.Mailer, config domain.BookingConfig, appURL string, secret []byte, logger ports.Logger) ports.ReviewService {
+	return &ReviewServiceImplementation{
+		reviewRepo:      reviewRepo,
+		appointmentRepo: appointmentRepo,
+		centersRepo:     centersRepo,
+		leadRepo:        leadRepo,
+		mailer:          mailer,
+		signer:          &appointmentLinkSigner{appURL: appURL, secret: secret},
+		config:          config,
+		logger:          logger,
+	}
+}
+
+func (s *ReviewServiceImplementation) List(ctx context.Context, centerID uuid.UUID, filter *domain.ReviewFilter) ([]*domain.AppointmentReview, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, exceptions.ErrReviewInvalidStatus
+	}
+	pageReviews(filter)
+	return s.reviewRepo.List(ctx, centerID, filter)
+}
+
+func (s *ReviewServiceImplementation) Moderate(ctx context.Context, actor *domain.CenterMembership, id uuid.UUID, input *domain.ModerateReviewInput) (*domain.AppointmentReview, error) {
+	if !input.Status.IsValid() {
+		return nil, exceptions.ErrReviewInvalidStatus
+	}
+	review, err := s.reviewRepo.GetByID(ctx, actor.CenterID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	review.Status = input.Status
+	review.ModerationNote = strings.TrimSpace(input.Note)
+	review.ModeratedBy = &actor.UserID
+	review.ModeratedAt = &now
+	err = s.reviewRepo.Moderate(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *ReviewServiceImplementation) IssueLink(ctx context.Context, actor *domain.CenterMembership, appointmentID uuid.UUID) (*domain.ReviewLink, error) {
+	appointment, err := s.appointmentRepo.GetByID(ctx, actor.CenterID, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	err = authorizeAppointment(actor, appointment.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isReviewable(appointment, time.Now()) {
+		return nil, exceptions.ErrReviewNotReviewable
+	}
+	return s.signer.reviewLink(appointment, s.config.ReviewLinkDuration), nil
+}
+
+// RequestReview skips the appointments not booked by a lead, and the leads
+// without an email or who don't consent to communications. Members can still
+// issue the link of those appointments.
+func (s *ReviewServiceImplementation) RequestReview(ctx context.Context, appointment *domain.Appointment) error {
+	if appointment.LeadID == nil || !s.isReviewable(appointment, time.Now()) {
+		return nil
+	}
+	lead, err := s.leadRepo.GetByID(ctx, appointment.CenterID, *appointment.LeadID)
+	if err != nil {
+		return err
+	}
+	if lead.Email == "" {
+		return nil
+	}
+	consents, err := s.leadRepo.ListConsents(ctx, lead.ID)
+	if err != nil {
+		return err
+	}
+	lead.Consents = domain.CurrentConsents(consents)
+	if !lead.HasConsent(domain.ConsentPurposeCommunications) {
+		return nil
+	}
+
+	center, err := s.centersRepo.GetByID(ctx, appointment.CenterID)
+	if err != nil {
+		return err
+	}
+	service, err := s.centersRepo.GetService(ctx, center.ID, appointment.ServiceID)
+	if err != nil {
+		return err
+	}
+	loc, err := center.Location()
+	if err != nil {
+		loc = time.UTC
+	}
+
+	link := s.signer.reviewLink(appointment, s.config.ReviewLinkDuration)
+	return s.mailer.Send(ctx, &domain.EmailMessage{
+		To:      lead.Email,
+		Subject: fmt.Sprintf("How was your visit to %s?", center.Name),
+		Body: fmt.Sprintf("Hello %s,\n\nThank you for your visit for %s. Tell us how it went, it only takes a minute:\n\n%s\n\n"+
+			"The link works until %s.\n",
+			lead.Name, service.Name, link.URL, link.ExpiresAt.In(loc).Format(offerTimeLayout)),
+	})
+}
+
+func (s *ReviewServiceImplementation) GetReviewable(ctx context.Context, linkToken string) (*domain.ReviewableAppointment, error) {
+	center, appointment, err := s.resolve(ctx, linkToken)
+	if err != nil {
+		return nil, err
+	}
+	service, err := s.centersRepo.GetService(ctx, center.ID, appointment.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewable := &domain.ReviewableAppointment{
+		AppointmentID: appointment.ID,
+		CenterName:    center.Name,
+		ServiceName:   service.Name,
+		StaffID:       appointment.StaffID,
+		StartsAt:      appointment.StartsAt,
+	}
+	review, err := s.reviewRepo.GetByAppointment(ctx, center.ID, appointment.ID)
+	if err == nil {
+		reviewable.Review = review
+	} else if !errors.Is(err, exceptions.ErrReviewNotFound) {
+		return nil, err
+	}
+	return reviewable, nil
+}
+
+// Submit publishes the review right away, members flag or hide it afterwards
+func (s *ReviewServiceImplementation) Submit(ctx context.Context, linkToken string, input *domain.SubmitReviewInput) (*domain.AppointmentReview, error) {
+	if input.Rating < domain.MinReviewRating || input.Rating > domain.MaxReviewRating {
+		return nil, exceptions.ErrReviewInvalidRating
+	}
+	center, appointment, err := s.resolve(ctx, linkToken)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &domain.AppointmentReview{
+		CenterID:      center.ID,
+		AppointmentID: appointment.ID,
+		StaffID:       appointment.StaffID,
+		ServiceID:     appointment.ServiceID,
+		LeadID:        appointment.LeadID,
+		AuthorName:    domain.ReviewAuthorName(appointment.CustomerName),
+		Rating:        input.Rating,
+		Comment:       strings.TrimSpace(input.Comment),
+		Status:        domain.ReviewStatusPublished,
+	}
+	err = s.reviewRepo.Create(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *ReviewServiceImplementation) ListPublished(ctx context.Context, slug string, filter *domain.ReviewFilter) ([]*domain.PublicReview, error) {
+	center, err := s.centersRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	filter.Status = domain.ReviewStatusPublished
+	pageReviews(filter)
+	reviews, err := s.reviewRepo.List(ctx, center.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	published := make([]*domain.PublicReview, len(reviews))
+	for i, review := range reviews {
+		published[i] = &domain.PublicReview{
+			StaffID:    review.StaffID,
+			ServiceID:  review.ServiceID,
+			AuthorName: review.AuthorName,
+			Rating:     review.Rating,
+			Comment:    review.Comment,
+			CreatedAt:  review.CreatedAt,
+		}
+	}
+	return published, nil
+}
+
+func (s *ReviewServiceImplementation) GetStats(ctx context.Context, slug string) (*domain.ReviewStats, error) {
+	center, err := s.centersRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.reviewRepo.CountRatings(ctx, center.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.ReviewStats{Staff: []*domain.StaffRatingStats{}}
+	byStaff := make(map[uuid.UUID]*domain.StaffRatingStats)
+	for _, count := range counts {
+		stats.Center.Add(count.Rating, count.Count)
+		staffStats, found := byStaff[count.StaffID]
+		if !found {
+			staffStats = &domain.StaffRatingStats{StaffID: count.StaffID}
+			byStaff[count.StaffID] = staffStats
+			stats.Staff = append(stats.Staff, staffStats)
+		}
+		staffStats.Add(count.Rating, count.Count)
+	}
+	stats.Center = stats.Center.Rounded()
+	for _, staffStats := range stats.Staff {
+		staffStats.RatingStats = staffStats.RatingStats.Rounded()
+	}
+	return stats, nil
+}
+
+// resolve returns the appointment of a review link that is still valid
+func (s *ReviewServiceImplementation) resolve(ctx context.Context, linkToken string) (*domain.Center, *domain.Appointment, error) {
+	link, err := s.signer.verify(linkToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	if link.purpose != domain.AppointmentLinkPurposeReview {
+		return nil, nil, exceptions.ErrAppointmentLinkWrongPurpose
+	}
+	now := time.Now()
+	if !now.Before(link.expiresAt) {
+		return nil, nil, exceptions.ErrAppointmentLinkExpired
+	}
+
+	appointment, err := s.appointmentRepo.GetByID(ctx, link.centerID, link.appointmentID)
+	if errors.Is(err, exceptions.ErrAppointmentNotFound) {
+		return nil, nil, exceptions.ErrAppointmentLinkInvalid
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if appointment.LinkVersion != link.version {
+		return nil, nil, exceptions.ErrAppointmentLinkRevoked
+	}
+	if appointment.Status != domain.AppointmentStatusCompleted {
+		return nil, nil, exceptions.ErrReviewNotReviewable
+	}
+
+	center, err := s.centersRepo.GetByID(ctx, link.centerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return center, appointment, nil
+}
+
+// isReviewable tells whether the appointment is completed and its review
+// link would not be expired yet
+func (s *ReviewServiceImplementation) isReviewable(appointment *domain.Appointment, now time.Time) bool {
+	return appointment.Status == domain.AppointmentStatusCompleted && appointment.CompletedAt != nil &&
+		now.Before(appointment.CompletedAt.Add(s.config.ReviewLinkDuration))
+}
+
+func pageReviews(filter *domain.ReviewFilter) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultReviewPageSize
+	}
+	if filter.Limit > maxReviewPageSize {
+		filter.Limit = maxReviewPageSize
+	}
+}
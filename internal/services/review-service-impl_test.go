@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewRepository) Create(ctx context.Context, review *domain.AppointmentReview) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockReviewRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.AppointmentReview, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.AppointmentReview), args.Error(1)
+}
+
+func (m *MockReviewRepository) GetByAppointment(ctx context.Context, centerID uuid.UUID, appointmentID uuid.UUID) (*domain.AppointmentReview, error) {
+	args := m.Called(ctx, centerID, appointmentID)
+	return args.Get(0).(*domain.AppointmentReview), args.Error(1)
+}
+
+func (m *MockReviewRepository) List(ctx context.Context, centerID uuid.UUID, filter *domain.ReviewFilter) ([]*domain.AppointmentReview, error) {
+	args := m.Called(ctx, centerID, filter)
+	return args.Get(0).([]*domain.AppointmentReview), args.Error(1)
+}
+
+func (m *MockReviewRepository) Moderate(ctx context.Context, review *domain.AppointmentReview) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockReviewRepository) CountRatings(ctx context.Context, centerID uuid.UUID) ([]*domain.RatingCount, error) {
+	args := m.Called(ctx, centerID)
+	return args.Get(0).([]*domain.RatingCount), args.Error(1)
+}
+
+var testReviewConfig = domain.BookingConfig{ReviewLinkDuration: 72 * time.Hour}
+
+// completedAppointment returns an appointment of the center completed an
+// hour ago
+func completedAppointment(centerID uuid.UUID) *domain.Appointment {
+	completedAt := time.Now().Add(-time.Hour)
+	return &domain.Appointment{
+		ID:           uuid.New(),
+		CenterID:     centerID,
+		StaffID:      uuid.New(),
+		ServiceID:    uuid.New(),
+		StartsAt:     completedAt.Add(-30 * time.Minute),
+		EndsAt:       completedAt,
+		Status:       domain.AppointmentStatusCompleted,
+		CompletedAt:  &completedAt,
+		CustomerName: "Ana García",
+		LinkVersion:  2,
+	}
+}
+
+func TestReviewService_Submit(t *testing.T) {
+	// Setup
+	mockReviewRepo := new(MockReviewRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewReviewService(mockReviewRepo, mockAppointmentRepo, mockCentersRepo, new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	appointment := completedAppointment(center.ID)
+	link := service.signer.reviewLink(appointment, service.config.ReviewLinkDuration)
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, appointment.ID).Return(appointment, nil)
+	mockReviewRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AppointmentReview")).Return(nil).Once()
+
+	// Execute
+	review, err := service.Submit(context.Background(), linkToken(t, link.URL), &domain.SubmitReviewInput{Rating: 4, Comment: "  Muy amables  "})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, appointment.ID, review.AppointmentID)
+	assert.Equal(t, appointment.StaffID, review.StaffID)
+	assert.Equal(t, "Ana", review.AuthorName)
+	assert.Equal(t, "Muy amables", review.Comment)
+	assert.Equal(t, domain.ReviewStatusPublished, review.Status)
+	mockReviewRepo.AssertExpectations(t)
+}
+
+func TestReviewService_SubmitTwice(t *testing.T) {
+	// Setup
+	mockReviewRepo := new(MockReviewRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewReviewService(mockReviewRepo, mockAppointmentRepo, mockCentersRepo, new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	appointment := completedAppointment(center.ID)
+	link := service.signer.reviewLink(appointment, service.config.ReviewLinkDuration)
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, appointment.ID).Return(appointment, nil)
+	mockReviewRepo.On("Create", mock.Anything, mock.Anything).Return(exceptions.ErrReviewAlreadySubmitted).Once()
+
+	// Execute
+	_, err := service.Submit(context.Background(), linkToken(t, link.URL), &domain.SubmitReviewInput{Rating: 5})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrReviewAlreadySubmitted)
+}
+
+func TestReviewService_SubmitRejectsOtherLinks(t *testing.T) {
+	// Setup
+	mockReviewRepo := new(MockReviewRepository)
+	service := NewReviewService(mockReviewRepo, new(MockAppointmentRepository), new(MockCentersRepository), new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	appointment := completedAppointment(center.ID)
+	cancelURL := service.signer.url(appointment, domain.AppointmentLinkPurposeCancel, time.Now().Add(time.Hour))
+
+	// Execute
+	_, err := service.Submit(context.Background(), linkToken(t, cancelURL), &domain.SubmitReviewInput{Rating: 5})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrAppointmentLinkWrongPurpose)
+	mockReviewRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestReviewService_SubmitRejectsReopenedAppointment(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	service := NewReviewService(new(MockReviewRepository), mockAppointmentRepo, new(MockCentersRepository), new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	appointment := completedAppointment(center.ID)
+	link := service.signer.reviewLink(appointment, service.config.ReviewLinkDuration)
+	appointment.Status = domain.AppointmentStatusNoShow
+
+	// Expectations
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, appointment.ID).Return(appointment, nil)
+
+	// Execute
+	_, err := service.Submit(context.Background(), linkToken(t, link.URL), &domain.SubmitReviewInput{Rating: 5})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrReviewNotReviewable)
+}
+
+func TestReviewService_SubmitInvalidRating(t *testing.T) {
+	// Setup
+	service := NewReviewService(new(MockReviewRepository), new(MockAppointmentRepository), new(MockCentersRepository), new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	appointment := completedAppointment(center.ID)
+	link := service.signer.reviewLink(appointment, service.config.ReviewLinkDuration)
+
+	// Execute
+	_, err := service.Submit(context.Background(), linkToken(t, link.URL), &domain.SubmitReviewInput{Rating: 6})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrReviewInvalidRating)
+}
+
+func TestReviewService_IssueLinkRequiresCompletion(t *testing.T) {
+	// Setup
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	service := NewReviewService(new(MockReviewRepository), mockAppointmentRepo, new(MockCentersRepository), new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	appointment := completedAppointment(center.ID)
+	appointment.Status = domain.AppointmentStatusConfirmed
+	appointment.CompletedAt = nil
+	actor := &domain.CenterMembership{CenterID: center.ID, UserID: uuid.New(), Role: domain.CenterRoleAdmin}
+
+	// Expectations
+	mockAppointmentRepo.On("GetByID", mock.Anything, center.ID, appointment.ID).Return(appointment, nil)
+
+	// Execute
+	_, err := service.IssueLink(context.Background(), actor, appointment.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrReviewNotReviewable)
+}
+
+func TestReviewService_Moderate(t *testing.T) {
+	// Setup
+	mockReviewRepo := new(MockReviewRepository)
+	service := NewReviewService(mockReviewRepo, new(MockAppointmentRepository), new(MockCentersRepository), new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	review := &domain.AppointmentReview{ID: uuid.New(), CenterID: center.ID, Rating: 1, Status: domain.ReviewStatusPublished}
+	actor := &domain.CenterMembership{CenterID: center.ID, UserID: uuid.New(), Role: domain.CenterRoleOwner}
+
+	// Expectations
+	mockReviewRepo.On("GetByID", mock.Anything, center.ID, review.ID).Return(review, nil).Once()
+	mockReviewRepo.On("Moderate", mock.Anything, review).Return(nil).Once()
+
+	// Execute
+	moderated, err := service.Moderate(context.Background(), actor, review.ID, &domain.ModerateReviewInput{Status: domain.ReviewStatusHidden, Note: " Insultos "})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusHidden, moderated.Status)
+	assert.Equal(t, "Insultos", moderated.ModerationNote)
+	assert.Equal(t, actor.UserID, *moderated.ModeratedBy)
+	assert.NotNil(t, moderated.ModeratedAt)
+	mockReviewRepo.AssertExpectations(t)
+}
+
+func TestReviewService_ModerateInvalidStatus(t *testing.T) {
+	// Setup
+	service := NewReviewService(new(MockReviewRepository), new(MockAppointmentRepository), new(MockCentersRepository), new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	actor := &domain.CenterMembership{CenterID: center.ID, UserID: uuid.New(), Role: domain.CenterRoleOwner}
+
+	// Execute
+	_, err := service.Moderate(context.Background(), actor, uuid.New(), &domain.ModerateReviewInput{Status: "deleted"})
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrReviewInvalidStatus)
+}
+
+func TestReviewService_GetStats(t *testing.T) {
+	// Setup
+	mockReviewRepo := new(MockReviewRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewReviewService(mockReviewRepo, new(MockAppointmentRepository), mockCentersRepo, new(MockLeadRepository), new(MockMailer), testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	ana, luis := uuid.New(), uuid.New()
+
+	// Expectations
+	mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+	mockReviewRepo.On("CountRatings", mock.Anything, center.ID).Return([]*domain.RatingCount{
+		{StaffID: ana, Rating: 5, Count: 2},
+		{StaffID: ana, Rating: 4, Count: 1},
+		{StaffID: luis, Rating: 2, Count: 1},
+	}, nil).Once()
+
+	// Execute
+	stats, err := service.GetStats(context.Background(), center.Slug)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), stats.Center.Count)
+	assert.Equal(t, 4.0, stats.Center.Average)
+	assert.Equal(t, [5]int64{0, 1, 0, 1, 2}, stats.Center.Distribution)
+	assert.Len(t, stats.Staff, 2)
+	assert.Equal(t, ana, stats.Staff[0].StaffID)
+	assert.Equal(t, int64(3), stats.Staff[0].Count)
+	assert.Equal(t, 4.67, stats.Staff[0].Average)
+	assert.Equal(t, 2.0, stats.Staff[1].Average)
+}
+
+func TestReviewService_RequestReview(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	mockLeadRepo := new(MockLeadRepository)
+	mockMailer := new(MockMailer)
+	service := NewReviewService(new(MockReviewRepository), new(MockAppointmentRepository), mockCentersRepo, mockLeadRepo, mockMailer, testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	appointment := completedAppointment(center.ID)
+	lead := &domain.Lead{ID: uuid.New(), CenterID: center.ID, Name: "Ana García", Email: "ana@example.com"}
+	appointment.LeadID = &lead.ID
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, appointment.ServiceID).Return(&domain.CenterService{ID: appointment.ServiceID, Name: "Limpieza", DurationMinutes: 30, IsActive: true}, nil)
+	mockLeadRepo.On("GetByID", mock.Anything, center.ID, lead.ID).Return(lead, nil).Once()
+	mockLeadRepo.On("ListConsents", mock.Anything, lead.ID).Return([]*domain.LeadConsent{
+		{LeadID: lead.ID, Purpose: domain.ConsentPurposeCommunications, Granted: true, CreatedAt: time.Now().Add(-time.Hour)},
+	}, nil).Once()
+	mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(message *domain.EmailMessage) bool {
+		return message.To == lead.Email
+	})).Return(nil).Once()
+
+	// Execute
+	err := service.RequestReview(context.Background(), appointment)
+
+	// Assert
+	assert.NoError(t, err)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestReviewService_RequestReviewWithoutConsent(t *testing.T) {
+	// Setup
+	mockLeadRepo := new(MockLeadRepository)
+	mockMailer := new(MockMailer)
+	service := NewReviewService(new(MockReviewRepository), new(MockAppointmentRepository), new(MockCentersRepository), mockLeadRepo, mockMailer, testReviewConfig, "https://app.example.com", testLinkSecret, new(mocks.LoggerMock)).(*ReviewServiceImplementation)
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	appointment := completedAppointment(center.ID)
+	lead := &domain.Lead{ID: uuid.New(), CenterID: center.ID, Name: "Ana García", Email: "ana@example.com"}
+	appointment.LeadID = &lead.ID
+
+	// Expectations
+	mockLeadRepo.On("GetByID", mock.Anything, center.ID, lead.ID).Return(lead, nil).Once()
+	mockLeadRepo.On("ListConsents", mock.Anything, lead.ID).Return([]*domain.LeadConsent{
+		{LeadID: lead.ID, Purpose: domain.ConsentPurposeCommunications, Granted: true, CreatedAt: time.Now().Add(-2 * time.Hour)},
+		{LeadID: lead.ID, Purpose: domain.ConsentPurposeCommunications, Granted: false, CreatedAt: time.Now().Add(-time.Hour)},
+	}, nil).Once()
+
+	// Execute
+	err := service.RequestReview(context.Background(), appointment)
+
+	// Assert
+	assert.NoError(t, err)
+	mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
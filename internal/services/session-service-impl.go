@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/utils/device"
+	"github.com/google/uuid"
+)
+
+type SessionServiceImplementation struct {
+	sourceRepo ports.SourceRepository
+	logger     ports.Logger
+}
+
+func NewSessionService(sourceRepo ports.SourceRepository, logger ports.Logger) ports.SessionService {
+	return &SessionServiceImplementation{
+		sourceRepo: sourceRepo,
+		logger:     logger,
+	}
+}
+
+func sourceToSession(source *domain.Source, currentSourceID string) *domain.Session {
+	return &domain.Session{
+		ID:           source.ID,
+		Current:      source.ID.String() == currentSourceID,
+		Device:       device.Parse(source.UserAgent),
+		UserAgent:    source.UserAgent,
+		IPAddress:    source.IPAddress,
+		City:         source.City,
+		CountryCode:  source.CountryCode,
+		CreatedAt:    source.CreatedAt,
+		LastActiveAt: source.UpdatedAt,
+		ExpiresAt:    source.RefreshTokenExpiresAt,
+	}
+}
+
+// List returns the active, non expired sessions of a user
+func (s *SessionServiceImplementation) List(ctx context.Context, userID uuid.UUID, currentSourceID string) ([]*domain.Session, error) {
+	sources, err := s.sourceRepo.GetByUserID(ctx, userID.String())
+	if err != nil {
+		s.logger.Error(ctx, err)
+		return nil, err
+	}
+
+	now := time.Now()
+	sessions := make([]*domain.Session, 0, len(sources))
+	for _, source := range sources {
+		if now.After(source.RefreshTokenExpiresAt) {
+			continue
+		}
+		sessions = append(sessions, sourceToSession(source, currentSourceID))
+	}
+
+	return sessions, nil
+}
+
+// Revoke deactivates a single session. Sessions of other users are reported as
+// not found so their IDs cannot be probed.
+func (s *SessionServiceImplementation) Revoke(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	source, err := s.sourceRepo.GetByID(ctx, sessionID.String())
+	if err != nil {
+		return err
+	}
+
+	if source.UserID != userID || !source.IsActive {
+		return exceptions.ErrAuthSourceNotFound
+	}
+
+	source.IsActive = false
+	source.UpdatedAt = time.Now()
+
+	return s.sourceRepo.Update(ctx, source)
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/scheduling"
+	"github.com/google/uuid"
+)
+
+const (
+	slotGranularity = 15 * time.Minute
+	maxSlotRange    = 31 * 24 * time.Hour
+)
+
+type SlotServiceImplementation struct {
+	centersRepo      ports.CentersRepository
+	availabilityRepo ports.AvailabilityRepository
+	timeBlockRepo    ports.TimeBlockRepository
+	appointmentRepo  ports.AppointmentRepository
+	seriesRepo       ports.AppointmentSeriesRepository
+	holdRepo         ports.SlotHoldRepository
+	membershipRepo   ports.MembershipRepository
+	logger           ports.Logger
+}
+
+func NewSlotService(centersRepo ports.CentersRepository, availabilityRepo ports.AvailabilityRepository, timeBlockRepo ports.TimeBlockRepository, appointmentRepo ports.AppointmentRepository, seriesRepo ports.AppointmentSeriesRepository, holdRepo ports.SlotHoldRepository, membershipRepo ports.MembershipRepository, logger ports.Logger) ports.SlotService {
+	return &SlotServiceImplementation{
+		centersRepo:      centersRepo,
+		availabilityRepo: availabilityRepo,
+		timeBlockRepo:    timeBlockRepo,
+		appointmentRepo:  appointmentRepo,
+		seriesRepo:       seriesRepo,
+		holdRepo:         holdRepo,
+		membershipRepo:   membershipRepo,
+		logger:           logger,
+	}
+}
+
+func (s *SlotServiceImplementation) FindSlots(ctx context.Context, centerID uuid.UUID, query *domain.SlotQuery) ([]*domain.Slot, error) {
+	if !query.To.After(query.From) {
+		return nil, exceptions.ErrSlotInvalidRange
+	}
+	if query.To.Sub(query.From) > maxSlotRange {
+		return nil, exceptions.ErrSlotRangeTooLarge
+	}
+
+	from, to := query.From, query.To
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+	if !to.After(from) {
+		return []*domain.Slot{}, nil
+	}
+
+	center, err := s.centersRepo.GetByID(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := center.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := s.centersRepo.GetService(ctx, centerID, query.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if !service.IsActive {
+		return nil, exceptions.ErrCenterServiceNotFound
+	}
+
+	openingHours, err := s.centersRepo.ListOpeningHours(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesByStaff, err := s.staffRules(ctx, centerID, query.StaffID)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(service.DurationMinutes) * time.Minute
+	bufferBefore := time.Duration(service.BufferBeforeMinutes) * time.Minute
+	bufferAfter := time.Duration(service.BufferAfterMinutes) * time.Minute
+	busyByStaff, closures, err := s.busyTime(ctx, centerID, from.Add(-bufferBefore), to.Add(duration+bufferAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	slots := []*domain.Slot{}
+	for staffID, rules := range rulesByStaff {
+		staffSlots, err := scheduling.Slots(&scheduling.Request{
+			Location:     loc,
+			From:         from,
+			To:           to,
+			Duration:     duration,
+			BufferBefore: bufferBefore,
+			BufferAfter:  bufferAfter,
+			Granularity:  slotGranularity,
+			Rules:        rules,
+			OpeningHours: openingHours,
+			Busy:         append(busyByStaff[staffID], closures...),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range staffSlots {
+			slots = append(slots, &domain.Slot{StaffID: staffID, StartsAt: slot.Start.In(loc), EndsAt: slot.End.In(loc)})
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		if !slots[i].StartsAt.Equal(slots[j].StartsAt) {
+			return slots[i].StartsAt.Before(slots[j].StartsAt)
+		}
+		return slots[i].StaffID.String() < slots[j].StaffID.String()
+	})
+	return slots, nil
+}
+
+// staffRules returns the availability rules of the staff member of the query,
+// or of every staff member of the center, grouped by staff member
+func (s *SlotServiceImplementation) staffRules(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID) (map[uuid.UUID][]*domain.AvailabilityRule, error) {
+	var rules []*domain.AvailabilityRule
+	var err error
+	if staffID != nil {
+		_, err = s.membershipRepo.GetByCenterAndUser(ctx, centerID, *staffID)
+		if err != nil {
+			return nil, err
+		}
+		rules, err = s.availabilityRepo.ListByStaff(ctx, centerID, *staffID)
+	} else {
+		rules, err = s.availabilityRepo.ListActiveByCenter(ctx, centerID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rulesByStaff := make(map[uuid.UUID][]*domain.AvailabilityRule)
+	for _, rule := range rules {
+		rulesByStaff[rule.UserID] = append(rulesByStaff[rule.UserID], rule)
+	}
+	return rulesByStaff, nil
+}
+
+// busyTime returns the time taken by time blocks, appointments, the
+// occurrences of recurring appointments and the active slot holds for each
+// staff member, and the closures of the center, which apply to everyone
+func (s *SlotServiceImplementation) busyTime(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) (map[uuid.UUID][]scheduling.Interval, []scheduling.Interval, error) {
+	blocks, err := s.timeBlockRepo.ListInRange(ctx, centerID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	busyByStaff := make(map[uuid.UUID][]scheduling.Interval)
+	var closures []scheduling.Interval
+	for _, block := range blocks {
+		interval := scheduling.Interval{Start: block.StartsAt, End: block.EndsAt}
+		if block.IsClosure() {
+			closures = append(closures, interval)
+		} else {
+			busyByStaff[*block.UserID] = append(busyByStaff[*block.UserID], interval)
+		}
+	}
+
+	appointments, err := s.appointmentRepo.ListBusyInRange(ctx, centerID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, appointment := range appointments {
+		interval := scheduling.Interval{Start: appointment.StartsAt, End: appointment.EndsAt}
+		busyByStaff[appointment.StaffID] = append(busyByStaff[appointment.StaffID], interval)
+	}
+
+	occurrences, err := pendingOccurrences(ctx, s.seriesRepo, centerID, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, occurrence := range occurrences {
+		interval := scheduling.Interval{Start: occurrence.StartsAt, End: occurrence.EndsAt}
+		busyByStaff[occurrence.StaffID] = append(busyByStaff[occurrence.StaffID], interval)
+	}
+
+	holds, err := s.holdRepo.ListActiveInRange(ctx, centerID, from, to, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, hold := range holds {
+		interval := scheduling.Interval{Start: hold.StartsAt, End: hold.EndsAt}
+		busyByStaff[hold.StaffID] = append(busyByStaff[hold.StaffID], interval)
+	}
+	return busyByStaff, closures, nil
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSlotService_FindSlots(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	service := NewSlotService(mockCentersRepo, mockAvailabilityRepo, mockTimeBlockRepo, mockAppointmentRepo, newEmptySeriesRepository(), mockHoldRepo, new(MockMembershipRepository), new(mocks.LoggerMock))
+	centerID, serviceID, aliceID, bobID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	// A Monday far enough in the future
+	at := func(hour, minute int) time.Time { return time.Date(2035, 1, 1, hour, minute, 0, 0, madrid) }
+	rules := []*domain.AvailabilityRule{
+		{UserID: aliceID, Weekday: time.Monday, StartTime: 9 * 60, EndTime: 10 * 60, IsActive: true},
+		{UserID: bobID, Weekday: time.Monday, StartTime: 9 * 60, EndTime: 11 * 60, IsActive: true},
+	}
+	blocks := []*domain.TimeBlock{
+		{UserID: &aliceID, StartsAt: at(9, 0), EndsAt: at(9, 30), Reason: domain.TimeBlockReasonPersonal},
+		{StartsAt: at(10, 30), EndsAt: at(12, 0), Reason: domain.TimeBlockReasonHoliday},
+	}
+	appointments := []*domain.Appointment{
+		{StaffID: bobID, StartsAt: at(9, 30), EndsAt: at(9, 45), Status: domain.AppointmentStatusConfirmed},
+	}
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Timezone: "Europe/Madrid"}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockCentersRepo.On("ListOpeningHours", mock.Anything, centerID).Return([]*domain.OpeningHours{}, nil)
+	mockAvailabilityRepo.On("ListActiveByCenter", mock.Anything, centerID).Return(rules, nil)
+	mockTimeBlockRepo.On("ListInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return(blocks, nil)
+	mockAppointmentRepo.On("ListBusyInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return(appointments, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, centerID, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.SlotHold{}, nil)
+
+	// Execute
+	slots, err := service.FindSlots(context.Background(), centerID, &domain.SlotQuery{ServiceID: serviceID, From: at(0, 0), To: at(23, 0)})
+
+	// Assert
+	assert.NoError(t, err)
+	type slot struct {
+		staff uuid.UUID
+		start time.Time
+	}
+	expected := []slot{
+		{aliceID, at(9, 30)},
+		{bobID, at(9, 0)}, {bobID, at(9, 45)}, {bobID, at(10, 0)},
+	}
+	found := make([]slot, len(slots))
+	for i, s := range slots {
+		found[i] = slot{s.StaffID, s.StartsAt}
+	}
+	assert.ElementsMatch(t, expected, found)
+	for i := 1; i < len(slots); i++ {
+		assert.False(t, slots[i].StartsAt.Before(slots[i-1].StartsAt))
+	}
+}
+
+func TestSlotService_FindSlotsSkipsHeldTime(t *testing.T) {
+	// Setup
+	mockCentersRepo := new(MockCentersRepository)
+	mockAvailabilityRepo := new(MockAvailabilityRepository)
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	service := NewSlotService(mockCentersRepo, mockAvailabilityRepo, mockTimeBlockRepo, mockAppointmentRepo, newEmptySeriesRepository(), mockHoldRepo, new(MockMembershipRepository), new(mocks.LoggerMock))
+	centerID, serviceID, staffID := uuid.New(), uuid.New(), uuid.New()
+	at := func(hour, minute int) time.Time { return time.Date(2035, 1, 1, hour, minute, 0, 0, time.UTC) }
+	holds := []*domain.SlotHold{
+		{StaffID: staffID, StartsAt: at(9, 30), EndsAt: at(10, 0), ExpiresAt: time.Now().Add(5 * time.Minute)},
+	}
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Timezone: "UTC"}, nil)
+	mockCentersRepo.On("GetService", mock.Anything, centerID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockCentersRepo.On("ListOpeningHours", mock.Anything, centerID).Return([]*domain.OpeningHours{}, nil)
+	mockAvailabilityRepo.On("ListActiveByCenter", mock.Anything, centerID).Return([]*domain.AvailabilityRule{
+		{UserID: staffID, Weekday: time.Monday, StartTime: 9 * 60, EndTime: 10*60 + 30, IsActive: true},
+	}, nil)
+	mockTimeBlockRepo.On("ListInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.TimeBlock{}, nil)
+	mockAppointmentRepo.On("ListBusyInRange", mock.Anything, centerID, mock.Anything, mock.Anything).Return([]*domain.Appointment{}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, centerID, mock.Anything, mock.Anything, mock.Anything).Return(holds, nil)
+
+	// Execute
+	slots, err := service.FindSlots(context.Background(), centerID, &domain.SlotQuery{ServiceID: serviceID, From: at(0, 0), To: at(23, 0)})
+
+	// Assert
+	assert.NoError(t, err)
+	starts := make([]time.Time, len(slots))
+	for i, slot := range slots {
+		starts[i] = slot.StartsAt
+	}
+	assert.Equal(t, []time.Time{at(9, 0), at(10, 0)}, starts)
+}
+
+func TestSlotService_InvalidRange(t *testing.T) {
+	from := time.Date(2035, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		to   time.Time
+		err  error
+	}{
+		{"inverted", from.Add(-time.Hour), exceptions.ErrSlotInvalidRange},
+		{"empty", from, exceptions.ErrSlotInvalidRange},
+		{"too large", from.AddDate(0, 0, 32), exceptions.ErrSlotRangeTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			service := NewSlotService(new(MockCentersRepository), new(MockAvailabilityRepository), new(MockTimeBlockRepository), new(MockAppointmentRepository), newEmptySeriesRepository(), new(MockSlotHoldRepository), new(MockMembershipRepository), new(mocks.LoggerMock))
+
+			// Execute
+			_, err := service.FindSlots(context.Background(), uuid.New(), &domain.SlotQuery{ServiceID: uuid.New(), From: from, To: tt.to})
+
+			// Assert
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
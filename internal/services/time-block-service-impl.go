@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"github.com/google/uuid"
+)
+
+type TimeBlockServiceImplementation struct {
+	timeBlockRepo  ports.TimeBlockRepository
+	centersRepo    ports.CentersRepository
+	membershipRepo ports.MembershipRepository
+	logger         ports.Logger
+}
+
+func NewTimeBlockService(timeBlockRepo ports.TimeBlockRepository, centersRepo ports.CentersRepository, membershipRepo ports.MembershipRepository, logger ports.Logger) ports.TimeBlockService {
+	return &TimeBlockServiceImplementation{
+		timeBlockRepo:  timeBlockRepo,
+		centersRepo:    centersRepo,
+		membershipRepo: membershipRepo,
+		logger:         logger,
+	}
+}
+
+func (s *TimeBlockServiceImplementation) List(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, from *time.Time, to *time.Time) ([]*domain.TimeBlock, error) {
+	if staffID != nil {
+		_, err := s.membershipRepo.GetByCenterAndUser(ctx, centerID, *staffID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.timeBlockRepo.List(ctx, centerID, staffID, from, to)
+}
+
+func (s *TimeBlockServiceImplementation) Create(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, input *domain.TimeBlockInput) (*domain.TimeBlock, error) {
+	if staffID != nil {
+		_, err := s.membershipRepo.GetByCenterAndUser(ctx, centerID, *staffID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	block := &domain.TimeBlock{
+		CenterID: centerID,
+		UserID:   staffID,
+		Source:   domain.TimeBlockSourceManual,
+	}
+	err := s.applyInput(ctx, block, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.timeBlockRepo.Create(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (s *TimeBlockServiceImplementation) Update(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, blockID uuid.UUID, input *domain.TimeBlockInput) (*domain.TimeBlock, error) {
+	block, err := s.getBlock(ctx, centerID, staffID, blockID)
+	if err != nil {
+		return nil, err
+	}
+	// The next import would undo the changes
+	if block.IsImported() {
+		return nil, exceptions.ErrTimeBlockImported
+	}
+
+	err = s.applyInput(ctx, block, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.timeBlockRepo.Update(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (s *TimeBlockServiceImplementation) Delete(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, blockID uuid.UUID) error {
+	_, err := s.getBlock(ctx, centerID, staffID, blockID)
+	if err != nil {
+		return err
+	}
+	return s.timeBlockRepo.Delete(ctx, centerID, blockID)
+}
+
+// getBlock hides the blocks of other staff members, and closures from the
+// staff routes, behind a not found
+func (s *TimeBlockServiceImplementation) getBlock(ctx context.Context, centerID uuid.UUID, staffID *uuid.UUID, blockID uuid.UUID) (*domain.TimeBlock, error) {
+	block, err := s.timeBlockRepo.GetByID(ctx, centerID, blockID)
+	if err != nil {
+		return nil, err
+	}
+	if staffID == nil && !block.IsClosure() {
+		return nil, exceptions.ErrTimeBlockNotFound
+	}
+	if staffID != nil && (block.IsClosure() || *block.UserID != *staffID) {
+		return nil, exceptions.ErrTimeBlockNotFound
+	}
+	return block, nil
+}
+
+// applyInput resolves all-day blocks to the midnights of the center timezone,
+// so a block keeps covering whole days across DST changes
+func (s *TimeBlockServiceImplementation) applyInput(ctx context.Context, block *domain.TimeBlock, input *domain.TimeBlockInput) error {
+	if !input.Reason.IsValid() {
+		return exceptions.ErrTimeBlockInvalidReason
+	}
+
+	if input.AllDay {
+		if input.StartDate == nil {
+			return exceptions.ErrTimeBlockInvalidRange
+		}
+		endDate := *input.StartDate
+		if input.EndDate != nil {
+			endDate = *input.EndDate
+		}
+		if endDate.Before(*input.StartDate) {
+			return exceptions.ErrTimeBlockInvalidRange
+		}
+
+		center, err := s.centersRepo.GetByID(ctx, block.CenterID)
+		if err != nil {
+			return err
+		}
+		loc, err := center.Location()
+		if err != nil {
+			return err
+		}
+		block.StartsAt = input.StartDate.In(loc)
+		block.EndsAt = endDate.AddDays(1).In(loc)
+	} else {
+		if input.StartsAt == nil || input.EndsAt == nil || !input.EndsAt.After(*input.StartsAt) {
+			return exceptions.ErrTimeBlockInvalidRange
+		}
+		block.StartsAt = *input.StartsAt
+		block.EndsAt = *input.EndsAt
+	}
+
+	block.AllDay = input.AllDay
+	block.Reason = input.Reason
+	block.Note = input.Note
+	return nil
+}
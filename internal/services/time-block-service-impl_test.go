@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTimeBlockRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimeBlockRepository) Create(ctx context.Context, block *domain.TimeBlock) error {
+	args := m.Called(ctx, block)
+	return args.Error(0)
+}
+
+func (m *MockTimeBlockRepository) GetByID(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.TimeBlock, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.TimeBlock), args.Error(1)
+}
+
+func (m *MockTimeBlockRepository) List(ctx context.Context, centerID uuid.UUID, userID *uuid.UUID, from *time.Time, to *time.Time) ([]*domain.TimeBlock, error) {
+	args := m.Called(ctx, centerID, userID, from, to)
+	return args.Get(0).([]*domain.TimeBlock), args.Error(1)
+}
+
+func (m *MockTimeBlockRepository) ListInRange(ctx context.Context, centerID uuid.UUID, from time.Time, to time.Time) ([]*domain.TimeBlock, error) {
+	args := m.Called(ctx, centerID, from, to)
+	return args.Get(0).([]*domain.TimeBlock), args.Error(1)
+}
+
+func (m *MockTimeBlockRepository) Update(ctx context.Context, block *domain.TimeBlock) error {
+	args := m.Called(ctx, block)
+	return args.Error(0)
+}
+
+func (m *MockTimeBlockRepository) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	args := m.Called(ctx, centerID, id)
+	return args.Error(0)
+}
+
+func (m *MockTimeBlockRepository) SyncImported(ctx context.Context, centerID uuid.UUID, userID uuid.UUID, source domain.TimeBlockSource, from time.Time, uids []string, blocks []*domain.TimeBlock) (*domain.BusyImportResult, error) {
+	args := m.Called(ctx, centerID, userID, source, from, uids, blocks)
+	return args.Get(0).(*domain.BusyImportResult), args.Error(1)
+}
+
+func TestTimeBlockService_CreateAllDayAcrossDST(t *testing.T) {
+	// Setup
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	service := NewTimeBlockService(mockTimeBlockRepo, mockCentersRepo, new(MockMembershipRepository), new(mocks.LoggerMock))
+	centerID := uuid.New()
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, centerID).Return(&domain.Center{ID: centerID, Timezone: "Europe/Madrid"}, nil)
+	mockTimeBlockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TimeBlock")).Return(nil)
+
+	// Execute, clocks go forward on 2025-03-30
+	block, err := service.Create(context.Background(), centerID, nil, &domain.TimeBlockInput{
+		AllDay:    true,
+		StartDate: mustParseDate("2025-03-29"),
+		EndDate:   mustParseDate("2025-03-30"),
+		Reason:    domain.TimeBlockReasonHoliday,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, block.IsClosure())
+	assert.True(t, block.StartsAt.Equal(time.Date(2025, 3, 29, 0, 0, 0, 0, madrid)))
+	assert.True(t, block.EndsAt.Equal(time.Date(2025, 3, 31, 0, 0, 0, 0, madrid)))
+	assert.Equal(t, 47*time.Hour, block.EndsAt.Sub(block.StartsAt))
+}
+
+func TestTimeBlockService_CreateInvalid(t *testing.T) {
+	startsAt := time.Date(2025, 5, 5, 10, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		input domain.TimeBlockInput
+		err   error
+	}{
+		{"unknown reason", domain.TimeBlockInput{StartsAt: &endsAt, EndsAt: &startsAt, Reason: "nap"}, exceptions.ErrTimeBlockInvalidReason},
+		{"ends before it starts", domain.TimeBlockInput{StartsAt: &startsAt, EndsAt: &endsAt, Reason: domain.TimeBlockReasonPersonal}, exceptions.ErrTimeBlockInvalidRange},
+		{"missing end", domain.TimeBlockInput{StartsAt: &startsAt, Reason: domain.TimeBlockReasonPersonal}, exceptions.ErrTimeBlockInvalidRange},
+		{"all-day without start date", domain.TimeBlockInput{AllDay: true, Reason: domain.TimeBlockReasonVacation}, exceptions.ErrTimeBlockInvalidRange},
+		{"all-day ending before it starts", domain.TimeBlockInput{AllDay: true, StartDate: mustParseDate("2025-08-10"), EndDate: mustParseDate("2025-08-01"), Reason: domain.TimeBlockReasonVacation}, exceptions.ErrTimeBlockInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimeBlockRepo := new(MockTimeBlockRepository)
+			mockMembershipRepo := new(MockMembershipRepository)
+			service := NewTimeBlockService(mockTimeBlockRepo, new(MockCentersRepository), mockMembershipRepo, new(mocks.LoggerMock))
+			centerID, staffID := uuid.New(), uuid.New()
+
+			// Expectations
+			mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, centerID, staffID).Return(&domain.CenterMembership{CenterID: centerID, UserID: staffID, Role: domain.CenterRoleStaff}, nil)
+
+			// Execute
+			_, err := service.Create(context.Background(), centerID, &staffID, &tt.input)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.err)
+			mockTimeBlockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTimeBlockService_StaffCannotDeleteClosure(t *testing.T) {
+	// Setup
+	mockTimeBlockRepo := new(MockTimeBlockRepository)
+	service := NewTimeBlockService(mockTimeBlockRepo, new(MockCentersRepository), new(MockMembershipRepository), new(mocks.LoggerMock))
+	centerID, staffID := uuid.New(), uuid.New()
+	closure := &domain.TimeBlock{ID: uuid.New(), CenterID: centerID, Reason: domain.TimeBlockReasonHoliday}
+
+	// Expectations
+	mockTimeBlockRepo.On("GetByID", mock.Anything, centerID, closure.ID).Return(closure, nil)
+
+	// Execute
+	err := service.Delete(context.Background(), centerID, &staffID, closure.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrTimeBlockNotFound)
+	mockTimeBlockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,534 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/ports"
+	"bifur.app/core/internal/utils/random"
+	"bifur.app/core/internal/utils/token"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxWaitlistDays bounds the range of dates an entry waits for
+	maxWaitlistDays = 90
+	// expiredOffersBatch bounds the offers passed on by a single sweep
+	expiredOffersBatch = 100
+	offerTimeLayout    = "Monday 2 January 2006 at 15:04"
+)
+
+type WaitlistServiceImplementation struct {
+	waitlistRepo    ports.WaitlistRepository
+	centersRepo     ports.CentersRepository
+	membershipRepo  ports.MembershipRepository
+	appointmentRepo ports.AppointmentRepository
+	holdRepo        ports.SlotHoldRepository
+	leadRepo        ports.LeadRepository
+	slotService     ports.SlotService
+	scheduler       *appointmentScheduler
+	links           *appointmentLinkSigner
+	mailer          ports.Mailer
+	config          domain.BookingConfig
+	appURL          string
+	hashSecret      []byte
+	logger          ports.Logger
+}
+
+func NewWaitlistService(waitlistRepo ports.WaitlistRepository, centersRepo ports.CentersRepository, membershipRepo ports.MembershipRepository, seriesRepo ports.AppointmentSeriesRepository, appointmentRepo ports.AppointmentRepository, holdRepo ports.SlotHoldRepository, leadRepo ports.LeadRepository, slotService ports.SlotService, mailer ports.Mailer, config domain.BookingConfig, appURL string, linkSecret, hashSecret []byte, logger ports.Logger) ports.WaitlistService {
+	return &WaitlistServiceImplementation{
+		waitlistRepo:    waitlistRepo,
+		centersRepo:     centersRepo,
+		membershipRepo:  membershipRepo,
+		appointmentRepo: appointmentRepo,
+		holdRepo:        holdRepo,
+		leadRepo:        leadRepo,
+		slotService:     slotService,
+		scheduler: &appointmentScheduler{
+			centersRepo:    centersRepo,
+			membershipRepo: membershipRepo,
+			seriesRepo:     seriesRepo,
+			holdRepo:       holdRepo,
+		},
+		links:      &appointmentLinkSigner{appURL: appURL, secret: linkSecret},
+		mailer:     mailer,
+		config:     config,
+		appURL:     appURL,
+		hashSecret: hashSecret,
+		logger:     logger,
+	}
+}
+
+func (s *WaitlistServiceImplementation) List(ctx context.Context, centerID uuid.UUID, filter *domain.WaitlistFilter) ([]*domain.WaitlistEntry, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, exceptions.ErrWaitlistInvalidStatus
+	}
+	return s.waitlistRepo.ListEntries(ctx, centerID, filter)
+}
+
+func (s *WaitlistServiceImplementation) Create(ctx context.Context, centerID uuid.UUID, input *domain.CreateWaitlistEntryInput) (*domain.WaitlistEntry, error) {
+	center, err := s.centersRepo.GetByID(ctx, centerID)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.newEntry(ctx, center, &input.WaitlistEntryInput)
+	if err != nil {
+		return nil, err
+	}
+
+	lead, err := s.leadRepo.GetByID(ctx, centerID, input.LeadID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkReachable(ctx, lead)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.LeadID = lead.ID
+	err = s.waitlistRepo.CreateEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *WaitlistServiceImplementation) Delete(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	return s.waitlistRepo.DeleteEntry(ctx, centerID, id)
+}
+
+func (s *WaitlistServiceImplementation) Join(ctx context.Context, slug string, input *domain.JoinWaitlistInput, userAgent, ipAddress string) (*domain.WaitlistEntry, error) {
+	center, err := s.centersRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.newEntry(ctx, center, &input.WaitlistEntryInput)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := &domain.Lead{CenterID: center.ID, Name: input.Name}
+	err = setLeadContact(customer, input.Email, input.Phone)
+	if err != nil {
+		return nil, err
+	}
+	if customer.Email == "" {
+		return nil, exceptions.ErrWaitlistEmailRequired
+	}
+	consents := make([]*domain.LeadConsent, len(input.Consents))
+	communications := false
+	for i, consentInput := range input.Consents {
+		consents[i], err = newConsent(consentInput, domain.ConsentSourceBooking, nil, userAgent, ipAddress)
+		if err != nil {
+			return nil, err
+		}
+		if consents[i].Purpose == domain.ConsentPurposeCommunications {
+			communications = consents[i].Granted
+		}
+	}
+	if !communications {
+		return nil, exceptions.ErrWaitlistConsentRequired
+	}
+
+	lead, err := matchLead(ctx, s.leadRepo, customer)
+	if err != nil {
+		return nil, err
+	}
+	for _, consent := range consents {
+		consent.LeadID = lead.ID
+		err = s.leadRepo.CreateConsent(ctx, consent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entry.LeadID = lead.ID
+	err = s.waitlistRepo.CreateEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *WaitlistServiceImplementation) GetOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) (*domain.WaitlistOffer, error) {
+	_, offer, err := s.getOffer(ctx, slug, offerID, offerToken)
+	return offer, err
+}
+
+func (s *WaitlistServiceImplementation) ClaimOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) (*domain.Booking, error) {
+	center, offer, err := s.getOffer(ctx, slug, offerID, offerToken)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !offer.IsOpen(now) {
+		return nil, exceptions.ErrWaitlistOfferClosed
+	}
+
+	entry, err := s.waitlistRepo.GetEntry(ctx, center.ID, offer.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	lead, err := s.leadRepo.GetByID(ctx, center.ID, entry.LeadID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment := &domain.Appointment{
+		CenterID:      center.ID,
+		StaffID:       offer.StaffID,
+		ServiceID:     offer.ServiceID,
+		LeadID:        &lead.ID,
+		StartsAt:      offer.StartsAt,
+		Status:        domain.AppointmentStatusRequested,
+		CustomerName:  lead.Name,
+		CustomerEmail: lead.Email,
+		CustomerPhone: lead.Phone,
+		Notes:         entry.Notes,
+	}
+	err = s.scheduler.scheduleHeld(ctx, appointment, offer.HoldID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.appointmentRepo.Create(ctx, appointment)
+	if err != nil {
+		return nil, err
+	}
+
+	// The appointment is booked, what follows only tidies up after the offer
+	offer.Status = domain.WaitlistOfferStatusClaimed
+	offer.AppointmentID = &appointment.ID
+	offer.RespondedAt = &now
+	err = s.waitlistRepo.CloseOffer(ctx, offer, domain.WaitlistStatusBooked)
+	if err != nil {
+		s.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"offer_id":       offer.ID.String(),
+			"appointment_id": appointment.ID.String(),
+		})
+	}
+	s.releaseHold(ctx, offer)
+
+	return &domain.Booking{
+		AppointmentID: appointment.ID,
+		LeadID:        lead.ID,
+		StaffID:       appointment.StaffID,
+		ServiceID:     appointment.ServiceID,
+		StartsAt:      appointment.StartsAt,
+		EndsAt:        appointment.EndsAt,
+		Status:        appointment.Status,
+		Links:         s.links.links(appointment),
+	}, nil
+}
+
+func (s *WaitlistServiceImplementation) DeclineOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) error {
+	center, offer, err := s.getOffer(ctx, slug, offerID, offerToken)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if !offer.IsOpen(now) {
+		return exceptions.ErrWaitlistOfferClosed
+	}
+
+	offer.Status = domain.WaitlistOfferStatusDeclined
+	offer.RespondedAt = &now
+	err = s.waitlistRepo.CloseOffer(ctx, offer, domain.WaitlistStatusWaiting)
+	if err != nil {
+		return err
+	}
+	s.passOn(ctx, center, offer)
+	return nil
+}
+
+func (s *WaitlistServiceImplementation) OfferFreedSlot(ctx context.Context, appointment *domain.Appointment) error {
+	center, err := s.centersRepo.GetByID(ctx, appointment.CenterID)
+	if err != nil {
+		return err
+	}
+	return s.offerSlot(ctx, center, appointment.StaffID, appointment.StartsAt)
+}
+
+// ExpireOffers closes the expired offers one by one, so that another
+// instance sweeping at the same time passes each of them on only once
+func (s *WaitlistServiceImplementation) ExpireOffers(ctx context.Context, now time.Time) error {
+	offers, err := s.waitlistRepo.ListExpiredOffers(ctx, now, expiredOffersBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, offer := range offers {
+		offer.Status = domain.WaitlistOfferStatusExpired
+		offer.RespondedAt = &now
+		err = s.waitlistRepo.CloseOffer(ctx, offer, domain.WaitlistStatusWaiting)
+		if errors.Is(err, exceptions.ErrWaitlistOfferClosed) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		center, err := s.centersRepo.GetByID(ctx, offer.CenterID)
+		if err != nil {
+			s.logger.ErrorWithVar(ctx, err, map[string]interface{}{"offer_id": offer.ID.String()})
+			continue
+		}
+		s.passOn(ctx, center, offer)
+	}
+	return nil
+}
+
+// newEntry checks the service, the staff member and the dates of the input
+func (s *WaitlistServiceImplementation) newEntry(ctx context.Context, center *domain.Center, input *domain.WaitlistEntryInput) (*domain.WaitlistEntry, error) {
+	service, err := s.centersRepo.GetService(ctx, center.ID, input.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if !service.IsActive {
+		return nil, exceptions.ErrCenterServiceNotFound
+	}
+	if input.StaffID != nil {
+		_, err = s.membershipRepo.GetByCenterAndUser(ctx, center.ID, *input.StaffID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	loc, err := center.Location()
+	if err != nil {
+		return nil, err
+	}
+	today := domain.DateOf(time.Now().In(loc))
+	if input.FromDate.Before(today) || input.ToDate.Before(*input.FromDate) || input.ToDate.After(input.FromDate.AddDays(maxWaitlistDays)) {
+		return nil, exceptions.ErrWaitlistInvalidRange
+	}
+
+	return &domain.WaitlistEntry{
+		CenterID:  center.ID,
+		ServiceID: input.ServiceID,
+		StaffID:   input.StaffID,
+		FromDate:  *input.FromDate,
+		ToDate:    *input.ToDate,
+		Status:    domain.WaitlistStatusWaiting,
+		Notes:     input.Notes,
+	}, nil
+}
+
+// checkReachable tells whether offers can be sent to the lead: by email, and
+// with their consent to communications
+func (s *WaitlistServiceImplementation) checkReachable(ctx context.Context, lead *domain.Lead) error {
+	if lead.Email == "" {
+		return exceptions.ErrWaitlistEmailRequired
+	}
+	consents, err := s.leadRepo.ListConsents(ctx, lead.ID)
+	if err != nil {
+		return err
+	}
+	lead.Consents = domain.CurrentConsents(consents)
+	if !lead.HasConsent(domain.ConsentPurposeCommunications) {
+		return exceptions.ErrWaitlistConsentRequired
+	}
+	return nil
+}
+
+// getOffer returns the offer of the center, a wrong token is reported as a
+// missing offer
+func (s *WaitlistServiceImplementation) getOffer(ctx context.Context, slug string, offerID uuid.UUID, offerToken string) (*domain.Center, *domain.WaitlistOffer, error) {
+	center, err := s.centersRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	offer, err := s.waitlistRepo.GetOffer(ctx, center.ID, offerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(offer.TokenHash), []byte(token.Hash(offerToken, s.hashSecret))) != 1 {
+		return nil, nil, exceptions.ErrWaitlistOfferNotFound
+	}
+	return center, offer, nil
+}
+
+// passOn releases the slot of a closed offer and offers it to the next entry.
+// The offer is closed already, so failures are only logged.
+func (s *WaitlistServiceImplementation) passOn(ctx context.Context, center *domain.Center, offer *domain.WaitlistOffer) {
+	s.releaseHold(ctx, offer)
+	err := s.offerSlot(ctx, center, offer.StaffID, offer.StartsAt)
+	if err != nil {
+		s.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"offer_id": offer.ID.String(),
+		})
+	}
+}
+
+func (s *WaitlistServiceImplementation) releaseHold(ctx context.Context, offer *domain.WaitlistOffer) {
+	err := s.holdRepo.Delete(ctx, offer.CenterID, offer.HoldID)
+	if err != nil && !errors.Is(err, exceptions.ErrSlotHoldNotFound) {
+		s.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"offer_id": offer.ID.String(),
+			"hold_id":  offer.HoldID.String(),
+		})
+	}
+}
+
+// offerSlot offers the slot of the staff member starting at startsAt to the
+// first entry waiting for it that was not offered it yet. The slot must be
+// bookable for the service of the entry, so entries whose service doesn't
+// fit in the freed time are skipped. Centers without a slug can't be booked
+// online, their waitlist is only managed by their members.
+func (s *WaitlistServiceImplementation) offerSlot(ctx context.Context, center *domain.Center, staffID uuid.UUID, startsAt time.Time) error {
+	now := time.Now()
+	if center.Slug == "" || !startsAt.After(now) {
+		return nil
+	}
+	loc, err := center.Location()
+	if err != nil {
+		return err
+	}
+
+	date := domain.DateOf(startsAt.In(loc))
+	entries, err := s.waitlistRepo.ListWaiting(ctx, center.ID, date)
+	if err != nil {
+		return err
+	}
+	offers, err := s.waitlistRepo.ListSlotOffers(ctx, center.ID, staffID, startsAt)
+	if err != nil {
+		return err
+	}
+	offered := make(map[uuid.UUID]bool, len(offers))
+	for _, offer := range offers {
+		offered[offer.EntryID] = true
+	}
+
+	for _, entry := range entries {
+		if offered[entry.ID] || !entry.Wants(date, staffID) {
+			continue
+		}
+		lead, err := s.leadRepo.GetByID(ctx, center.ID, entry.LeadID)
+		if err != nil {
+			return err
+		}
+		err = s.checkReachable(ctx, lead)
+		if errors.Is(err, exceptions.ErrWaitlistEmailRequired) || errors.Is(err, exceptions.ErrWaitlistConsentRequired) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		slots, err := s.slotService.FindSlots(ctx, center.ID, &domain.SlotQuery{
+			ServiceID: entry.ServiceID,
+			StaffID:   &staffID,
+			From:      startsAt,
+			To:        startsAt.Add(slotGranularity),
+		})
+		if err != nil {
+			return err
+		}
+		slot := slotStartingAt(slots, startsAt)
+		if slot == nil {
+			continue
+		}
+
+		err = s.offer(ctx, center, entry, lead, slot, now)
+		switch {
+		case errors.Is(err, exceptions.ErrWaitlistEntryNotFound):
+			// Deleted or offered another slot in the meantime
+			continue
+		case errors.Is(err, exceptions.ErrSlotUnavailable):
+			// Held or offered by someone else in the meantime
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func slotStartingAt(slots []*domain.Slot, startsAt time.Time) *domain.Slot {
+	for _, slot := range slots {
+		if slot.StartsAt.Equal(startsAt) {
+			return slot
+		}
+	}
+	return nil
+}
+
+// offer holds the slot for the lead of the entry until the offer expires,
+// before the slot starts at the latest, and sends them the link to claim it
+func (s *WaitlistServiceImplementation) offer(ctx context.Context, center *domain.Center, entry *domain.WaitlistEntry, lead *domain.Lead, slot *domain.Slot, now time.Time) error {
+	expiresAt := now.Add(s.config.WaitlistOfferDuration)
+	if slot.StartsAt.Before(expiresAt) {
+		expiresAt = slot.StartsAt
+	}
+	offerToken := random.GenerateRandomString(holdTokenLength)
+	tokenHash := token.Hash(offerToken, s.hashSecret)
+
+	hold := &domain.SlotHold{
+		CenterID:  center.ID,
+		StaffID:   slot.StaffID,
+		ServiceID: entry.ServiceID,
+		StartsAt:  slot.StartsAt,
+		EndsAt:    slot.EndsAt,
+		ExpiresAt: expiresAt,
+		TokenHash: tokenHash,
+	}
+	err := s.holdRepo.Create(ctx, hold)
+	if err != nil {
+		return err
+	}
+
+	offer := &domain.WaitlistOffer{
+		CenterID:  center.ID,
+		EntryID:   entry.ID,
+		HoldID:    hold.ID,
+		StaffID:   slot.StaffID,
+		ServiceID: entry.ServiceID,
+		StartsAt:  slot.StartsAt,
+		EndsAt:    slot.EndsAt,
+		ExpiresAt: expiresAt,
+		Status:    domain.WaitlistOfferStatusPending,
+		TokenHash: tokenHash,
+	}
+	err = s.waitlistRepo.CreateOffer(ctx, offer)
+	if err != nil {
+		s.releaseHold(ctx, offer)
+		return err
+	}
+
+	s.sendOffer(ctx, center, lead, offer, offerToken)
+	return nil
+}
+
+// sendOffer emails the claim link of the offer. An offer whose email failed
+// is left to expire, the slot then goes to the next entry.
+func (s *WaitlistServiceImplementation) sendOffer(ctx context.Context, center *domain.Center, lead *domain.Lead, offer *domain.WaitlistOffer, offerToken string) {
+	service, err := s.centersRepo.GetService(ctx, center.ID, offer.ServiceID)
+	if err != nil {
+		s.logger.ErrorWithVar(ctx, err, map[string]interface{}{"offer_id": offer.ID.String()})
+		return
+	}
+	loc, err := center.Location()
+	if err != nil {
+		loc = time.UTC
+	}
+
+	link := fmt.Sprintf("%s/booking/%s/waitlist-offers/%s?token=%s", s.appURL, url.PathEscape(center.Slug), offer.ID, url.QueryEscape(offerToken))
+	message := &domain.EmailMessage{
+		To:      lead.Email,
+		Subject: fmt.Sprintf("A slot opened up at %s", center.Name),
+		Body: fmt.Sprintf("Hello %s,\n\nA slot for %s opened up on %s, and you are next on the waitlist.\n\n"+
+			"It is held for you until %s. Book it here:\n\n%s\n\n"+
+			"If you don't need it anymore, decline it from the same page so it goes to the next person waiting.\n",
+			lead.Name, service.Name, offer.StartsAt.In(loc).Format(offerTimeLayout), offer.ExpiresAt.In(loc).Format(offerTimeLayout), link),
+	}
+	err = s.mailer.Send(ctx, message)
+	if err != nil {
+		s.logger.ErrorWithVar(ctx, err, map[string]interface{}{
+			"offer_id": offer.ID.String(),
+			"lead_id":  lead.ID.String(),
+		})
+	}
+}
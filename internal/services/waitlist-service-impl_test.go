@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"bifur.app/core/internal/exceptions"
+	"bifur.app/core/internal/test-utils/mocks"
+	"bifur.app/core/internal/utils/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWaitlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistRepository) CreateEntry(ctx context.Context, entry *domain.WaitlistEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) GetEntry(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListEntries(ctx context.Context, centerID uuid.UUID, filter *domain.WaitlistFilter) ([]*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, centerID, filter)
+	return args.Get(0).([]*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListWaiting(ctx context.Context, centerID uuid.UUID, date domain.Date) ([]*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, centerID, date)
+	return args.Get(0).([]*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) DeleteEntry(ctx context.Context, centerID uuid.UUID, id uuid.UUID) error {
+	args := m.Called(ctx, centerID, id)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) CreateOffer(ctx context.Context, offer *domain.WaitlistOffer) error {
+	args := m.Called(ctx, offer)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) GetOffer(ctx context.Context, centerID uuid.UUID, id uuid.UUID) (*domain.WaitlistOffer, error) {
+	args := m.Called(ctx, centerID, id)
+	return args.Get(0).(*domain.WaitlistOffer), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListSlotOffers(ctx context.Context, centerID uuid.UUID, staffID uuid.UUID, startsAt time.Time) ([]*domain.WaitlistOffer, error) {
+	args := m.Called(ctx, centerID, staffID, startsAt)
+	return args.Get(0).([]*domain.WaitlistOffer), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListExpiredOffers(ctx context.Context, now time.Time, limit int) ([]*domain.WaitlistOffer, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]*domain.WaitlistOffer), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) CloseOffer(ctx context.Context, offer *domain.WaitlistOffer, entryStatus domain.WaitlistStatus) error {
+	args := m.Called(ctx, offer, entryStatus)
+	return args.Error(0)
+}
+
+var testWaitlistConfig = domain.BookingConfig{HoldDuration: 10 * time.Minute, WaitlistOfferDuration: 2 * time.Hour}
+
+// openOffer returns a pending offer of the center placed with offerToken
+func openOffer(centerID uuid.UUID, offerToken string) *domain.WaitlistOffer {
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	return &domain.WaitlistOffer{
+		ID:        uuid.New(),
+		CenterID:  centerID,
+		EntryID:   uuid.New(),
+		HoldID:    uuid.New(),
+		StaffID:   uuid.New(),
+		ServiceID: uuid.New(),
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(30 * time.Minute),
+		ExpiresAt: time.Now().Add(time.Hour),
+		Status:    domain.WaitlistOfferStatusPending,
+		TokenHash: token.Hash(offerToken, testHoldSecret),
+	}
+}
+
+func TestWaitlistService_OfferFreedSlot(t *testing.T) {
+	// Setup
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockLeadRepo := new(MockLeadRepository)
+	mockSlotService := new(MockSlotService)
+	mockMailer := new(MockMailer)
+	service := NewWaitlistService(mockWaitlistRepo, mockCentersRepo, new(MockMembershipRepository), newEmptySeriesRepository(), new(MockAppointmentRepository), mockHoldRepo, mockLeadRepo, mockSlotService, mockMailer, testWaitlistConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	staffID, otherStaffID, serviceID, longServiceID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	startsAt := time.Now().Add(time.Hour).Truncate(15 * time.Minute).Add(15 * time.Minute)
+	date := domain.DateOf(startsAt.In(madrid))
+	appointment := &domain.Appointment{ID: uuid.New(), CenterID: center.ID, StaffID: staffID, StartsAt: startsAt, EndsAt: startsAt.Add(30 * time.Minute)}
+	withdrawn := &domain.Lead{ID: uuid.New(), CenterID: center.ID, Name: "Ana García", Email: "ana@example.com"}
+	lead := &domain.Lead{ID: uuid.New(), CenterID: center.ID, Name: "Ana García", Email: "ana@example.com"}
+	entry := func(leadID uuid.UUID, serviceID uuid.UUID, staffID *uuid.UUID) *domain.WaitlistEntry {
+		return &domain.WaitlistEntry{ID: uuid.New(), CenterID: center.ID, LeadID: leadID, ServiceID: serviceID, StaffID: staffID, FromDate: date, ToDate: date, Status: domain.WaitlistStatusWaiting}
+	}
+	alreadyOffered := entry(lead.ID, serviceID, nil)
+	otherStaff := entry(lead.ID, serviceID, &otherStaffID)
+	noConsent := entry(withdrawn.ID, serviceID, nil)
+	tooLong := entry(lead.ID, longServiceID, nil)
+	next := entry(lead.ID, serviceID, &staffID)
+	slot := &domain.Slot{StaffID: staffID, StartsAt: startsAt, EndsAt: startsAt.Add(30 * time.Minute)}
+	var offerToken string
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockLeadRepo.On("GetByID", mock.Anything, center.ID, withdrawn.ID).Return(withdrawn, nil)
+	mockLeadRepo.On("ListConsents", mock.Anything, withdrawn.ID).Return([]*domain.LeadConsent{
+		{LeadID: withdrawn.ID, Purpose: domain.ConsentPurposeCommunications, Granted: false, CreatedAt: time.Now()},
+	}, nil)
+	mockLeadRepo.On("GetByID", mock.Anything, center.ID, lead.ID).Return(lead, nil)
+	mockLeadRepo.On("ListConsents", mock.Anything, lead.ID).Return([]*domain.LeadConsent{
+		{LeadID: lead.ID, Purpose: domain.ConsentPurposeCommunications, Granted: true, CreatedAt: time.Now()},
+	}, nil)
+	mockWaitlistRepo.On("ListWaiting", mock.Anything, center.ID, date).Return([]*domain.WaitlistEntry{alreadyOffered, otherStaff, noConsent, tooLong, next}, nil)
+	mockWaitlistRepo.On("ListSlotOffers", mock.Anything, center.ID, staffID, startsAt).Return([]*domain.WaitlistOffer{{EntryID: alreadyOffered.ID, Status: domain.WaitlistOfferStatusDeclined}}, nil)
+	mockSlotService.On("FindSlots", mock.Anything, center.ID, &domain.SlotQuery{ServiceID: longServiceID, StaffID: &staffID, From: startsAt, To: startsAt.Add(slotGranularity)}).Return([]*domain.Slot{}, nil)
+	mockSlotService.On("FindSlots", mock.Anything, center.ID, &domain.SlotQuery{ServiceID: serviceID, StaffID: &staffID, From: startsAt, To: startsAt.Add(slotGranularity)}).Return([]*domain.Slot{slot}, nil)
+	mockHoldRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SlotHold")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.SlotHold).ID = uuid.New()
+	}).Return(nil)
+	mockWaitlistRepo.On("CreateOffer", mock.Anything, mock.AnythingOfType("*domain.WaitlistOffer")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.WaitlistOffer).ID = uuid.New()
+	}).Return(nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, serviceID).Return(&domain.CenterService{ID: serviceID, Name: "Fisioterapia", DurationMinutes: 30, IsActive: true}, nil)
+	mockMailer.On("Send", mock.Anything, mock.AnythingOfType("*domain.EmailMessage")).Run(func(args mock.Arguments) {
+		body := args.Get(1).(*domain.EmailMessage).Body
+		if start := strings.Index(body, "?token="); start >= 0 {
+			offerToken, _ = url.QueryUnescape(strings.Fields(body[start+len("?token="):])[0])
+		}
+	}).Return(nil)
+
+	// Execute
+	err := service.OfferFreedSlot(context.Background(), appointment)
+
+	// Assert
+	assert.NoError(t, err)
+	hold := mockHoldRepo.Calls[0].Arguments.Get(1).(*domain.SlotHold)
+	offer := mockWaitlistRepo.Calls[2].Arguments.Get(1).(*domain.WaitlistOffer)
+	assert.Equal(t, next.ID, offer.EntryID)
+	assert.Equal(t, hold.ID, offer.HoldID)
+	assert.Equal(t, staffID, hold.StaffID)
+	assert.True(t, startsAt.Equal(offer.StartsAt))
+	// The slot starts before the offer would expire, the offer ends with it
+	assert.True(t, startsAt.Equal(offer.ExpiresAt))
+	assert.True(t, offer.ExpiresAt.Equal(hold.ExpiresAt))
+	assert.Equal(t, domain.WaitlistOfferStatusPending, offer.Status)
+	assert.Equal(t, offer.TokenHash, hold.TokenHash)
+	assert.Equal(t, token.Hash(offerToken, testHoldSecret), offer.TokenHash)
+	message := mockMailer.Calls[0].Arguments.Get(1).(*domain.EmailMessage)
+	assert.Equal(t, lead.Email, message.To)
+	assert.Contains(t, message.Body, "https://app.example.com/booking/clinica-sol/waitlist-offers/"+offer.ID.String()+"?token=")
+	assert.Contains(t, message.Body, "Fisioterapia")
+	mockHoldRepo.AssertNumberOfCalls(t, "Create", 1)
+	mockSlotService.AssertNumberOfCalls(t, "FindSlots", 2)
+}
+
+func TestWaitlistService_ClaimOffer(t *testing.T) {
+	// Setup
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockMembershipRepo := new(MockMembershipRepository)
+	mockAppointmentRepo := new(MockAppointmentRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	mockLeadRepo := new(MockLeadRepository)
+	service := NewWaitlistService(mockWaitlistRepo, mockCentersRepo, mockMembershipRepo, newEmptySeriesRepository(), mockAppointmentRepo, mockHoldRepo, mockLeadRepo, new(MockSlotService), new(MockMailer), testWaitlistConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	offer := openOffer(center.ID, "offer-token")
+	lead := &domain.Lead{ID: uuid.New(), CenterID: center.ID, Name: "Ana García", Email: "ana@example.com"}
+	entry := &domain.WaitlistEntry{ID: offer.EntryID, CenterID: center.ID, LeadID: lead.ID, ServiceID: offer.ServiceID, Status: domain.WaitlistStatusOffered, Notes: "Mornings only"}
+
+	// Expectations
+	mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+	mockWaitlistRepo.On("GetOffer", mock.Anything, center.ID, offer.ID).Return(offer, nil)
+	mockWaitlistRepo.On("GetEntry", mock.Anything, center.ID, entry.ID).Return(entry, nil)
+	mockMembershipRepo.On("GetByCenterAndUser", mock.Anything, center.ID, offer.StaffID).Return(&domain.CenterMembership{CenterID: center.ID, UserID: offer.StaffID, Role: domain.CenterRoleStaff}, nil)
+	mockLeadRepo.On("GetByID", mock.Anything, center.ID, lead.ID).Return(lead, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, offer.ServiceID).Return(&domain.CenterService{ID: offer.ServiceID, DurationMinutes: 30, IsActive: true}, nil)
+	mockHoldRepo.On("ListActiveInRange", mock.Anything, center.ID, mock.Anything, mock.Anything, mock.Anything).Return([]*domain.SlotHold{{ID: offer.HoldID, CenterID: center.ID, StaffID: offer.StaffID}}, nil)
+	mockAppointmentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Appointment")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Appointment).ID = uuid.New()
+	}).Return(nil)
+	mockWaitlistRepo.On("CloseOffer", mock.Anything, offer, domain.WaitlistStatusBooked).Return(nil)
+	mockHoldRepo.On("Delete", mock.Anything, center.ID, offer.HoldID).Return(nil)
+
+	// Execute
+	booking, err := service.ClaimOffer(context.Background(), center.Slug, offer.ID, "offer-token")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, lead.ID, booking.LeadID)
+	appointment := mockAppointmentRepo.Calls[0].Arguments.Get(1).(*domain.Appointment)
+	assert.Equal(t, appointment.ID, booking.AppointmentID)
+	assert.Equal(t, lead.ID, *appointment.LeadID)
+	assert.Equal(t, offer.StaffID, appointment.StaffID)
+	assert.True(t, offer.StartsAt.Equal(appointment.StartsAt))
+	assert.Equal(t, lead.Email, appointment.CustomerEmail)
+	assert.Equal(t, "Mornings only", appointment.Notes)
+	assert.Equal(t, domain.WaitlistOfferStatusClaimed, offer.Status)
+	assert.Equal(t, appointment.ID, *offer.AppointmentID)
+	assert.NotNil(t, offer.RespondedAt)
+	mockWaitlistRepo.AssertExpectations(t)
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestWaitlistService_ClaimOfferRejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		offer    func(offer *domain.WaitlistOffer)
+		expected error
+	}{
+		{"wrong token", "another-token", func(offer *domain.WaitlistOffer) {}, exceptions.ErrWaitlistOfferNotFound},
+		{"expired", "offer-token", func(offer *domain.WaitlistOffer) { offer.ExpiresAt = time.Now().Add(-time.Minute) }, exceptions.ErrWaitlistOfferClosed},
+		{"declined", "offer-token", func(offer *domain.WaitlistOffer) { offer.Status = domain.WaitlistOfferStatusDeclined }, exceptions.ErrWaitlistOfferClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockWaitlistRepo := new(MockWaitlistRepository)
+			mockCentersRepo := new(MockCentersRepository)
+			mockAppointmentRepo := new(MockAppointmentRepository)
+			service := NewWaitlistService(mockWaitlistRepo, mockCentersRepo, new(MockMembershipRepository), newEmptySeriesRepository(), mockAppointmentRepo, new(MockSlotHoldRepository), new(MockLeadRepository), new(MockSlotService), new(MockMailer), testWaitlistConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+			center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+			offer := openOffer(center.ID, "offer-token")
+			tt.offer(offer)
+
+			// Expectations
+			mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+			mockWaitlistRepo.On("GetOffer", mock.Anything, center.ID, offer.ID).Return(offer, nil)
+
+			// Execute
+			_, err := service.ClaimOffer(context.Background(), center.Slug, offer.ID, tt.token)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			mockAppointmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			mockWaitlistRepo.AssertNotCalled(t, "CloseOffer", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestWaitlistService_DeclineOfferPassesSlotOn(t *testing.T) {
+	// Setup
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	service := NewWaitlistService(mockWaitlistRepo, mockCentersRepo, new(MockMembershipRepository), newEmptySeriesRepository(), new(MockAppointmentRepository), mockHoldRepo, new(MockLeadRepository), new(MockSlotService), new(MockMailer), testWaitlistConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	offer := openOffer(center.ID, "offer-token")
+
+	// Expectations
+	mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+	mockWaitlistRepo.On("GetOffer", mock.Anything, center.ID, offer.ID).Return(offer, nil)
+	mockWaitlistRepo.On("CloseOffer", mock.Anything, offer, domain.WaitlistStatusWaiting).Return(nil)
+	mockHoldRepo.On("Delete", mock.Anything, center.ID, offer.HoldID).Return(nil)
+	mockWaitlistRepo.On("ListWaiting", mock.Anything, center.ID, mock.Anything).Return([]*domain.WaitlistEntry{}, nil)
+	mockWaitlistRepo.On("ListSlotOffers", mock.Anything, center.ID, offer.StaffID, offer.StartsAt).Return([]*domain.WaitlistOffer{offer}, nil)
+
+	// Execute
+	err := service.DeclineOffer(context.Background(), center.Slug, offer.ID, "offer-token")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WaitlistOfferStatusDeclined, offer.Status)
+	mockWaitlistRepo.AssertExpectations(t)
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestWaitlistService_ExpireOffersSkipsClosedOnes(t *testing.T) {
+	// Setup
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockHoldRepo := new(MockSlotHoldRepository)
+	service := NewWaitlistService(mockWaitlistRepo, mockCentersRepo, new(MockMembershipRepository), newEmptySeriesRepository(), new(MockAppointmentRepository), mockHoldRepo, new(MockLeadRepository), new(MockSlotService), new(MockMailer), testWaitlistConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	now := time.Now()
+	closed := openOffer(center.ID, "closed-token")
+	expired := openOffer(center.ID, "expired-token")
+
+	// Expectations
+	mockCentersRepo.On("GetByID", mock.Anything, center.ID).Return(center, nil)
+	mockWaitlistRepo.On("ListExpiredOffers", mock.Anything, now, expiredOffersBatch).Return([]*domain.WaitlistOffer{closed, expired}, nil)
+	mockWaitlistRepo.On("CloseOffer", mock.Anything, closed, domain.WaitlistStatusWaiting).Return(exceptions.ErrWaitlistOfferClosed)
+	mockWaitlistRepo.On("CloseOffer", mock.Anything, expired, domain.WaitlistStatusWaiting).Return(nil)
+	mockHoldRepo.On("Delete", mock.Anything, center.ID, expired.HoldID).Return(exceptions.ErrSlotHoldNotFound)
+	mockWaitlistRepo.On("ListWaiting", mock.Anything, center.ID, mock.Anything).Return([]*domain.WaitlistEntry{}, nil)
+	mockWaitlistRepo.On("ListSlotOffers", mock.Anything, center.ID, expired.StaffID, expired.StartsAt).Return([]*domain.WaitlistOffer{expired}, nil)
+
+	// Execute
+	err := service.ExpireOffers(context.Background(), now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WaitlistOfferStatusExpired, expired.Status)
+	mockHoldRepo.AssertNotCalled(t, "Delete", mock.Anything, center.ID, closed.HoldID)
+	mockWaitlistRepo.AssertNumberOfCalls(t, "ListWaiting", 1)
+}
+
+func TestWaitlistService_JoinRequiresConsentToCommunications(t *testing.T) {
+	// Setup
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockCentersRepo := new(MockCentersRepository)
+	mockLeadRepo := new(MockLeadRepository)
+	service := NewWaitlistService(mockWaitlistRepo, mockCentersRepo, new(MockMembershipRepository), newEmptySeriesRepository(), new(MockAppointmentRepository), new(MockSlotHoldRepository), mockLeadRepo, new(MockSlotService), new(MockMailer), testWaitlistConfig, "https://app.example.com", testLinkSecret, testHoldSecret, new(mocks.LoggerMock))
+	center := &domain.Center{ID: uuid.New(), Name: "Clínica Sol", Slug: "clinica-sol", Timezone: "Europe/Madrid"}
+	serviceID := uuid.New()
+	tomorrow := domain.DateOf(time.Now().In(time.UTC)).AddDays(1)
+	input := &domain.JoinWaitlistInput{
+		WaitlistEntryInput: domain.WaitlistEntryInput{ServiceID: serviceID, FromDate: &tomorrow, ToDate: &tomorrow},
+		Name:               "Ana García",
+		Email:              "ana@example.com",
+		Consents:           []*domain.ConsentInput{{Purpose: domain.ConsentPurposeDataProcessing, Granted: true, PolicyVersion: "2024-01"}},
+	}
+
+	// Expectations
+	mockCentersRepo.On("GetBySlug", mock.Anything, center.Slug).Return(center, nil)
+	mockCentersRepo.On("GetService", mock.Anything, center.ID, serviceID).Return(&domain.CenterService{ID: serviceID, DurationMinutes: 30, IsActive: true}, nil)
+
+	// Execute
+	_, err := service.Join(context.Background(), center.Slug, input, "Mozilla/5.0", "203.0.113.7")
+
+	// Assert
+	assert.ErrorIs(t, err, exceptions.ErrWaitlistConsentRequired)
+	mockLeadRepo.AssertNotCalled(t, "FindByContact", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockWaitlistRepo.AssertNotCalled(t, "CreateEntry", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,43 @@
+package device
+
+import (
+	"strings"
+
+	"bifur.app/core/internal/domain"
+	"github.com/mssola/useragent"
+)
+
+// Parse extracts browser, operating system and device type from a User-Agent header
+func Parse(userAgent string) domain.DeviceInfo {
+	if strings.TrimSpace(userAgent) == "" {
+		return domain.DeviceInfo{DeviceType: domain.DeviceTypeUnknown}
+	}
+
+	ua := useragent.New(userAgent)
+	browser, version := ua.Browser()
+	os := ua.OSInfo()
+
+	return domain.DeviceInfo{
+		Browser:        browser,
+		BrowserVersion: version,
+		OS:             strings.TrimSpace(os.Name + " " + os.Version),
+		DeviceType:     detectDeviceType(ua, userAgent),
+	}
+}
+
+func detectDeviceType(ua *useragent.UserAgent, raw string) string {
+	lower := strings.ToLower(raw)
+	switch {
+	case ua.Bot():
+		return domain.DeviceTypeBot
+	case strings.Contains(lower, "ipad") || strings.Contains(lower, "tablet"):
+		return domain.DeviceTypeTablet
+	// Android tablets do not advertise "Mobile" in their user agent
+	case strings.Contains(lower, "android") && !strings.Contains(lower, "mobile"):
+		return domain.DeviceTypeTablet
+	case ua.Mobile():
+		return domain.DeviceTypeMobile
+	default:
+		return domain.DeviceTypeDesktop
+	}
+}
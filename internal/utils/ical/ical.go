@@ -0,0 +1,133 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// RFC 5545 value layouts
+const (
+	DateLayout      = "20060102"
+	LocalLayout     = "20060102T150405"
+	UTCLayout       = "20060102T150405Z"
+	maxLineOctets   = 75
+	ContentTypeICal = "text/calendar; charset=utf-8"
+)
+
+// Param is a property parameter, e.g. TZID=Europe/Madrid
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Property is a content line of a component. Value is written as is, text
+// values must be escaped with Text.
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string
+}
+
+// Component is a calendar component such as VCALENDAR, VEVENT or VTIMEZONE
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Add appends a property with a raw value
+func (c *Component) Add(name string, value string, params ...Param) {
+	c.Properties = append(c.Properties, Property{Name: name, Params: params, Value: value})
+}
+
+// AddText appends a property with a text value, escaping it
+func (c *Component) AddText(name string, value string) {
+	c.Add(name, Text(value))
+}
+
+// AddUTC appends a date-time property in UTC
+func (c *Component) AddUTC(name string, t time.Time) {
+	c.Add(name, t.UTC().Format(UTCLayout))
+}
+
+// AddLocal appends a date-time property with the local time of t and the
+// TZID of its location
+func (c *Component) AddLocal(name string, t time.Time) {
+	c.Add(name, t.Format(LocalLayout), Param{Name: "TZID", Value: t.Location().String()})
+}
+
+func (c *Component) AddComponent(component *Component) {
+	c.Components = append(c.Components, component)
+}
+
+// Encode writes the component with CRLF line endings, folding the lines
+// longer than 75 octets
+func (c *Component) Encode() []byte {
+	var buf bytes.Buffer
+	c.encode(&buf)
+	return buf.Bytes()
+}
+
+func (c *Component) encode(buf *bytes.Buffer) {
+	writeLine(buf, "BEGIN:"+c.Name)
+	for _, property := range c.Properties {
+		var line strings.Builder
+		line.WriteString(property.Name)
+		for _, param := range property.Params {
+			line.WriteString(";" + param.Name + "=" + paramValue(param.Value))
+		}
+		line.WriteString(":" + property.Value)
+		writeLine(buf, line.String())
+	}
+	for _, component := range c.Components {
+		component.encode(buf)
+	}
+	writeLine(buf, "END:"+c.Name)
+}
+
+// writeLine folds the line without splitting a UTF-8 sequence, continuation
+// lines start with a space
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line + "\r\n")
+}
+
+// paramValue quotes the values with characters not allowed unquoted
+func paramValue(value string) string {
+	if strings.ContainsAny(value, ":;,") {
+		return `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+	return value
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// Text escapes a TEXT value
+func Text(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// FormatOffset writes a UTC offset in seconds as +HHMM
+func FormatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
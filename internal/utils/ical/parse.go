@@ -0,0 +1,242 @@
+package ical
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCalendar = errors.New("invalid iCalendar data")
+	ErrInvalidValue    = errors.New("invalid iCalendar value")
+)
+
+// Parse reads an iCalendar document into its top level component, usually a
+// VCALENDAR. Lines may end with CRLF or LF.
+func Parse(data []byte) (*Component, error) {
+	var root *Component
+	var stack []*Component
+	for _, line := range unfold(string(data)) {
+		if line == "" {
+			continue
+		}
+		property, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch property.Name {
+		case "BEGIN":
+			component := NewComponent(strings.ToUpper(property.Value))
+			if len(stack) > 0 {
+				stack[len(stack)-1].AddComponent(component)
+			} else if root != nil {
+				return nil, ErrInvalidCalendar
+			}
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(property.Value) {
+				return nil, ErrInvalidCalendar
+			}
+			if len(stack) == 1 {
+				root = stack[0]
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, ErrInvalidCalendar
+			}
+			current := stack[len(stack)-1]
+			current.Properties = append(current.Properties, *property)
+		}
+	}
+	if root == nil || len(stack) > 0 {
+		return nil, ErrInvalidCalendar
+	}
+	return root, nil
+}
+
+// unfold joins the continuation lines, which start with a space or a tab
+func unfold(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	var lines []string
+	for _, line := range strings.Split(data, "\n") {
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+	}
+	return lines
+}
+
+// parseLine splits a content line into its name, parameters and value. The
+// value starts at the first colon outside of a quoted parameter value.
+func parseLine(line string) (*Property, error) {
+	quoted := false
+	valueStart := -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			valueStart = i
+			break
+		}
+	}
+	if valueStart <= 0 {
+		return nil, ErrInvalidCalendar
+	}
+
+	parts := splitUnquoted(line[:valueStart], ';')
+	property := &Property{Name: strings.ToUpper(parts[0]), Value: line[valueStart+1:]}
+	for _, part := range parts[1:] {
+		name, value, found := strings.Cut(part, "=")
+		if !found {
+			return nil, ErrInvalidCalendar
+		}
+		property.Params = append(property.Params, Param{Name: strings.ToUpper(name), Value: strings.Trim(value, `"`)})
+	}
+	return property, nil
+}
+
+func splitUnquoted(value string, separator rune) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i, c := range value {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == separator && !quoted {
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// Get returns the first property with the name
+func (c *Component) Get(name string) (*Property, bool) {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i], true
+		}
+	}
+	return nil, false
+}
+
+// GetAll returns every property with the name, e.g. the EXDATE of an event
+func (c *Component) GetAll(name string) []*Property {
+	var properties []*Property
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			properties = append(properties, &c.Properties[i])
+		}
+	}
+	return properties
+}
+
+// Param returns the value of the parameter of the property
+func (p *Property) Param(name string) string {
+	for _, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// Times reads a DATE or DATE-TIME property, which may hold several comma
+// separated values. UTC times end with Z, the others are in the location of
+// their TZID, or in defaultLoc when it is missing or unknown. Dates are the
+// midnights of defaultLoc and tell so with isDate.
+func (p *Property) Times(defaultLoc *time.Location) (times []time.Time, isDate bool, err error) {
+	loc := defaultLoc
+	if tzid := p.Param("TZID"); tzid != "" {
+		if tzLoc, err := time.LoadLocation(tzid); err == nil {
+			loc = tzLoc
+		}
+	}
+	isDate = strings.EqualFold(p.Param("VALUE"), "DATE")
+
+	for _, value := range strings.Split(p.Value, ",") {
+		value = strings.TrimSpace(value)
+		var t time.Time
+		switch {
+		case isDate || len(value) == len(DateLayout):
+			isDate = true
+			t, err = time.ParseInLocation(DateLayout, value, defaultLoc)
+		case strings.HasSuffix(value, "Z"):
+			t, err = time.Parse(UTCLayout, value)
+		default:
+			t, err = time.ParseInLocation(LocalLayout, value, loc)
+		}
+		if err != nil {
+			return nil, false, ErrInvalidValue
+		}
+		times = append(times, t)
+	}
+	return times, isDate, nil
+}
+
+// Time reads a property holding a single DATE or DATE-TIME, see Times
+func (p *Property) Time(defaultLoc *time.Location) (time.Time, bool, error) {
+	times, isDate, err := p.Times(defaultLoc)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return times[0], isDate, nil
+}
+
+// ParseDuration reads a DURATION value such as PT1H30M or P1D. Days and
+// weeks count as 24 hours.
+func ParseDuration(value string) (time.Duration, error) {
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign, value = -1, value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, ErrInvalidValue
+	}
+
+	var duration time.Duration
+	inTime := false
+	number := ""
+	for _, c := range value[1:] {
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, ErrInvalidValue
+		}
+		number = ""
+		switch {
+		case c == 'W' && !inTime:
+			duration += time.Duration(n) * 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			duration += time.Duration(n) * 24 * time.Hour
+		case c == 'H' && inTime:
+			duration += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			duration += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			duration += time.Duration(n) * time.Second
+		default:
+			return 0, ErrInvalidValue
+		}
+	}
+	if number != "" {
+		return 0, ErrInvalidValue
+	}
+	return sign * duration, nil
+}
@@ -0,0 +1,57 @@
+package ical
+
+import "time"
+
+// Timezone describes the location as a VTIMEZONE with an observance for each
+// offset change between from and to, as found in the tz database. The first
+// observance starts at from, so every local time of the range is covered.
+func Timezone(loc *time.Location, from time.Time, to time.Time) *Component {
+	timezone := NewComponent("VTIMEZONE")
+	timezone.Add("TZID", loc.String())
+
+	current := from.In(loc)
+	name, offset := current.Zone()
+	timezone.AddComponent(observance(current, current.Format(LocalLayout), offset))
+
+	// Offsets change at most once a day, each change is then looked for to
+	// the second
+	for day := current; day.Before(to); {
+		next := day.Add(24 * time.Hour)
+		if nextName, nextOffset := next.Zone(); nextOffset == offset && nextName == name {
+			day = next
+			continue
+		}
+
+		low, high := day, next
+		for high.Sub(low) > time.Second {
+			middle := low.Add(high.Sub(low) / 2)
+			if middleName, middleOffset := middle.Zone(); middleOffset == offset && middleName == name {
+				low = middle
+			} else {
+				high = middle
+			}
+		}
+		// DTSTART is the local time in the offset being left
+		start := high.UTC().Add(time.Duration(offset) * time.Second).Format(LocalLayout)
+		timezone.AddComponent(observance(high, start, offset))
+		name, offset = high.Zone()
+		day = high
+	}
+	return timezone
+}
+
+// observance is the STANDARD or DAYLIGHT component for the offset in effect at
+// t, which changes from offsetFrom
+func observance(t time.Time, start string, offsetFrom int) *Component {
+	kind := "STANDARD"
+	if t.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, offset := t.Zone()
+	component := NewComponent(kind)
+	component.Add("DTSTART", start)
+	component.Add("TZOFFSETFROM", FormatOffset(offsetFrom))
+	component.Add("TZOFFSETTO", FormatOffset(offset))
+	component.AddText("TZNAME", name)
+	return component
+}
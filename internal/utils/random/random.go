@@ -1,16 +1,32 @@
 package random
 
 import (
-	"math/rand"
-	"time"
+	"crypto/rand"
 )
 
+const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// maxUnbiased is the largest multiple of len(charset) that fits in a byte,
+// bytes above it are discarded to keep the distribution uniform.
+const maxUnbiased = 256 - (256 % len(charset))
+
+// GenerateRandomString returns an alphanumeric string read from crypto/rand,
+// suitable for secrets such as refresh tokens.
 func GenerateRandomString(length int) string {
-	seededRand := rand.New(rand.NewSource(time.Now().UnixNano()))
-	charset := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = charset[seededRand.Intn(len(charset))]
+	b := make([]byte, 0, length)
+	buf := make([]byte, length)
+	for len(b) < length {
+		// crypto/rand.Read never returns an error and never short-reads
+		_, _ = rand.Read(buf)
+		for _, c := range buf {
+			if int(c) >= maxUnbiased {
+				continue
+			}
+			b = append(b, charset[int(c)%len(charset)])
+			if len(b) == length {
+				break
+			}
+		}
 	}
 	return string(b)
 }
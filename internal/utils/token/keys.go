@@ -0,0 +1,294 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"bifur.app/core/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// legacyKeyID identifies the shared secret key, tokens signed before key IDs
+// were introduced carry no kid and resolve to it
+const legacyKeyID = "default"
+
+var (
+	ErrNoSigningKey   = errors.New("no active signing key")
+	ErrUnknownKeyID   = errors.New("unknown signing key")
+	ErrInvalidKeyFile = errors.New("invalid signing key file")
+)
+
+// SigningKey is a key used to sign or verify access tokens. A key signs tokens
+// from ActivateAt until a newer key is activated, and is accepted for
+// verification until RetireAt.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	ActivateAt time.Time
+	RetireAt   time.Time
+	// signer is nil for verify-only keys
+	signer   interface{}
+	verifier interface{}
+	method   jwt.SigningMethod
+}
+
+// CanSign tells whether the private part of the key is available
+func (k *SigningKey) CanSign() bool {
+	return k.signer != nil
+}
+
+func (k *SigningKey) retired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+// KeySet holds every access token key known to the service
+type KeySet struct {
+	keys []*SigningKey
+}
+
+// NewHMACKeySet returns a key set with a single shared secret, the behaviour
+// of the service when no asymmetric keys are configured
+func NewHMACKeySet(secret []byte) *KeySet {
+	return &KeySet{keys: []*SigningKey{{
+		ID:        legacyKeyID,
+		Algorithm: AlgorithmHS256,
+		signer:    secret,
+		verifier:  secret,
+		method:    jwt.SigningMethodHS256,
+	}}}
+}
+
+// keyManifest is the JSON file listing the asymmetric keys and their rotation
+// schedule. Key file paths are relative to the manifest.
+type keyManifest struct {
+	Keys []struct {
+		ID             string    `json:"kid"`
+		Algorithm      string    `json:"alg"`
+		PrivateKeyFile string    `json:"private_key_file"`
+		PublicKeyFile  string    `json:"public_key_file"`
+		ActivateAt     time.Time `json:"activate_at"`
+		RetireAt       time.Time `json:"retire_at"`
+	} `json:"keys"`
+}
+
+// LoadKeySet reads a key manifest and the PEM files it references
+func LoadKeySet(manifestPath string) (*KeySet, error) {
+	raw, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest keyManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyFile, err)
+	}
+
+	baseDir := filepath.Dir(manifestPath)
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(baseDir, path)
+	}
+
+	set := &KeySet{}
+	seen := map[string]bool{}
+	for _, entry := range manifest.Keys {
+		if entry.ID == "" || seen[entry.ID] {
+			return nil, fmt.Errorf("%w: missing or duplicated kid %q", ErrInvalidKeyFile, entry.ID)
+		}
+		seen[entry.ID] = true
+
+		key, err := newSigningKey(entry.ID, entry.Algorithm, resolve(entry.PrivateKeyFile), resolve(entry.PublicKeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", entry.ID, err)
+		}
+		key.ActivateAt = entry.ActivateAt
+		key.RetireAt = entry.RetireAt
+		set.keys = append(set.keys, key)
+	}
+
+	if len(set.keys) == 0 {
+		return nil, fmt.Errorf("%w: no keys", ErrInvalidKeyFile)
+	}
+	return set, nil
+}
+
+func newSigningKey(id, algorithm, privateKeyFile, publicKeyFile string) (*SigningKey, error) {
+	key := &SigningKey{ID: id, Algorithm: algorithm}
+
+	switch algorithm {
+	case AlgorithmRS256:
+		key.method = jwt.SigningMethodRS256
+	case AlgorithmEdDSA:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidKeyFile, algorithm)
+	}
+
+	var public crypto.PublicKey
+	switch {
+	case privateKeyFile != "":
+		private, err := readPrivateKey(privateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		key.signer = private
+		public = private.Public()
+	case publicKeyFile != "":
+		var err error
+		public, err = readPublicKey(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: a private or public key file is required", ErrInvalidKeyFile)
+	}
+
+	switch public := public.(type) {
+	case *rsa.PublicKey:
+		if algorithm != AlgorithmRS256 {
+			return nil, fmt.Errorf("%w: RSA key used with %s", ErrInvalidKeyFile, algorithm)
+		}
+		if public.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("%w: RSA keys must be at least 2048 bits", ErrInvalidKeyFile)
+		}
+	case ed25519.PublicKey:
+		if algorithm != AlgorithmEdDSA {
+			return nil, fmt.Errorf("%w: Ed25519 key used with %s", ErrInvalidKeyFile, algorithm)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKeyFile, public)
+	}
+	key.verifier = public
+
+	return key, nil
+}
+
+func readPEMBlock(path string) (*pem.Block, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s is not PEM encoded", ErrInvalidKeyFile, path)
+	}
+	return block, nil
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported private key in %s", ErrInvalidKeyFile, path)
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: cannot parse private key in %s", ErrInvalidKeyFile, path)
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: cannot parse public key in %s", ErrInvalidKeyFile, path)
+}
+
+// SigningKey returns the key new tokens are signed with: the most recently
+// activated key that has a private part and is not retired
+func (s *KeySet) SigningKey(now time.Time) (*SigningKey, error) {
+	var active *SigningKey
+	for _, key := range s.keys {
+		if !key.CanSign() || key.retired(now) || now.Before(key.ActivateAt) {
+			continue
+		}
+		if active == nil || key.ActivateAt.After(active.ActivateAt) {
+			active = key
+		}
+	}
+	if active == nil {
+		return nil, ErrNoSigningKey
+	}
+	return active, nil
+}
+
+// VerificationKey returns the key identified by kid, as long as it is not
+// retired. Keys scheduled for a future activation are already accepted so
+// instances that rotate slightly earlier do not break the others.
+func (s *KeySet) VerificationKey(kid string, now time.Time) (*SigningKey, error) {
+	if kid == "" {
+		kid = legacyKeyID
+	}
+	for _, key := range s.keys {
+		if key.ID == kid && !key.retired(now) {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownKeyID
+}
+
+// JWKS returns the public keys that are not retired, including the ones
+// scheduled for a future activation so verifiers can cache them in advance.
+// Shared secret keys are never published.
+func (s *KeySet) JWKS(now time.Time) domain.JWKS {
+	keys := make([]*SigningKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		if key.Algorithm != AlgorithmHS256 && !key.retired(now) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ActivateAt.After(keys[j].ActivateAt) })
+
+	jwks := domain.JWKS{Keys: make([]domain.JWK, 0, len(keys))}
+	for _, key := range keys {
+		jwk := domain.JWK{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+		switch public := key.verifier.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.Modulus = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
@@ -1,6 +1,10 @@
 package token
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
@@ -17,17 +21,25 @@ var (
 	ErrAuthHeaderMissing = errors.New("authentication required")
 	ErrInvalidAuthFormat = errors.New("authorization header format must be bearer {token}")
 	ErrInvalidToken      = errors.New("invalid or expired token")
+	ErrInvalidSignature  = errors.New("invalid signature")
 )
 
 // JWTClaims holds the standard JWT claims plus our custom claims
 
-// ValidateToken validates a JWT token string and returns the claims
-func ValidateToken(tokenString string, secret []byte) (*domain.JWTClaims, error) {
+// ValidateToken validates a JWT token string and returns the claims. The
+// verification key is picked by the kid header and must match the algorithm
+// of the token.
+func ValidateToken(tokenString string, keys *KeySet) (*domain.JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &domain.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, err := keys.VerificationKey(kid, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return secret, nil
+		return key.verifier, nil
 	})
 
 	if err != nil {
@@ -41,8 +53,14 @@ func ValidateToken(tokenString string, secret []byte) (*domain.JWTClaims, error)
 	return nil, ErrInvalidToken
 }
 
-// GenerateToken creates a new JWT token for a user
-func GenerateToken(userID uuid.UUID, email string, secret []byte, expiration time.Duration, sourceID string) (string, error) {
+// GenerateToken creates a new JWT token for a user, signed with the active
+// key of the set
+func GenerateToken(userID uuid.UUID, email string, keys *KeySet, expiration time.Duration, sourceID string) (string, error) {
+	key, err := keys.SigningKey(time.Now())
+	if err != nil {
+		return "", err
+	}
+
 	claims := domain.JWTClaims{
 		UserID:   userID,
 		Email:    email,
@@ -54,8 +72,11 @@ func GenerateToken(userID uuid.UUID, email string, secret []byte, expiration tim
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(secret)
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.ID != legacyKeyID {
+		token.Header["kid"] = key.ID
+	}
+	signedToken, err := token.SignedString(key.signer)
 	if err != nil {
 		return "", err
 	}
@@ -87,11 +108,51 @@ func ExtractToken(c *gin.Context) (string, error) {
 }
 
 // ExtractAndValidateToken combines extraction and validation
-func ExtractAndValidateToken(c *gin.Context, secret []byte) (*domain.JWTClaims, error) {
+func ExtractAndValidateToken(c *gin.Context, keys *KeySet) (*domain.JWTClaims, error) {
 	tokenString, err := ExtractToken(c)
 	if err != nil {
 		return nil, err
 	}
 
-	return ValidateToken(tokenString, secret)
+	return ValidateToken(tokenString, keys)
+}
+
+// Hash returns the hex encoded HMAC-SHA256 of an opaque token, so it can be
+// stored and looked up without keeping the token itself at rest
+func Hash(value string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeriveKey returns the key of a secret for a single purpose, so a token
+// hashed or signed for one purpose is never accepted for another
+func DeriveKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// Sign returns the payload along with its HMAC, as the base64url encoded
+// payload and the hex encoded Hash of it, joined by a dot. The payload is
+// readable by anyone holding the signed value, it must not be a secret.
+func Sign(payload string, secret []byte) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + Hash(payload, secret)
+}
+
+// Verify returns the payload of a value returned by Sign, or
+// ErrInvalidSignature when it wasn't signed with the secret
+func Verify(signed string, secret []byte) (string, error) {
+	encoded, signature, found := strings.Cut(signed, ".")
+	if !found {
+		return "", ErrInvalidSignature
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(Hash(string(payload), secret))) {
+		return "", ErrInvalidSignature
+	}
+	return string(payload), nil
 }